@@ -1,7 +1,20 @@
 package main
 
-import "AVITOSAMPISHU/internal/app"
+import (
+	"os"
+
+	"AVITOSAMPISHU/internal/app"
+)
 
 func main() {
-	app.Run()
+	args := os.Args[1:]
+
+	switch {
+	case len(args) > 0 && args[0] == "migrate":
+		os.Exit(app.Migrate(args[1:]))
+	case len(args) > 1 && args[0] == "config" && args[1] == "print":
+		os.Exit(app.PrintConfig(args[2:]))
+	default:
+		os.Exit(app.Run(args))
+	}
 }
@@ -0,0 +1,67 @@
+//go:build integration
+
+package integration_tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"AVITOSAMPISHU/internal/domain"
+	apikey_repository "AVITOSAMPISHU/internal/repository/apikey_repository"
+	apikey_service "AVITOSAMPISHU/internal/service/apikey_service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationAPIKeys(t *testing.T) {
+	truncateAll(t)
+
+	svc := apikey_service.NewAPIKeyService(apikey_repository.NewAPIKeyStorage(testDB))
+	admin := domain.ContextWithActor(context.Background(), domain.Actor{ID: domain.AdminActorID, Role: domain.RoleAdmin})
+
+	key, err := svc.CreateAPIKey(admin, &domain.CreateAPIKeyReq{
+		Name:   "ci-bot",
+		Scopes: []domain.APIKeyScope{domain.ScopePullRequestWrite},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, key.Key)
+
+	// В БД хранится только хеш
+	var stored int
+	require.NoError(t, testDB.QueryRow(`SELECT COUNT(*) FROM api_keys WHERE key_hash = $1`, key.Key).Scan(&stored))
+	assert.Zero(t, stored)
+
+	actor, err := svc.Authenticate(context.Background(), key.Key)
+	require.NoError(t, err)
+	assert.Equal(t, key.ActorID(), actor.ID)
+	assert.True(t, actor.HasScope(domain.ScopePullRequestWrite))
+
+	keys, err := svc.ListAPIKeys(admin)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Empty(t, keys[0].Key)
+	assert.Equal(t, key.Prefix, keys[0].Prefix)
+	assert.NotNil(t, keys[0].LastUsedAt)
+
+	revoked, err := svc.RevokeAPIKey(admin, &domain.RevokeAPIKeyReq{ID: key.ID})
+	require.NoError(t, err)
+	require.NotNil(t, revoked.RevokedAt)
+
+	_, err = svc.Authenticate(context.Background(), key.Key)
+	assert.ErrorIs(t, err, domain.ErrUnauthorized)
+
+	// Истёкший ключ отклоняется так же, как отозванный
+	expiring, err := svc.CreateAPIKey(admin, &domain.CreateAPIKeyReq{
+		Name:   "short-lived",
+		Scopes: []domain.APIKeyScope{domain.ScopeTeamAdmin},
+	})
+	require.NoError(t, err)
+	_, err = testDB.Exec(`UPDATE api_keys SET expires_at = $1::timestamp WHERE id = $2`,
+		time.Now().Add(-time.Hour).UTC().Format("2006-01-02 15:04:05"), expiring.ID)
+	require.NoError(t, err)
+
+	_, err = svc.Authenticate(context.Background(), expiring.Key)
+	assert.ErrorIs(t, err, domain.ErrUnauthorized)
+}
@@ -0,0 +1,126 @@
+//go:build integration
+
+package integration_tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"AVITOSAMPISHU/internal/domain"
+	audit_repository "AVITOSAMPISHU/internal/repository/audit_repository"
+	pullrequest_repository "AVITOSAMPISHU/internal/repository/pullrequest_repository"
+	reviewer_repository "AVITOSAMPISHU/internal/repository/reviewer_repository"
+	team_repository "AVITOSAMPISHU/internal/repository/team_repository"
+	user_repository "AVITOSAMPISHU/internal/repository/user_repository"
+	audit_service "AVITOSAMPISHU/internal/service/audit_service"
+	pullrequest_service "AVITOSAMPISHU/internal/service/pullrequest_service"
+	reviewer_selector "AVITOSAMPISHU/internal/service/reviewer_selector"
+	team_service "AVITOSAMPISHU/internal/service/team_service"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationAuditEvents(t *testing.T) {
+	truncateAll(t)
+
+	userRepo := user_repository.NewUserRepository(testDB)
+	teamRepo := team_repository.NewTeamStorage(testDB)
+	prReviewersRepo := reviewer_repository.NewPrReviewersStorage(testDB)
+
+	auditSvc := audit_service.NewAuditService(audit_repository.NewAuditStorage(testDB))
+	teamSvc := auditSvc.WrapTeamService(team_service.NewTeamService(teamRepo, userRepo))
+	prSvc := auditSvc.WrapPullRequestService(pullrequest_service.NewPullRequestService(
+		pullrequest_repository.NewPullRequestStorage(testDB),
+		prReviewersRepo,
+		userRepo,
+		teamRepo,
+		reviewer_selector.NewTeamStrategySelector(prReviewersRepo),
+	))
+
+	admin := domain.ContextWithActor(context.Background(), domain.Actor{ID: domain.AdminActorID, Role: domain.RoleAdmin})
+	ctx := domain.ContextWithRequestID(admin, "req-audit-1")
+
+	_, err := teamSvc.CreateTeam(ctx, &domain.Team{
+		TeamName: "audit-team",
+		Members: []domain.TeamMember{
+			{UserID: "audit-author", Username: "Author", IsActive: true},
+			{UserID: "audit-r1", Username: "R1", IsActive: true},
+			{UserID: "audit-r2", Username: "R2", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = prSvc.CreatePullRequest(ctx, &domain.CreatePullRequestReq{
+		PullRequestID:   "audit-pr",
+		PullRequestName: "Audit",
+		AuthorID:        "audit-author",
+	})
+	require.NoError(t, err)
+
+	_, err = prSvc.ClosePullRequest(ctx, &domain.ClosePullRequestReq{PullRequestID: "audit-pr"})
+	require.NoError(t, err)
+
+	// Неуспешная операция в журнал не попадает
+	_, err = prSvc.MergePullRequest(ctx, &domain.MergePullRequestReq{PullRequestID: "audit-pr"})
+	require.Error(t, err)
+
+	res, err := auditSvc.ListAuditEvents(admin, &domain.ListAuditEventsReq{
+		EntityType: domain.AuditEntityPullRequest,
+		EntityID:   "audit-pr",
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Events, 2)
+
+	closed := res.Events[0]
+	require.Equal(t, "ClosePullRequest", closed.Operation)
+	require.Equal(t, domain.AdminActorID, closed.Actor)
+	require.Equal(t, "req-audit-1", closed.RequestID)
+
+	var before, after domain.PullRequest
+	require.NoError(t, json.Unmarshal(closed.Before, &before))
+	require.NoError(t, json.Unmarshal(closed.After, &after))
+	require.Equal(t, domain.PRStatusOpen, before.Status)
+	require.Equal(t, domain.PRStatusClosed, after.Status)
+
+	created := res.Events[1]
+	require.Equal(t, "CreatePullRequest", created.Operation)
+	require.Nil(t, created.Before)
+	require.Len(t, created.TargetIDs, 2)
+
+	// Фильтр по инициатору и постраничный обход всего журнала
+	var operations []string
+	req := &domain.ListAuditEventsReq{Actor: domain.AdminActorID, Limit: 1}
+	for {
+		page, err := auditSvc.ListAuditEvents(admin, req)
+		require.NoError(t, err)
+		for _, event := range page.Events {
+			operations = append(operations, event.Operation)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		req.Cursor, err = domain.DecodePageCursor(page.NextCursor)
+		require.NoError(t, err)
+	}
+	require.Equal(t, []string{"ClosePullRequest", "CreatePullRequest", "CreateTeam"}, operations)
+
+	// Слияние в обход одобрений видно по параметрам запроса
+	_, err = prSvc.CreatePullRequest(ctx, &domain.CreatePullRequestReq{
+		PullRequestID:   "audit-pr-2",
+		PullRequestName: "Audit force",
+		AuthorID:        "audit-author",
+	})
+	require.NoError(t, err)
+	_, err = prSvc.MergePullRequest(ctx, &domain.MergePullRequestReq{PullRequestID: "audit-pr-2", Force: true})
+	require.NoError(t, err)
+
+	res, err = auditSvc.ListAuditEvents(admin, &domain.ListAuditEventsReq{
+		EntityType: domain.AuditEntityPullRequest,
+		EntityID:   "audit-pr-2",
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Events, 2)
+	require.Equal(t, "MergePullRequest", res.Events[0].Operation)
+	require.JSONEq(t, `{"pull_request_id":"audit-pr-2","force":true}`, string(res.Events[0].Params))
+}
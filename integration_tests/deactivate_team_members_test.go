@@ -10,6 +10,7 @@ import (
 	prreviewerspkg "AVITOSAMPISHU/internal/repository/reviewer_repository"
 	teampkg "AVITOSAMPISHU/internal/repository/team_repository"
 	repositorypkg "AVITOSAMPISHU/internal/repository/user_repository"
+	selectorpkg "AVITOSAMPISHU/internal/service/reviewer_selector"
 	userservice "AVITOSAMPISHU/internal/service/user_service"
 
 	"github.com/google/uuid"
@@ -62,7 +63,7 @@ func TestIntegrationDeactivateTeamMembersFlow(t *testing.T) {
 	userRepo := repositorypkg.NewUserRepository(testDB)
 	prRepo := prreviewerspkg.NewPrReviewersStorage(testDB)
 	teamRepo := teampkg.NewTeamStorage(testDB)
-	userService := userservice.NewUserService(userRepo, prRepo, teamRepo)
+	userService := userservice.NewUserService(userRepo, prRepo, teamRepo, selectorpkg.NewTeamStrategySelector(prRepo))
 
 	res, err := userService.DeactivateTeamMembers(ctx, &domain.DeactivateTeamMembersReq{
 		TeamName: teamName,
@@ -86,7 +87,7 @@ func TestIntegrationDeactivateTeamMembersFlow(t *testing.T) {
 	require.False(t, isActive, "user must be deactivated in DB")
 
 	var remainingReviewers []string
-	rows, err := testDB.QueryContext(ctx, `SELECT reviewer_id FROM reviewers WHERE pull_request_id = $1`, prID)
+	rows, err := testDB.QueryContext(ctx, `SELECT reviewer_id FROM reviewers WHERE pull_request_id = $1 AND unassigned_at IS NULL`, prID)
 	require.NoError(t, err)
 	for rows.Next() {
 		var reviewerID string
@@ -96,6 +97,14 @@ func TestIntegrationDeactivateTeamMembersFlow(t *testing.T) {
 	require.NoError(t, rows.Err())
 	require.NotContains(t, remainingReviewers, "u2", "u2 should be removed from reviewers")
 	require.Contains(t, remainingReviewers, "u3", "u3 should remain as reviewer")
+
+	// Снятое назначение остаётся в истории с причиной
+	var reason string
+	err = testDB.QueryRowContext(ctx,
+		`SELECT unassign_reason FROM reviewers WHERE pull_request_id = $1 AND reviewer_id = $2 AND unassigned_at IS NOT NULL`,
+		prID, "u2").Scan(&reason)
+	require.NoError(t, err)
+	require.Equal(t, string(domain.UnassignReasonDeactivation), reason)
 	// Если был назначен новый ревьювер, проверяем что он в списке
 	if res.Reassignments[0].NewReviewerID != "" {
 		require.Contains(t, remainingReviewers, res.Reassignments[0].NewReviewerID, "new reviewer should be in the list")
@@ -166,7 +175,7 @@ func TestIntegrationDeactivateTeamMembers_Rollback(t *testing.T) {
 
 	// Verify that the reviewer 'u2' is still assigned to pr-rollback (rollback occurred)
 	var reviewerCount int
-	err = testDB.QueryRowContext(ctx, `SELECT COUNT(*) FROM reviewers WHERE pull_request_id = $1 AND reviewer_id = $2`, prID, "u2").Scan(&reviewerCount)
+	err = testDB.QueryRowContext(ctx, `SELECT COUNT(*) FROM reviewers WHERE pull_request_id = $1 AND reviewer_id = $2 AND unassigned_at IS NULL`, prID, "u2").Scan(&reviewerCount)
 	require.NoError(t, err)
 	require.Equal(t, 1, reviewerCount, "reviewer 'u2' should still be assigned due to rollback")
 }
@@ -202,7 +211,7 @@ func TestIntegrationDeactivateTeamMembers_CannotDeactivateAll(t *testing.T) {
 	userRepo := repositorypkg.NewUserRepository(testDB)
 	prRepo := prreviewerspkg.NewPrReviewersStorage(testDB)
 	teamRepo := teampkg.NewTeamStorage(testDB)
-	userService := userservice.NewUserService(userRepo, prRepo, teamRepo)
+	userService := userservice.NewUserService(userRepo, prRepo, teamRepo, selectorpkg.NewTeamStrategySelector(prRepo))
 
 	// Test case 1: Empty UserIDs list
 	_, err = userService.DeactivateTeamMembers(ctx, &domain.DeactivateTeamMembersReq{
@@ -0,0 +1,78 @@
+//go:build integration
+
+package integration_tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"AVITOSAMPISHU/internal/domain"
+	stats_repository "AVITOSAMPISHU/internal/repository/stats_repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationExportReviews(t *testing.T) {
+	truncateAll(t)
+	seedReviewStats(t)
+
+	ctx := context.Background()
+	statsRepo := stats_repository.NewStatsStorage(testDB)
+
+	from := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	collect := func(t *testing.T, filter *domain.ExportReviewsReq) []domain.ReviewExportRow {
+		t.Helper()
+		var rows []domain.ReviewExportRow
+		require.NoError(t, statsRepo.ExportReviews(ctx, filter, func(row *domain.ReviewExportRow) error {
+			rows = append(rows, *row)
+			return nil
+		}))
+		return rows
+	}
+
+	t.Run("team and window", func(t *testing.T) {
+		rows := collect(t, &domain.ExportReviewsReq{TeamName: "stats-backend", From: &from, To: &to})
+
+		got := make([]string, 0, len(rows))
+		for _, row := range rows {
+			got = append(got, row.PullRequestID+"/"+row.ReviewerID)
+			assert.Equal(t, "stats-backend", row.TeamName)
+			assert.Equal(t, "a1", row.AuthorID)
+		}
+		assert.Equal(t, []string{"p1/r1", "p1/r2", "p2/r1", "p2/r2", "p4/r1"}, got)
+
+		merged := rows[0]
+		require.NotNil(t, merged.AssignedAt)
+		require.NotNil(t, merged.MergedAt)
+		assert.Equal(t, time.Date(2025, 10, 1, 10, 0, 0, 0, time.UTC), *merged.AssignedAt)
+		assert.Equal(t, time.Date(2025, 10, 1, 14, 0, 0, 0, time.UTC), *merged.MergedAt)
+		assert.Equal(t, merged.MergedAt, merged.RemovedAt)
+		assert.Equal(t, domain.UnassignReasonMerged, merged.Reason)
+
+		open := rows[4]
+		assert.Nil(t, open.MergedAt)
+		assert.Nil(t, open.RemovedAt)
+		assert.Empty(t, open.Reason)
+	})
+
+	t.Run("all teams", func(t *testing.T) {
+		rows := collect(t, &domain.ExportReviewsReq{From: &from, To: &to})
+		assert.Len(t, rows, 6)
+	})
+
+	t.Run("handle error stops export", func(t *testing.T) {
+		stop := errors.New("client gone")
+		calls := 0
+		err := statsRepo.ExportReviews(ctx, &domain.ExportReviewsReq{}, func(*domain.ReviewExportRow) error {
+			calls++
+			return stop
+		})
+		assert.ErrorIs(t, err, stop)
+		assert.Equal(t, 1, calls)
+	})
+}
@@ -0,0 +1,59 @@
+//go:build integration
+
+package integration_tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"AVITOSAMPISHU/internal/domain"
+	idempotency_repository "AVITOSAMPISHU/internal/repository/idempotency_repository"
+	idempotency_service "AVITOSAMPISHU/internal/service/idempotency_service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationIdempotencyKeys(t *testing.T) {
+	truncateAll(t)
+
+	ctx := context.Background()
+	svc := idempotency_service.NewIdempotencyService(idempotency_repository.NewIdempotencyStorage(testDB), time.Hour)
+
+	record, err := svc.Begin(ctx, "alice", "k1", "hash-1")
+	require.NoError(t, err)
+	require.Nil(t, record, "free key must be claimed")
+
+	_, err = svc.Begin(ctx, "alice", "k1", "hash-1")
+	assert.ErrorIs(t, err, domain.ErrIdempotencyInProgress)
+
+	require.NoError(t, svc.Complete(ctx, "alice", "k1", 201, []byte(`{"ok":true}`)))
+
+	record, err = svc.Begin(ctx, "alice", "k1", "hash-1")
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	assert.Equal(t, 201, record.StatusCode)
+	assert.JSONEq(t, `{"ok":true}`, string(record.Body))
+
+	_, err = svc.Begin(ctx, "alice", "k1", "hash-2")
+	assert.ErrorIs(t, err, domain.ErrIdempotencyKeyReused)
+
+	// Тот же ключ другого инициатора свободен
+	record, err = svc.Begin(ctx, "bob", "k1", "hash-2")
+	require.NoError(t, err)
+	assert.Nil(t, record)
+
+	// Освобождённый ключ можно занять снова
+	require.NoError(t, svc.Release(ctx, "bob", "k1"))
+	record, err = svc.Begin(ctx, "bob", "k1", "hash-3")
+	require.NoError(t, err)
+	assert.Nil(t, record)
+
+	// Истёкший ключ занимается заново даже с другим запросом
+	_, err = testDB.Exec(`UPDATE idempotency_keys SET expires_at = NOW() - INTERVAL '1 second' WHERE principal = 'alice'`)
+	require.NoError(t, err)
+	record, err = svc.Begin(ctx, "alice", "k1", "hash-2")
+	require.NoError(t, err)
+	assert.Nil(t, record)
+}
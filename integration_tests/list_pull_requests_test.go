@@ -0,0 +1,126 @@
+//go:build integration
+
+package integration_tests
+
+import (
+	"context"
+	"testing"
+
+	"AVITOSAMPISHU/internal/domain"
+	pullrequest_repository "AVITOSAMPISHU/internal/repository/pullrequest_repository"
+	reviewer_repository "AVITOSAMPISHU/internal/repository/reviewer_repository"
+	team_repository "AVITOSAMPISHU/internal/repository/team_repository"
+	user_repository "AVITOSAMPISHU/internal/repository/user_repository"
+	pullrequest_service "AVITOSAMPISHU/internal/service/pullrequest_service"
+	reviewer_selector "AVITOSAMPISHU/internal/service/reviewer_selector"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationListPullRequests(t *testing.T) {
+	truncateAll(t)
+
+	ctx := context.Background()
+
+	teams := map[string]uuid.UUID{"team-list-a": uuid.New(), "team-list-b": uuid.New()}
+	for name, id := range teams {
+		_, err := testDB.ExecContext(ctx, `INSERT INTO teams (id, team_name) VALUES ($1, $2)`, id, name)
+		require.NoError(t, err)
+	}
+
+	users := []struct {
+		ID   string
+		Team string
+	}{
+		{"a1", "team-list-a"},
+		{"a2", "team-list-a"},
+		{"b1", "team-list-b"},
+	}
+	for _, u := range users {
+		_, err := testDB.ExecContext(ctx,
+			`INSERT INTO users (id, username, team_id, is_active) VALUES ($1, $1, $2, true)`,
+			u.ID, teams[u.Team],
+		)
+		require.NoError(t, err)
+	}
+
+	// Пять PR с разницей в час: pr-1 самый старый
+	prs := []struct {
+		ID       string
+		Author   string
+		Status   string
+		NeedMore bool
+		Reviewer string
+	}{
+		{"pr-1", "a1", "MERGED", false, "a2"},
+		{"pr-2", "a1", "OPEN", true, "a2"},
+		{"pr-3", "b1", "OPEN", false, ""},
+		{"pr-4", "a2", "CLOSED", false, ""},
+		{"pr-5", "a1", "OPEN", false, "a2"},
+	}
+	for i, pr := range prs {
+		_, err := testDB.ExecContext(ctx,
+			`INSERT INTO pull_requests (id, pull_requests_name, author_id, status, need_more_reviewers, created_at)
+			 VALUES ($1, $1, $2, $3, $4, TIMESTAMP '2025-10-01 10:00:00' + make_interval(hours => $5))`,
+			pr.ID, pr.Author, pr.Status, pr.NeedMore, i,
+		)
+		require.NoError(t, err)
+		if pr.Reviewer != "" {
+			_, err = testDB.ExecContext(ctx, `INSERT INTO reviewers (pull_request_id, reviewer_id) VALUES ($1, $2)`, pr.ID, pr.Reviewer)
+			require.NoError(t, err)
+		}
+	}
+
+	prRepo := pullrequest_repository.NewPullRequestStorage(testDB)
+	prReviewersRepo := reviewer_repository.NewPrReviewersStorage(testDB)
+	prSvc := pullrequest_service.NewPullRequestService(
+		prRepo,
+		prReviewersRepo,
+		user_repository.NewUserRepository(testDB),
+		team_repository.NewTeamStorage(testDB),
+		reviewer_selector.NewTeamStrategySelector(prReviewersRepo),
+	)
+
+	ids := func(res *domain.ListPullRequestsResponse) []string {
+		out := make([]string, 0, len(res.PullRequests))
+		for _, pr := range res.PullRequests {
+			out = append(out, pr.PullRequestID)
+		}
+		return out
+	}
+
+	// Пагинация по убыванию created_at проходит все PR без пропусков и повторов
+	var collected []string
+	req := &domain.ListPullRequestsReq{Order: domain.SortOrderDesc, Limit: 2}
+	for {
+		res, err := prSvc.ListPullRequests(ctx, req)
+		require.NoError(t, err)
+		collected = append(collected, ids(res)...)
+		if res.NextCursor == "" {
+			break
+		}
+		req.Cursor, err = domain.DecodePageCursor(res.NextCursor)
+		require.NoError(t, err)
+	}
+	require.Equal(t, []string{"pr-5", "pr-4", "pr-3", "pr-2", "pr-1"}, collected)
+
+	res, err := prSvc.ListPullRequests(ctx, &domain.ListPullRequestsReq{
+		Statuses: []domain.PRStatus{domain.PRStatusOpen},
+		TeamName: "team-list-a",
+		Order:    domain.SortOrderAsc,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"pr-2", "pr-5"}, ids(res))
+	require.Equal(t, []string{"a2"}, res.PullRequests[0].AssignedReviewers)
+	require.Equal(t, "team-list-a", res.PullRequests[0].TeamName)
+
+	needMore := true
+	res, err = prSvc.ListPullRequests(ctx, &domain.ListPullRequestsReq{ReviewerID: "a2", NeedMoreReviewers: &needMore, Order: domain.SortOrderDesc})
+	require.NoError(t, err)
+	require.Equal(t, []string{"pr-2"}, ids(res))
+
+	res, err = prSvc.ListPullRequests(ctx, &domain.ListPullRequestsReq{AuthorID: "b1", Order: domain.SortOrderDesc})
+	require.NoError(t, err)
+	require.Equal(t, []string{"pr-3"}, ids(res))
+}
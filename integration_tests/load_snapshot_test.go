@@ -0,0 +1,87 @@
+//go:build integration
+
+package integration_tests
+
+import (
+	"context"
+	"testing"
+
+	"AVITOSAMPISHU/internal/domain"
+	pullrequest_repository "AVITOSAMPISHU/internal/repository/pullrequest_repository"
+	stats_repository "AVITOSAMPISHU/internal/repository/stats_repository"
+	team_repository "AVITOSAMPISHU/internal/repository/team_repository"
+	user_repository "AVITOSAMPISHU/internal/repository/user_repository"
+	team_service "AVITOSAMPISHU/internal/service/team_service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationLoadSnapshot(t *testing.T) {
+	truncateAll(t)
+
+	ctx := context.Background()
+
+	teamSvc := team_service.NewTeamService(team_repository.NewTeamStorage(testDB), user_repository.NewUserRepository(testDB))
+	prRepo := pullrequest_repository.NewPullRequestStorage(testDB)
+	statsRepo := stats_repository.NewStatsStorage(testDB)
+
+	_, err := teamSvc.CreateTeam(ctx, &domain.Team{
+		TeamName: "load-backend",
+		Members: []domain.TeamMember{
+			{UserID: "load-author", Username: "Author", IsActive: true},
+			{UserID: "load-r1", Username: "R1", IsActive: true},
+			{UserID: "load-r2", Username: "R2", IsActive: true},
+			{UserID: "load-r3", Username: "R3", IsActive: true},
+			{UserID: "load-inactive", Username: "Inactive", IsActive: false},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = teamSvc.CreateTeam(ctx, &domain.Team{
+		TeamName: "load-frontend",
+		Members:  []domain.TeamMember{{UserID: "load-f1", Username: "F1", IsActive: true}},
+	})
+	require.NoError(t, err)
+
+	createPR := func(id string, reviewers []string, needMore bool) *domain.PullRequest {
+		pr := &domain.PullRequest{PullRequestID: id, PullRequestName: id, AuthorID: "load-author", Status: domain.PRStatusOpen}
+		require.NoError(t, prRepo.CreatePullRequestWithReviewers(ctx, pr, reviewers, needMore))
+		return pr
+	}
+	createPR("load-pr-1", []string{"load-r1", "load-r2"}, false)
+	createPR("load-pr-2", []string{"load-r1", "load-f1"}, true)
+	// Слитый PR не считается ни в нагрузке ревьюверов, ни в открытых PR команды
+	require.NoError(t, prRepo.MergePullRequest(ctx, createPR("load-pr-3", []string{"load-r2", "load-r3"}, false), nil))
+
+	snapshot, err := statsRepo.GetLoadSnapshot(ctx)
+	require.NoError(t, err)
+	require.Len(t, snapshot, 2)
+
+	backend := snapshot[0]
+	assert.Equal(t, "load-backend", backend.TeamName)
+	assert.Equal(t, 2, backend.OpenPullRequests)
+	assert.Equal(t, 1, backend.NeedMoreReviewers)
+	assert.Equal(t, 4, backend.ActiveMembers)
+	assert.Equal(t, 1, backend.InactiveMembers)
+
+	openReviews := make(map[string]int, len(backend.Reviewers))
+	for _, reviewer := range backend.Reviewers {
+		assert.Equal(t, "load-backend", reviewer.TeamName)
+		openReviews[reviewer.UserID] = reviewer.OpenReviews
+	}
+	assert.Equal(t, map[string]int{
+		"load-author":   0,
+		"load-inactive": 0,
+		"load-r1":       2,
+		"load-r2":       1,
+		"load-r3":       0,
+	}, openReviews)
+
+	// Ревьювер из другой команды нагружает свою команду, а PR остаётся за командой автора
+	frontend := snapshot[1]
+	assert.Equal(t, "load-frontend", frontend.TeamName)
+	assert.Equal(t, 0, frontend.OpenPullRequests)
+	require.Len(t, frontend.Reviewers, 1)
+	assert.Equal(t, 1, frontend.Reviewers[0].OpenReviews)
+}
@@ -11,6 +11,8 @@ import (
 	"time"
 
 	"AVITOSAMPISHU/internal/infrastructure/database"
+	"AVITOSAMPISHU/internal/infrastructure/migrator"
+	"AVITOSAMPISHU/migrations"
 	"AVITOSAMPISHU/pkg/helpers"
 	"AVITOSAMPISHU/pkg/logger"
 
@@ -46,6 +48,15 @@ func TestMain(m *testing.M) {
 
 	logger.Logger.Infow("Test database connection established")
 
+	// Схему накатывают те же встроенные миграции, что и в приложении
+	schemaMigrator, err := migrator.New(testDB, migrations.FS)
+	if err != nil {
+		logger.Logger.Fatalw("Failed to load migrations", "error", err)
+	}
+	if _, err := schemaMigrator.Up(context.Background()); err != nil {
+		logger.Logger.Fatalw("Failed to apply migrations", "error", err)
+	}
+
 	// Run tests
 	exitCode := m.Run()
 
@@ -54,8 +65,8 @@ func TestMain(m *testing.M) {
 }
 
 func truncateAll(t *testing.T) {
-	tables := make([]string, 0, 4)
-	tables = append(tables, "reviewers", "pull_requests", "users", "teams")
+	tables := make([]string, 0, 8)
+	tables = append(tables, "reviewers", "pull_requests", "users", "teams", "outbox", "audit_events", "api_keys", "idempotency_keys")
 	for _, table := range tables {
 		_, err := testDB.Exec(fmt.Sprintf("TRUNCATE TABLE %s CASCADE", table))
 		require.NoError(t, err, "Failed to truncate table %s", table)
@@ -0,0 +1,67 @@
+//go:build integration
+
+package integration_tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"AVITOSAMPISHU/internal/domain"
+	pullrequest_repository "AVITOSAMPISHU/internal/repository/pullrequest_repository"
+	reviewer_repository "AVITOSAMPISHU/internal/repository/reviewer_repository"
+	team_repository "AVITOSAMPISHU/internal/repository/team_repository"
+	user_repository "AVITOSAMPISHU/internal/repository/user_repository"
+	team_service "AVITOSAMPISHU/internal/service/team_service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Решение, записанное после чтения PR, должно попасть в проверку политики внутри транзакции слияния
+func TestIntegrationMergeChecksVerdictsInTransaction(t *testing.T) {
+	truncateAll(t)
+
+	ctx := context.Background()
+
+	teamSvc := team_service.NewTeamService(team_repository.NewTeamStorage(testDB), user_repository.NewUserRepository(testDB))
+	prRepo := pullrequest_repository.NewPullRequestStorage(testDB)
+	reviewersRepo := reviewer_repository.NewPrReviewersStorage(testDB)
+
+	_, err := teamSvc.CreateTeam(ctx, &domain.Team{
+		TeamName: "merge-backend",
+		Members: []domain.TeamMember{
+			{UserID: "merge-author", Username: "Author", IsActive: true},
+			{UserID: "merge-r1", Username: "R1", IsActive: true},
+			{UserID: "merge-r2", Username: "R2", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+
+	prID := "merge-pr"
+	pr := &domain.PullRequest{PullRequestID: prID, PullRequestName: prID, AuthorID: "merge-author", Status: domain.PRStatusOpen}
+	require.NoError(t, prRepo.CreatePullRequestWithReviewers(ctx, pr, []string{"merge-r1", "merge-r2"}, false))
+	require.NoError(t, reviewersRepo.SubmitReview(ctx, prID, "merge-r1", domain.ReviewVerdictApproved, ""))
+
+	stale, err := prRepo.GetPullRequestByID(ctx, prID)
+	require.NoError(t, err)
+	require.NoError(t, reviewersRepo.SubmitReview(ctx, prID, "merge-r2", domain.ReviewVerdictChangesRequested, "later"))
+
+	blocked := errors.New("changes requested")
+	err = prRepo.MergePullRequest(ctx, stale, func(locked *domain.PullRequest) error {
+		if _, changesRequested := locked.CountVerdicts(); changesRequested > 0 {
+			return blocked
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, blocked)
+
+	current, err := prRepo.GetPullRequestByID(ctx, prID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PRStatusOpen, current.Status)
+	assert.Nil(t, current.MergedAt)
+
+	// После слияния решение уже не принимается
+	require.NoError(t, prRepo.MergePullRequest(ctx, current, nil))
+	assert.ErrorIs(t, reviewersRepo.SubmitReview(ctx, prID, "merge-r1", domain.ReviewVerdictApproved, ""), domain.ErrPRMerged)
+}
@@ -12,6 +12,7 @@ import (
 	team_repository "AVITOSAMPISHU/internal/repository/team_repository"
 	user_repository "AVITOSAMPISHU/internal/repository/user_repository"
 	pullrequest_service "AVITOSAMPISHU/internal/service/pullrequest_service"
+	reviewer_selector "AVITOSAMPISHU/internal/service/reviewer_selector"
 	team_service "AVITOSAMPISHU/internal/service/team_service"
 
 	"github.com/stretchr/testify/require"
@@ -30,7 +31,7 @@ func TestIntegrationPullRequestFlow(t *testing.T) {
 
 	// Setup Services
 	teamSvc := team_service.NewTeamService(teamRepo, userRepo)
-	prSvc := pullrequest_service.NewPullRequestService(prRepo, prReviewersRepo, userRepo, teamRepo)
+	prSvc := pullrequest_service.NewPullRequestService(prRepo, prReviewersRepo, userRepo, teamRepo, reviewer_selector.NewTeamStrategySelector(prReviewersRepo))
 
 	// 1. Create Team
 	teamName := "dev-team"
@@ -65,7 +66,7 @@ func TestIntegrationPullRequestFlow(t *testing.T) {
 	require.NotNil(t, createdPR)
 	require.Equal(t, prID, createdPR.PullRequestID)
 	require.Equal(t, domain.PRStatusOpen, createdPR.Status)
-	require.Len(t, createdPR.AssignedReviewers, domain.MaxReviewersCount)
+	require.Len(t, createdPR.AssignedReviewers, domain.DefaultRequiredReviewers)
 	require.NotContains(t, createdPR.AssignedReviewers, authorID)
 	require.NotContains(t, createdPR.AssignedReviewers, inactiveUserID) // Inactive user should not be selected
 	// Проверяем, что выбраны только активные ревьюверы (не автор и не неактивный)
@@ -77,9 +78,25 @@ func TestIntegrationPullRequestFlow(t *testing.T) {
 	// Verify reviewers in DB
 	dbReviewers, err := prReviewersRepo.GetAssignedReviewers(ctx, prID)
 	require.NoError(t, err)
-	require.Len(t, dbReviewers, domain.MaxReviewersCount)
+	require.Len(t, dbReviewers, domain.DefaultRequiredReviewers)
 	require.NotContains(t, dbReviewers, authorID)
 
+	// Полная карточка PR: автор, команда и время назначения каждого ревьювера
+	fetchedPR, err := prSvc.GetPullRequest(ctx, prID)
+	require.NoError(t, err)
+	require.Equal(t, "Author", fetchedPR.AuthorUsername)
+	require.Equal(t, teamName, fetchedPR.TeamName)
+	require.NotNil(t, fetchedPR.NeedMoreReviewers)
+	require.False(t, *fetchedPR.NeedMoreReviewers)
+	require.Len(t, fetchedPR.Reviews, domain.DefaultRequiredReviewers)
+	for _, review := range fetchedPR.Reviews {
+		require.NotNil(t, review.AssignedAt)
+		require.Equal(t, domain.ReviewVerdictPending, review.Verdict)
+	}
+
+	_, err = prSvc.GetPullRequest(ctx, "unknown-pr")
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
 	// 3. Reassign Reviewer - используем первого реально назначенного ревьювера
 	require.NotEmpty(t, createdPR.AssignedReviewers, "PR should have assigned reviewers")
 	oldReviewer := createdPR.AssignedReviewers[0]
@@ -97,7 +114,7 @@ func TestIntegrationPullRequestFlow(t *testing.T) {
 	// Verify reviewers in DB after reassign
 	dbReviewersAfterReassign, err := prReviewersRepo.GetAssignedReviewers(ctx, prID)
 	require.NoError(t, err)
-	require.Len(t, dbReviewersAfterReassign, domain.MaxReviewersCount)
+	require.Len(t, dbReviewersAfterReassign, domain.DefaultRequiredReviewers)
 	require.NotContains(t, dbReviewersAfterReassign, oldReviewer)
 	require.Contains(t, dbReviewersAfterReassign, newReviewer)
 
@@ -114,6 +131,42 @@ func TestIntegrationPullRequestFlow(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, domain.PRStatusMerged, dbPR.Status)
 	require.NotNil(t, dbPR.MergedAt)
+	// Назначения, завершённые слиянием, остаются ревьюверами слитого PR
+	require.ElementsMatch(t, mergedPR.AssignedReviewers, dbPR.AssignedReviewers)
+	require.Len(t, dbPR.AssignedReviewers, domain.DefaultRequiredReviewers)
+
+	// Снятый ревьювер остаётся в истории, назначения остальных завершаются слиянием
+	history, err := prSvc.GetPullRequestHistory(ctx, prID)
+	require.NoError(t, err)
+	require.Len(t, history.History, domain.DefaultRequiredReviewers+1)
+	reasons := make(map[string]domain.UnassignReason, len(history.History))
+	for _, assignment := range history.History {
+		require.NotNil(t, assignment.UnassignedAt)
+		reasons[assignment.ReviewerID] = assignment.Reason
+	}
+	require.Equal(t, domain.UnassignReasonManualReassign, reasons[oldReviewer])
+	require.Equal(t, domain.UnassignReasonMerged, reasons[newReviewer])
+
+	// Повторное слияние не пишет второе событие
+	_, err = prSvc.MergePullRequest(ctx, mergeReq)
+	require.NoError(t, err)
+
+	// События записаны в outbox в порядке изменений
+	rows, err := testDB.QueryContext(ctx, `SELECT event_type FROM outbox WHERE processed_at IS NULL ORDER BY id`)
+	require.NoError(t, err)
+	var outboxEvents []string
+	for rows.Next() {
+		var eventType string
+		require.NoError(t, rows.Scan(&eventType))
+		outboxEvents = append(outboxEvents, eventType)
+	}
+	require.NoError(t, rows.Err())
+	require.NoError(t, rows.Close())
+	require.Equal(t, []string{
+		string(domain.EventPullRequestCreated),
+		string(domain.EventReviewerReassigned),
+		string(domain.EventPullRequestMerged),
+	}, outboxEvents)
 
 	// 5. Attempt to reassign on merged PR (negative)
 	_, _, err = prSvc.ReassignReviewer(ctx, reassignReq)
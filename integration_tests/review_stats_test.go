@@ -0,0 +1,171 @@
+//go:build integration
+
+package integration_tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"AVITOSAMPISHU/internal/domain"
+	stats_repository "AVITOSAMPISHU/internal/repository/stats_repository"
+	stats_service "AVITOSAMPISHU/internal/service/stats_service"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedReviewStats заполняет историю за октябрь 2025: команда stats-backend (автор a1, ревьюверы r1, r2)
+// и stats-frontend (автор f1, ревьювер fr1), у которой r2 ревьюит PR на замену
+func seedReviewStats(t *testing.T) {
+	ctx := context.Background()
+
+	exec := func(query string, args ...interface{}) {
+		t.Helper()
+		_, err := testDB.ExecContext(ctx, query, args...)
+		require.NoError(t, err)
+	}
+
+	teams := map[string][]string{
+		"stats-backend":  {"a1", "r1", "r2"},
+		"stats-frontend": {"f1", "fr1"},
+	}
+	for teamName, members := range teams {
+		teamID := uuid.New()
+		exec(`INSERT INTO teams (id, team_name) VALUES ($1, $2)`, teamID, teamName)
+		for _, userID := range members {
+			exec(`INSERT INTO users (id, username, team_id, is_active) VALUES ($1, $1, $2, true)`, userID, teamID)
+		}
+	}
+
+	prs := []struct {
+		id, author, status string
+		createdAt          string
+		mergedAt, closedAt interface{}
+		needMore           bool
+	}{
+		{"p0", "a1", "MERGED", "2025-09-20 10:00:00", "2025-09-21 10:00:00", nil, false}, // вне периода
+		{"p1", "a1", "MERGED", "2025-10-01 10:00:00", "2025-10-01 14:00:00", nil, false},
+		{"p2", "a1", "MERGED", "2025-10-02 10:00:00", "2025-10-03 10:00:00", nil, false},
+		{"p3", "a1", "CLOSED", "2025-10-05 10:00:00", nil, "2025-10-06 10:00:00", false},
+		{"p4", "a1", "OPEN", "2025-10-10 10:00:00", nil, nil, true},
+		{"pf", "f1", "OPEN", "2025-10-03 10:00:00", nil, nil, false},
+	}
+	for _, pr := range prs {
+		exec(`INSERT INTO pull_requests (id, pull_requests_name, author_id, status, need_more_reviewers, created_at, merged_at, closed_at)
+			VALUES ($1, $1, $2, $3, $4, $5, $6, $7)`,
+			pr.id, pr.author, pr.status, pr.needMore, pr.createdAt, pr.mergedAt, pr.closedAt)
+	}
+
+	// Назначения слитых PR завершены временем слияния
+	reviews := []struct {
+		prID, reviewerID, verdict string
+		assignedAt                string
+		reviewedAt                interface{}
+		unassignedAt, reason      interface{}
+	}{
+		{"p1", "r1", "APPROVED", "2025-10-01 10:00:00", "2025-10-01 11:00:00", "2025-10-01 14:00:00", "merged"},
+		{"p1", "r2", "APPROVED", "2025-10-01 10:00:00", "2025-10-01 12:00:00", "2025-10-01 14:00:00", "merged"},
+		{"p2", "r1", "CHANGES_REQUESTED", "2025-10-02 10:00:00", "2025-10-02 12:00:00", "2025-10-03 10:00:00", "merged"},
+		{"p2", "r2", "APPROVED", "2025-10-02 10:00:00", "2025-10-02 20:00:00", "2025-10-03 10:00:00", "merged"},
+		{"p4", "r1", "PENDING", "2025-10-11 10:00:00", nil, nil, nil},
+		{"pf", "r2", "APPROVED", "2025-10-03 10:00:00", "2025-10-03 13:00:00", nil, nil},
+	}
+	for _, review := range reviews {
+		exec(`INSERT INTO reviewers (pull_request_id, reviewer_id, verdict, assigned_at, reviewed_at, unassigned_at, unassign_reason)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			review.prID, review.reviewerID, review.verdict, review.assignedAt, review.reviewedAt, review.unassignedAt, review.reason)
+	}
+
+	// Переназначения: ручное на p4, при деактивации на pf и одно до начала периода
+	reassignments := []struct {
+		prID, oldReviewerID, newReviewerID string
+		reassignedAt                       string
+	}{
+		{"p4", "r2", "r1", "2025-10-11 10:00:00"},
+		{"pf", "fr1", "r2", "2025-10-12 10:00:00"},
+		{"p4", "r1", "r2", "2025-09-15 10:00:00"},
+	}
+	for _, reassignment := range reassignments {
+		exec(`INSERT INTO reviewer_reassignments (pull_request_id, old_reviewer_id, new_reviewer_id, reassigned_at) VALUES ($1, $2, $3, $4)`,
+			reassignment.prID, reassignment.oldReviewerID, reassignment.newReviewerID, reassignment.reassignedAt)
+	}
+}
+
+func hours(h float64) float64 {
+	return h * time.Hour.Seconds()
+}
+
+func TestIntegrationReviewStats(t *testing.T) {
+	truncateAll(t)
+	seedReviewStats(t)
+
+	ctx := context.Background()
+	statsSvc := stats_service.NewStatsService(stats_repository.NewStatsStorage(testDB))
+
+	from := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("team", func(t *testing.T) {
+		stats, err := statsSvc.GetTeamStats(ctx, &domain.GetTeamStatsReq{TeamName: "stats-backend", From: &from, To: &to})
+		require.NoError(t, err)
+
+		assert.Equal(t, domain.PullRequestThroughput{Created: 4, Merged: 2, Closed: 1}, stats.PullRequests)
+
+		// Первые вердикты через 1 и 2 часа после создания
+		assert.Equal(t, 2, stats.TimeToFirstReview.Count)
+		require.NotNil(t, stats.TimeToFirstReview.MedianSeconds)
+		assert.InDelta(t, hours(1.5), *stats.TimeToFirstReview.MedianSeconds, 1)
+		assert.InDelta(t, hours(1.9), *stats.TimeToFirstReview.P90Seconds, 1)
+
+		// Слияние через 4 и 24 часа; p0 слит до начала периода
+		assert.Equal(t, 2, stats.TimeToMerge.Count)
+		require.NotNil(t, stats.TimeToMerge.MedianSeconds)
+		assert.InDelta(t, hours(14), *stats.TimeToMerge.MedianSeconds, 1)
+		assert.InDelta(t, hours(22), *stats.TimeToMerge.P90Seconds, 1)
+
+		// Вердикты участников команды, включая ревью r2 в чужой команде
+		assert.Equal(t, domain.ReviewCounts{Approved: 4, ChangesRequested: 1}, stats.Reviews)
+		// Переназначение на PR другой команды и до начала периода не считаются
+		assert.Equal(t, 1, stats.Reassignments)
+		assert.Equal(t, domain.TeamOpenLoad{OpenPullRequests: 1, NeedMoreReviewers: 1, OpenReviews: 2}, stats.OpenLoad)
+	})
+
+	t.Run("user", func(t *testing.T) {
+		stats, err := statsSvc.GetUserStats(ctx, &domain.GetUserStatsReq{UserID: "r2", From: &from, To: &to})
+		require.NoError(t, err)
+
+		assert.Equal(t, "stats-backend", stats.TeamName)
+		assert.Equal(t, domain.PullRequestThroughput{}, stats.PullRequests)
+		assert.Equal(t, 0, stats.TimeToMerge.Count)
+		assert.Nil(t, stats.TimeToMerge.MedianSeconds, "no merged PRs means no median")
+
+		assert.Equal(t, domain.ReviewCounts{Approved: 3}, stats.Reviews)
+		// От назначения до вердикта: 2, 10 и 3 часа
+		assert.Equal(t, 3, stats.ReviewTurnaround.Count)
+		require.NotNil(t, stats.ReviewTurnaround.MedianSeconds)
+		assert.InDelta(t, hours(3), *stats.ReviewTurnaround.MedianSeconds, 1)
+		assert.InDelta(t, hours(8.6), *stats.ReviewTurnaround.P90Seconds, 1)
+
+		assert.Equal(t, domain.UserReassignments{ReassignedAway: 1, ReassignedIn: 1}, stats.Reassignments)
+		assert.Equal(t, 1, stats.OpenReviews)
+	})
+
+	t.Run("author", func(t *testing.T) {
+		stats, err := statsSvc.GetUserStats(ctx, &domain.GetUserStatsReq{UserID: "a1", From: &from, To: &to})
+		require.NoError(t, err)
+
+		assert.Equal(t, domain.PullRequestThroughput{Created: 4, Merged: 2, Closed: 1}, stats.PullRequests)
+		assert.Equal(t, 2, stats.TimeToFirstReview.Count)
+		assert.Equal(t, 2, stats.TimeToMerge.Count)
+		assert.Equal(t, 0, stats.ReviewTurnaround.Count)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := statsSvc.GetTeamStats(ctx, &domain.GetTeamStatsReq{TeamName: "ghost"})
+		require.ErrorIs(t, err, domain.ErrNotFound)
+		_, err = statsSvc.GetUserStats(ctx, &domain.GetUserStatsReq{UserID: "ghost"})
+		require.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
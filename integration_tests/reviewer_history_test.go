@@ -0,0 +1,89 @@
+//go:build integration
+
+package integration_tests
+
+import (
+	"context"
+	"testing"
+
+	"AVITOSAMPISHU/internal/domain"
+	pullrequest_repository "AVITOSAMPISHU/internal/repository/pullrequest_repository"
+	reviewer_repository "AVITOSAMPISHU/internal/repository/reviewer_repository"
+	team_repository "AVITOSAMPISHU/internal/repository/team_repository"
+	user_repository "AVITOSAMPISHU/internal/repository/user_repository"
+	team_service "AVITOSAMPISHU/internal/service/team_service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationReviewerHistory(t *testing.T) {
+	truncateAll(t)
+
+	ctx := context.Background()
+
+	teamSvc := team_service.NewTeamService(team_repository.NewTeamStorage(testDB), user_repository.NewUserRepository(testDB))
+	prRepo := pullrequest_repository.NewPullRequestStorage(testDB)
+	reviewersRepo := reviewer_repository.NewPrReviewersStorage(testDB)
+
+	_, err := teamSvc.CreateTeam(ctx, &domain.Team{
+		TeamName: "history-backend",
+		Members: []domain.TeamMember{
+			{UserID: "history-author", Username: "Author", IsActive: true},
+			{UserID: "history-r1", Username: "R1", IsActive: true},
+			{UserID: "history-r2", Username: "R2", IsActive: true},
+			{UserID: "history-r3", Username: "R3", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+
+	prID := "history-pr"
+	pr := &domain.PullRequest{PullRequestID: prID, PullRequestName: prID, AuthorID: "history-author", Status: domain.PRStatusOpen}
+	require.NoError(t, prRepo.CreatePullRequestWithReviewers(ctx, pr, []string{"history-r1", "history-r2"}, false))
+
+	// r1 заменяется на r3, затем r3 снова на r1: у r1 две строки, действующая одна
+	require.NoError(t, reviewersRepo.ReassignReviewer(ctx, prID, "history-r1", "history-r3"))
+	require.NoError(t, reviewersRepo.ReassignReviewer(ctx, prID, "history-r3", "history-r1"))
+	require.ErrorIs(t, reviewersRepo.ReassignReviewer(ctx, prID, "history-r3", "history-r1"), domain.ErrNotAssigned)
+
+	current, err := reviewersRepo.GetAssignedReviewers(ctx, prID)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"history-r1", "history-r2"}, current)
+
+	// Закрытие снимает всех, повторное открытие назначает тех же ревьюверов заново
+	released, err := prRepo.ClosePullRequest(ctx, prID)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"history-r1", "history-r2"}, released)
+
+	current, err = reviewersRepo.GetAssignedReviewers(ctx, prID)
+	require.NoError(t, err)
+	assert.Empty(t, current)
+
+	require.NoError(t, prRepo.OpenPullRequest(ctx, prID, domain.PRStatusClosed, []string{"history-r1", "history-r2"}, false))
+
+	history, err := reviewersRepo.GetReviewerHistory(ctx, prID)
+	require.NoError(t, err)
+
+	type entry struct {
+		reviewerID string
+		reason     domain.UnassignReason
+	}
+	got := make([]entry, 0, len(history))
+	for _, assignment := range history {
+		require.NotNil(t, assignment.AssignedAt)
+		assert.Equal(t, assignment.Reason == "", assignment.UnassignedAt == nil, assignment.ReviewerID)
+		got = append(got, entry{assignment.ReviewerID, assignment.Reason})
+	}
+	assert.ElementsMatch(t, []entry{
+		{"history-r1", domain.UnassignReasonManualReassign},
+		{"history-r3", domain.UnassignReasonManualReassign},
+		{"history-r1", domain.UnassignReasonClosed},
+		{"history-r2", domain.UnassignReasonClosed},
+		{"history-r1", ""},
+		{"history-r2", ""},
+	}, got)
+
+	reviews, err := reviewersRepo.GetReviews(ctx, prID)
+	require.NoError(t, err)
+	assert.Len(t, reviews, 2)
+}
@@ -0,0 +1,79 @@
+//go:build integration
+
+package integration_tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"AVITOSAMPISHU/internal/domain"
+	reviewer_repository "AVITOSAMPISHU/internal/repository/reviewer_repository"
+	team_repository "AVITOSAMPISHU/internal/repository/team_repository"
+	user_repository "AVITOSAMPISHU/internal/repository/user_repository"
+	reviewer_selector "AVITOSAMPISHU/internal/service/reviewer_selector"
+	user_service "AVITOSAMPISHU/internal/service/user_service"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationGetUserReviewsPagination(t *testing.T) {
+	truncateAll(t)
+
+	ctx := context.Background()
+
+	teamID := uuid.New()
+	_, err := testDB.ExecContext(ctx, `INSERT INTO teams (id, team_name) VALUES ($1, 'team-reviews')`, teamID)
+	require.NoError(t, err)
+	for _, userID := range []string{"author", "reviewer"} {
+		_, err = testDB.ExecContext(ctx,
+			`INSERT INTO users (id, username, team_id, is_active) VALUES ($1, $1, $2, true)`, userID, teamID)
+		require.NoError(t, err)
+	}
+
+	// pr-0 самый старый; pr-2 слит и в выборку по OPEN не попадает
+	statuses := []string{"OPEN", "OPEN", "MERGED", "OPEN"}
+	for i, status := range statuses {
+		prID := fmt.Sprintf("pr-%d", i)
+		_, err = testDB.ExecContext(ctx,
+			`INSERT INTO pull_requests (id, pull_requests_name, author_id, status, created_at)
+			 VALUES ($1, $1, 'author', $2, TIMESTAMP '2025-10-01 10:00:00' + make_interval(hours => $3))`,
+			prID, status, i,
+		)
+		require.NoError(t, err)
+		_, err = testDB.ExecContext(ctx, `INSERT INTO reviewers (pull_request_id, reviewer_id) VALUES ($1, 'reviewer')`, prID)
+		require.NoError(t, err)
+	}
+
+	prReviewersRepo := reviewer_repository.NewPrReviewersStorage(testDB)
+	userSvc := user_service.NewUserService(
+		user_repository.NewUserRepository(testDB),
+		prReviewersRepo,
+		team_repository.NewTeamStorage(testDB),
+		reviewer_selector.NewRandomSelector(),
+	)
+
+	var collected []string
+	req := &domain.GetUserReviewsReq{
+		UserID:   "reviewer",
+		Statuses: []domain.PRStatus{domain.PRStatusOpen},
+		Limit:    2,
+	}
+	for {
+		res, err := userSvc.GetUserReviews(ctx, req)
+		require.NoError(t, err)
+		for _, pr := range res.PullRequests {
+			collected = append(collected, pr.PullRequestID)
+		}
+		if res.NextCursor == "" {
+			break
+		}
+		req.Cursor, err = domain.DecodePageCursor(res.NextCursor)
+		require.NoError(t, err)
+	}
+	require.Equal(t, []string{"pr-3", "pr-1", "pr-0"}, collected)
+
+	_, err = userSvc.GetUserReviews(ctx, &domain.GetUserReviewsReq{UserID: "ghost"})
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
@@ -2,47 +2,94 @@ package app
 
 import (
 	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"AVITOSAMPISHU/internal/config"
+	"AVITOSAMPISHU/internal/domain"
 	"AVITOSAMPISHU/internal/handlers"
+	"AVITOSAMPISHU/internal/infrastructure/auth"
 	"AVITOSAMPISHU/internal/infrastructure/database"
+	"AVITOSAMPISHU/internal/infrastructure/migrator"
+	"AVITOSAMPISHU/internal/infrastructure/ratelimit"
+	"AVITOSAMPISHU/internal/infrastructure/tracing"
 	"AVITOSAMPISHU/internal/middleware"
+	apikey_repository "AVITOSAMPISHU/internal/repository/apikey_repository"
+	audit_repository "AVITOSAMPISHU/internal/repository/audit_repository"
+	idempotency_repository "AVITOSAMPISHU/internal/repository/idempotency_repository"
+	outbox_repository "AVITOSAMPISHU/internal/repository/outbox_repository"
 	pullrequest_repository "AVITOSAMPISHU/internal/repository/pullrequest_repository"
 	reviewer_repository "AVITOSAMPISHU/internal/repository/reviewer_repository"
+	stats_repository "AVITOSAMPISHU/internal/repository/stats_repository"
 	team_repository "AVITOSAMPISHU/internal/repository/team_repository"
 	user_repository "AVITOSAMPISHU/internal/repository/user_repository"
+	webhook_repository "AVITOSAMPISHU/internal/repository/webhook_repository"
 	"AVITOSAMPISHU/internal/server"
+	apikey_service "AVITOSAMPISHU/internal/service/apikey_service"
+	audit_service "AVITOSAMPISHU/internal/service/audit_service"
+	idempotency_service "AVITOSAMPISHU/internal/service/idempotency_service"
+	load_metrics_service "AVITOSAMPISHU/internal/service/load_metrics_service"
+	outbox_dispatcher "AVITOSAMPISHU/internal/service/outbox_dispatcher"
+	policy_service "AVITOSAMPISHU/internal/service/policy_service"
 	pullrequest_service "AVITOSAMPISHU/internal/service/pullrequest_service"
+	reviewer_selector "AVITOSAMPISHU/internal/service/reviewer_selector"
+	stats_service "AVITOSAMPISHU/internal/service/stats_service"
 	team_service "AVITOSAMPISHU/internal/service/team_service"
 	user_service "AVITOSAMPISHU/internal/service/user_service"
+	webhook_service "AVITOSAMPISHU/internal/service/webhook_service"
+	"AVITOSAMPISHU/migrations"
 	"AVITOSAMPISHU/pkg/logger"
 	"AVITOSAMPISHU/pkg/metrics"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-const (
-	shutdownTimeoutSeconds = 30
-)
+// tracingFlushTimeout сколько ждать выгрузки оставшихся спанов при остановке
+const tracingFlushTimeout = 5 * time.Second
+
+// Run загружает конфигурацию из файла, окружения и флагов args, затем инициализирует и запускает приложение
+func Run(args []string) int {
+	cfg, _, err := config.Load("app", args)
+	if err != nil {
+		return reportConfigError(err)
+	}
 
-// Run инициализирует и запускает приложение
-func Run() {
 	// Инициализация логгера
-	logger.InitLogger()
+	if err := logger.InitLoggerWithConfig(cfg.LoggerOptions()); err != nil {
+		fmt.Fprintln(os.Stderr, "error initializing logger:", err)
+		return 1
+	}
 	defer logger.Sync()
 
 	logger.Logger.Infow("initializing application")
 
+	// Трейсы: спаны HTTP-запросов, методов сервисов и SQL-запросов
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.TracingOptions())
+	if err != nil {
+		logger.Logger.Fatalw("error configuring tracing", "error", err)
+	}
+	defer func() {
+		// Отдельный таймаут: к этому моменту контекст остановки сервера может быть уже исчерпан
+		flushCtx, flushCancel := context.WithTimeout(context.Background(), tracingFlushTimeout)
+		defer flushCancel()
+		if err := shutdownTracing(flushCtx); err != nil {
+			logger.Logger.Errorw("tracing shutdown error", "error", err)
+		}
+	}()
+
 	// Подключение к БД
-	dbCtx, dbCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	dbCtx, dbCancel := context.WithTimeout(context.Background(), cfg.Database.ConnectTimeout)
 	defer dbCancel()
 
-	db, err := database.NewDB(dbCtx)
+	db, err := database.NewDBWithConfig(dbCtx, cfg.DatabaseOptions())
 	if err != nil {
 		logger.Logger.Fatalw("error connecting to database", "error", err)
 	}
@@ -50,38 +97,175 @@ func Run() {
 
 	logger.Logger.Infow("database connection established")
 
+	// Миграции встроены в бинарник. С migrations.on_start они применяются при старте под advisory lock,
+	// поэтому несколько реплик не накатят их одновременно
+	schemaMigrator, err := migrator.New(db, migrations.FS)
+	if err != nil {
+		logger.Logger.Fatalw("error loading migrations", "error", err)
+	}
+	if cfg.Migrations.OnStart {
+		migrateCtx, migrateCancel := context.WithTimeout(context.Background(), migrateTimeout)
+		applied, err := schemaMigrator.Up(migrateCtx)
+		migrateCancel()
+		if err != nil {
+			logger.Logger.Fatalw("error applying migrations", "error", err)
+		}
+		logger.Logger.Infow("migrations applied", "count", applied, "version", schemaMigrator.Latest())
+	}
+
+	// Схема новее бинарника — отказываемся стартовать. Отстающая схема не мешает старту,
+	// но /readyz будет отвечать 503, пока миграции не применят
+	checkCtx, checkCancel := context.WithTimeout(context.Background(), domain.ReadinessCheckTimeout)
+	err = schemaMigrator.Check(checkCtx)
+	checkCancel()
+	if err != nil {
+		if errors.Is(err, migrator.ErrSchemaTooNew) {
+			logger.Logger.Fatalw("refusing to start", "error", err)
+		}
+		logger.Logger.Warnw("database schema is not ready", "error", err)
+	}
+
 	// Инициализация репозиториев
 	teamRepo := team_repository.NewTeamStorage(db)
 	userRepo := user_repository.NewUserRepository(db)
 	prRepo := pullrequest_repository.NewPullRequestStorage(db)
 	prReviewersRepo := reviewer_repository.NewPrReviewersStorage(db)
+	webhookRepo := webhook_repository.NewWebhookStorage(db)
+	outboxRepo := outbox_repository.NewOutboxStorage(db)
+	auditRepo := audit_repository.NewAuditStorage(db)
+	apiKeyRepo := apikey_repository.NewAPIKeyStorage(db)
+	idempotencyRepo := idempotency_repository.NewIdempotencyStorage(db)
+	statsRepo := stats_repository.NewStatsStorage(db)
 
 	// Инициализация сервисов
+	reviewerSelector := reviewer_selector.NewTeamStrategySelector(prReviewersRepo)
+	webhookSvc := webhook_service.NewWebhookService(webhookRepo)
 	teamSvc := team_service.NewTeamService(teamRepo, userRepo)
-	userSvc := user_service.NewUserService(userRepo, prReviewersRepo, teamRepo)
-	prSvc := pullrequest_service.NewPullRequestService(prRepo, prReviewersRepo, userRepo, teamRepo)
+	userSvc := user_service.NewUserService(userRepo, prReviewersRepo, teamRepo, reviewerSelector)
+	prSvc := pullrequest_service.NewPullRequestService(prRepo, prReviewersRepo, userRepo, teamRepo, reviewerSelector)
+	apiKeySvc := apikey_service.NewAPIKeyService(apiKeyRepo)
+	loadMetricsSvc := load_metrics_service.NewLoadMetricsService(statsRepo)
+
+	idempotencySvc := idempotency_service.NewIdempotencyService(idempotencyRepo, cfg.Idempotency.KeyTTL)
+
+	// Фоновая рассылка событий из outbox
+	dispatcherCtx, dispatcherCancel := context.WithCancel(context.Background())
+	defer dispatcherCancel()
+	dispatcherDone := make(chan struct{})
+	dispatcher := outbox_dispatcher.NewDispatcher(outboxRepo, webhookSvc, loadMetricsSvc)
+	go func() {
+		defer close(dispatcherDone)
+		dispatcher.Run(dispatcherCtx)
+	}()
+
+	// Фоновое удаление обработанных событий outbox, останавливается вместе с диспетчером
+	go dispatcher.RunCleanup(dispatcherCtx, domain.OutboxCleanupInterval, domain.OutboxRetention)
+
+	// Возобновление доставок вебхуков, брошенных при прошлой остановке; останавливается в webhookSvc.Shutdown
+	webhookSvc.StartResumer(domain.WebhookResumeInterval)
+
+	// Фоновая очистка истёкших ключей идемпотентности, останавливается вместе с диспетчером
+	go idempotencySvc.RunCleanup(dispatcherCtx, domain.IdempotencyCleanupInterval)
+
+	// Метрики нагрузки ревьюверов и команд: пересчёт по таймеру и после событий из outbox
+	go loadMetricsSvc.Run(dispatcherCtx, domain.LoadMetricsRefreshInterval)
 
 	// Создание роутера
 	mux := http.NewServeMux()
 
 	// Регистрация метрик
-	prometheus.MustRegister(metrics.ReviewerLoadDistribution)
+	prometheus.MustRegister(
+		metrics.ReviewerOpenReviews,
+		metrics.TeamOpenPullRequests,
+		metrics.TeamPullRequestsNeedMoreReviewers,
+		metrics.TeamInactiveMembers,
+		metrics.RateLimitedRequests,
+		metrics.HTTPRequestsTotal,
+		metrics.HTTPRequestDuration,
+		metrics.HTTPRequestsInFlight,
+		metrics.DBQueryDuration,
+		metrics.DBQueryErrors,
+		// Состояние пула соединений (sql.DBStats): занятые, простаивающие, ожидания свободного соединения
+		collectors.NewDBStatsCollector(db, cfg.Database.Name),
+	)
 	mux.Handle("/metrics", promhttp.Handler())
 
 	logger.Logger.Infow("metrics registered")
 
-	// Регистрация роутов
-	handlers.RegisterRoutes(mux, teamSvc, userSvc, prSvc)
+	// Регистрация роутов: сначала проверяются права, затем успешные изменяющие операции пишутся в журнал аудита
+	auditSvc := audit_service.NewAuditService(auditRepo)
+	policySvc := policy_service.NewPolicyService(userRepo, prRepo)
+	handlers.RegisterRoutes(mux,
+		policySvc.WrapTeamService(auditSvc.WrapTeamService(teamSvc)),
+		policySvc.WrapUserService(auditSvc.WrapUserService(userSvc)),
+		policySvc.WrapPullRequestService(auditSvc.WrapPullRequestService(prSvc)),
+		policySvc.WrapWebhookService(auditSvc.WrapWebhookService(webhookSvc)),
+		policySvc.WrapAuditService(auditSvc),
+		policySvc.WrapAPIKeyService(auditSvc.WrapAPIKeyService(apiKeySvc)),
+		stats_service.NewStatsService(statsRepo),
+	)
 
 	logger.Logger.Infow("routes registered")
 
-	// Применение middleware (сначала логирование, потом авторизация)
-	handler := middleware.AuthMiddleware(middleware.LoggingMiddleware(mux))
+	// Проверка JWT
+	verifier, err := auth.NewVerifier(cfg.JWTOptions())
+	if err != nil {
+		logger.Logger.Fatalw("error configuring authentication", "error", err)
+	}
+	if !verifier.Enabled() {
+		logger.Logger.Warnw("JWT verification is not configured: only API keys and the bootstrap admin token are accepted")
+	}
+
+	// Статический токен администратора принимается только при явно включённом BOOTSTRAP_ADMIN
+	adminToken := cfg.BootstrapAdminToken()
+	if adminToken != "" {
+		logger.Logger.Warnw("bootstrap admin is enabled: ADMIN_TOKEN grants admin rights, disable BOOTSTRAP_ADMIN once JWT or API keys are set up")
+	} else if cfg.Auth.AdminToken != "" {
+		logger.Logger.Warnw("ADMIN_TOKEN is ignored because BOOTSTRAP_ADMIN is disabled")
+	}
+
+	// Ограничение частоты запросов
+	rateLimitCfg, err := cfg.RateLimitOptions()
+	if err != nil {
+		logger.Logger.Fatalw("error configuring rate limiting", "error", err)
+	}
+	limiter := ratelimit.NewLimiter(rateLimitCfg)
+	ipLimiter := ratelimit.NewLimiter(cfg.IPRateLimitOptions())
+
+	// Применение middleware: идентификатор запроса, трейсинг, логирование, ограничение частоты по IP, авторизация
+	// по JWT или ключу API, ограничение частоты по клиенту и ключи идемпотентности. Логирование стоит снаружи
+	// лимита по IP и авторизации, чтобы их отказы 429 и 401 попадали в access-лог и RED-метрики. Лимит по IP стоит
+	// до авторизации, чтобы запросы с неверными учётными данными тоже ограничивались; последние два различают
+	// клиентов по инициатору
+	handler := middleware.RequestIDMiddleware(middleware.TracingMiddleware(middleware.LoggingMiddleware(mux,
+		middleware.IPRateLimitMiddleware(ipLimiter,
+			middleware.AuthMiddleware(verifier, apiKeySvc, adminToken,
+				middleware.RateLimitMiddleware(limiter,
+					middleware.IdempotencyMiddleware(idempotencySvc, mux)))))))
+
+	// Создание сервера. После сигнала остановки /readyz сразу отвечает 503, а сервер ещё server.drain_delay
+	// принимает запросы, чтобы балансировщик успел вывести инстанс из ротации
+	srv := server.NewAPIServer(handler, cfg.ServerOptions())
 
-	// Создание сервера
-	srv := server.NewAPIServer(handler)
+	// Пробы живости и готовности, доступны без авторизации
+	handlers.NewHealthHandler(
+		handlers.ReadinessCheck{Name: "database", Check: db.PingContext},
+		handlers.ReadinessCheck{Name: "migrations", Check: func(ctx context.Context) error {
+			// Более новая схема при выкатке допустима: её применяют новые реплики, пока старые ещё обслуживают трафик
+			if err := schemaMigrator.Check(ctx); err != nil && !errors.Is(err, migrator.ErrSchemaTooNew) {
+				return err
+			}
+			return nil
+		}},
+		handlers.ReadinessCheck{Name: "shutdown", Check: func(context.Context) error {
+			if srv.ShuttingDown() {
+				return errors.New("server is shutting down")
+			}
+			return nil
+		}},
+	).Register(mux)
 
-	logger.Logger.Infow("server created", "port", os.Getenv("API_PORT"))
+	logger.Logger.Infow("server created", "port", cfg.Server.Port)
 
 	// Запуск сервера в горутине
 	go func() {
@@ -99,13 +283,46 @@ func Run() {
 
 	logger.Logger.Infow("shutting down server...")
 
-	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), shutdownTimeoutSeconds*time.Second)
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
 	defer shutdownCancel()
 
 	if err := srv.Shutdown(shutdownCtx); err != nil {
 		logger.Logger.Errorw("shutdown error", "error", err)
-		os.Exit(1)
+		return 1
+	}
+
+	// Останавливаем разбор outbox: необработанные события разошлёт следующий запуск
+	dispatcherCancel()
+	<-dispatcherDone
+
+	// Дожидаемся фоновых доставок вебхуков, начатых до остановки сервера
+	if err := webhookSvc.Shutdown(shutdownCtx); err != nil {
+		logger.Logger.Errorw("webhook deliveries shutdown error", "error", err)
 	}
 
 	logger.Logger.Infow("server stopped gracefully")
+	return 0
+}
+
+// PrintConfig выводит итоговую конфигурацию после слияния всех источников, секреты скрыты
+func PrintConfig(args []string) int {
+	cfg, _, err := config.Load("app config print", args)
+	if err != nil {
+		return reportConfigError(err)
+	}
+
+	if err := config.Print(os.Stdout, cfg); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
+
+// reportConfigError печатает ошибку загрузки конфигурации; логгер в этот момент ещё не настроен
+func reportConfigError(err error) int {
+	if errors.Is(err, flag.ErrHelp) {
+		return 0
+	}
+	fmt.Fprintln(os.Stderr, err)
+	return 2
 }
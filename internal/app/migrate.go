@@ -0,0 +1,141 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"AVITOSAMPISHU/internal/config"
+	"AVITOSAMPISHU/internal/infrastructure/database"
+	"AVITOSAMPISHU/internal/infrastructure/migrator"
+	"AVITOSAMPISHU/migrations"
+	"AVITOSAMPISHU/pkg/logger"
+)
+
+const migrateUsage = `usage: app migrate [flags] <command>
+
+commands:
+  up          применить все новые миграции
+  down N      откатить N последних миграций
+  status      показать текущую версию схемы и ещё не применённые миграции
+  force V     записать версию V без выполнения миграций (после ручной починки грязной схемы)
+
+flags те же, что у сервиса (--config, --db-host, ...), см. app -h`
+
+// migrateTimeout ограничивает ожидание advisory lock и выполнение миграций
+const migrateTimeout = 10 * time.Minute
+
+// Migrate выполняет подкоманду migrate и возвращает код выхода процесса
+func Migrate(args []string) int {
+	cfg, args, err := config.Load("app migrate", args)
+	if err != nil {
+		return reportConfigError(err)
+	}
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	if err := logger.InitLoggerWithConfig(cfg.LoggerOptions()); err != nil {
+		fmt.Fprintln(os.Stderr, "error initializing logger:", err)
+		return 1
+	}
+	defer logger.Sync()
+
+	ctx, cancel := context.WithTimeout(context.Background(), migrateTimeout)
+	defer cancel()
+
+	db, err := database.NewDBWithConfig(ctx, cfg.DatabaseOptions())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error connecting to database:", err)
+		return 1
+	}
+	defer db.Close()
+
+	m, err := migrator.New(db, migrations.FS)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error loading migrations:", err)
+		return 1
+	}
+
+	if err := runMigrateCommand(ctx, m, args[0], args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		if errors.Is(err, errMigrateUsage) {
+			fmt.Fprintln(os.Stderr, migrateUsage)
+			return 2
+		}
+		return 1
+	}
+	return 0
+}
+
+var errMigrateUsage = errors.New("invalid migrate arguments")
+
+func runMigrateCommand(ctx context.Context, m *migrator.Migrator, command string, args []string) error {
+	switch command {
+	case "up":
+		if len(args) != 0 {
+			return fmt.Errorf("%w: up takes no arguments", errMigrateUsage)
+		}
+		applied, err := m.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("applied %d migration(s), schema is at version %d\n", applied, m.Latest())
+		return nil
+
+	case "down":
+		n, err := singleIntArg(command, args)
+		if err != nil {
+			return err
+		}
+		reverted, err := m.Down(ctx, int(n))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("rolled back %d migration(s)\n", reverted)
+		return nil
+
+	case "status":
+		if len(args) != 0 {
+			return fmt.Errorf("%w: status takes no arguments", errMigrateUsage)
+		}
+		status, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("version: %d\ndirty: %t\nlatest: %d\n", status.Version, status.Dirty, status.Latest)
+		for _, pending := range status.Pending {
+			fmt.Printf("pending: %04d_%s\n", pending.Version, pending.Name)
+		}
+		return nil
+
+	case "force":
+		version, err := singleIntArg(command, args)
+		if err != nil {
+			return err
+		}
+		if err := m.Force(ctx, version); err != nil {
+			return err
+		}
+		fmt.Printf("schema version forced to %d\n", version)
+		return nil
+
+	default:
+		return fmt.Errorf("%w: unknown command %q", errMigrateUsage, command)
+	}
+}
+
+func singleIntArg(command string, args []string) (int64, error) {
+	if len(args) != 1 {
+		return 0, fmt.Errorf("%w: %s takes exactly one number", errMigrateUsage, command)
+	}
+	n, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%w: %s expects a non-negative number, got %q", errMigrateUsage, command, args[0])
+	}
+	return n, nil
+}
@@ -0,0 +1,271 @@
+// Package config собирает настройки сервиса из значений по умолчанию, файла YAML/TOML,
+// переменных окружения и флагов командной строки (каждый следующий источник перекрывает предыдущий)
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/infrastructure/auth"
+	"AVITOSAMPISHU/internal/infrastructure/database"
+	"AVITOSAMPISHU/internal/infrastructure/ratelimit"
+	"AVITOSAMPISHU/internal/infrastructure/tracing"
+	"AVITOSAMPISHU/internal/server"
+	"AVITOSAMPISHU/pkg/logger"
+)
+
+// Теги полей: yaml и toml — ключ в файле, env — переменная окружения (из неё же выводится имя флага:
+// DB_MAX_OPEN_CONNS → --db-max-open-conns), desc — описание флага, secret — значение скрывается в config print
+
+type Config struct {
+	Server      ServerConfig      `yaml:"server" toml:"server"`
+	Database    DatabaseConfig    `yaml:"database" toml:"database"`
+	Log         LogConfig         `yaml:"log" toml:"log"`
+	Auth        AuthConfig        `yaml:"auth" toml:"auth"`
+	RateLimit   RateLimitConfig   `yaml:"rate_limit" toml:"rate_limit"`
+	Idempotency IdempotencyConfig `yaml:"idempotency" toml:"idempotency"`
+	Migrations  MigrationsConfig  `yaml:"migrations" toml:"migrations"`
+	Tracing     TracingConfig     `yaml:"tracing" toml:"tracing"`
+}
+
+type ServerConfig struct {
+	Port            string        `yaml:"port" toml:"port" env:"API_PORT" desc:"HTTP port"`
+	ReadTimeout     time.Duration `yaml:"read_timeout" toml:"read_timeout" env:"HTTP_READ_TIMEOUT" desc:"maximum duration for reading the whole request"`
+	WriteTimeout    time.Duration `yaml:"write_timeout" toml:"write_timeout" env:"HTTP_WRITE_TIMEOUT" desc:"maximum duration before timing out writes of the response"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT" desc:"how long graceful shutdown may take in total"`
+	DrainDelay      time.Duration `yaml:"drain_delay" toml:"drain_delay" env:"SHUTDOWN_DRAIN_DELAY" desc:"how long to keep serving after /readyz starts failing on shutdown"`
+}
+
+type DatabaseConfig struct {
+	Host            string        `yaml:"host" toml:"host" env:"DB_HOST" desc:"PostgreSQL host"`
+	Port            string        `yaml:"port" toml:"port" env:"DB_PORT" desc:"PostgreSQL port"`
+	User            string        `yaml:"user" toml:"user" env:"DB_USER" desc:"PostgreSQL user"`
+	Password        string        `yaml:"password" toml:"password" env:"DB_PASSWORD" desc:"PostgreSQL password" secret:"true"`
+	Name            string        `yaml:"name" toml:"name" env:"DB_NAME" desc:"PostgreSQL database name"`
+	SSLMode         string        `yaml:"ssl_mode" toml:"ssl_mode" env:"DB_SSLMODE" desc:"PostgreSQL sslmode"`
+	MaxOpenConns    int           `yaml:"max_open_conns" toml:"max_open_conns" env:"DB_MAX_OPEN_CONNS" desc:"maximum number of open connections"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" toml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS" desc:"maximum number of idle connections"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" toml:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME" desc:"maximum time a connection may be reused"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time" toml:"conn_max_idle_time" env:"DB_CONN_MAX_IDLE_TIME" desc:"maximum time a connection may stay idle"`
+	ConnectTimeout  time.Duration `yaml:"connect_timeout" toml:"connect_timeout" env:"DB_CONNECT_TIMEOUT" desc:"how long to wait for the database at startup"`
+}
+
+type LogConfig struct {
+	Level            string   `yaml:"level" toml:"level" env:"LOG_LEVEL" desc:"log level: debug, info, warn or error"`
+	OutputPaths      []string `yaml:"output_paths" toml:"output_paths" env:"LOG_OUTPUT_PATHS" desc:"comma-separated log outputs"`
+	ErrorOutputPaths []string `yaml:"error_output_paths" toml:"error_output_paths" env:"LOG_ERROR_OUTPUT_PATHS" desc:"comma-separated outputs for logger errors"`
+}
+
+type AuthConfig struct {
+	BootstrapAdmin bool   `yaml:"bootstrap_admin" toml:"bootstrap_admin" env:"BOOTSTRAP_ADMIN" desc:"accept admin_token as an admin bearer token, for bootstrap and development only"`
+	AdminToken     string `yaml:"admin_token" toml:"admin_token" env:"ADMIN_TOKEN" desc:"static bearer token with admin rights, accepted only with bootstrap_admin" secret:"true"`
+	JWTHS256Secret string `yaml:"jwt_hs256_secret" toml:"jwt_hs256_secret" env:"JWT_HS256_SECRET" desc:"HS256 secret for JWT verification" secret:"true"`
+	JWTJWKSFile    string `yaml:"jwt_jwks_file" toml:"jwt_jwks_file" env:"JWT_JWKS_FILE" desc:"JWKS file with RS256 public keys"`
+	JWTIssuer      string `yaml:"jwt_issuer" toml:"jwt_issuer" env:"JWT_ISSUER" desc:"expected JWT issuer"`
+	JWTAudience    string `yaml:"jwt_audience" toml:"jwt_audience" env:"JWT_AUDIENCE" desc:"expected JWT audience"`
+}
+
+type RateLimitConfig struct {
+	RPS     float64 `yaml:"rps" toml:"rps" env:"RATE_LIMIT_RPS" desc:"requests per second per client, 0 disables the default limit"`
+	Burst   int     `yaml:"burst" toml:"burst" env:"RATE_LIMIT_BURST" desc:"token bucket capacity"`
+	Routes  string  `yaml:"routes" toml:"routes" env:"RATE_LIMIT_ROUTES" desc:"per-route limits: /path=rps:burst,..."`
+	IPRPS   float64 `yaml:"ip_rps" toml:"ip_rps" env:"RATE_LIMIT_IP_RPS" desc:"requests per second per IP before authentication, 0 disables the limit"`
+	IPBurst int     `yaml:"ip_burst" toml:"ip_burst" env:"RATE_LIMIT_IP_BURST" desc:"token bucket capacity per IP before authentication"`
+}
+
+type IdempotencyConfig struct {
+	KeyTTL time.Duration `yaml:"key_ttl" toml:"key_ttl" env:"IDEMPOTENCY_KEY_TTL" desc:"how long responses to Idempotency-Key requests are kept"`
+}
+
+type MigrationsConfig struct {
+	OnStart bool `yaml:"on_start" toml:"on_start" env:"MIGRATE_ON_START" desc:"apply embedded migrations at startup"`
+}
+
+type TracingConfig struct {
+	Exporter     string  `yaml:"exporter" toml:"exporter" env:"OTEL_TRACES_EXPORTER" desc:"trace exporter: none, stdout or otlp"`
+	OTLPEndpoint string  `yaml:"otlp_endpoint" toml:"otlp_endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT" desc:"OTLP/HTTP collector URL, e.g. http://otel-collector:4318"`
+	SampleRatio  float64 `yaml:"sample_ratio" toml:"sample_ratio" env:"OTEL_TRACES_SAMPLER_ARG" desc:"fraction of new traces to sample, from 0 to 1"`
+	ServiceName  string  `yaml:"service_name" toml:"service_name" env:"OTEL_SERVICE_NAME" desc:"service.name resource attribute"`
+}
+
+// Default значения по умолчанию, совпадающие с прежними константами и переменными окружения
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			Port:            "8080",
+			ReadTimeout:     15 * time.Second,
+			WriteTimeout:    15 * time.Second,
+			ShutdownTimeout: 30 * time.Second,
+			DrainDelay:      5 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Host:            "localhost",
+			Port:            "5432",
+			User:            "avito_user",
+			Password:        "avito_password",
+			Name:            "avito_pr_db",
+			SSLMode:         "disable",
+			MaxOpenConns:    10,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+			ConnectTimeout:  10 * time.Second,
+		},
+		Log: LogConfig{
+			Level:            "info",
+			OutputPaths:      []string{"logs/log.txt", "stdout"},
+			ErrorOutputPaths: []string{"logs/error.txt", "stderr"},
+		},
+		RateLimit: RateLimitConfig{
+			RPS:     20,
+			Burst:   40,
+			IPRPS:   100,
+			IPBurst: 200,
+		},
+		Idempotency: IdempotencyConfig{
+			KeyTTL: domain.DefaultIdempotencyKeyTTL,
+		},
+		Tracing: TracingConfig{
+			Exporter:    tracing.ExporterNone,
+			SampleRatio: 1,
+			ServiceName: "pr-reviewer-service",
+		},
+	}
+}
+
+// Validate проверяет значения после слияния всех источников и возвращает все ошибки разом
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	port, err := strconv.Atoi(c.Server.Port)
+	check(err == nil && port > 0 && port <= 65535, "server.port: %q is not a valid port", c.Server.Port)
+	check(c.Server.ReadTimeout > 0, "server.read_timeout must be positive")
+	check(c.Server.WriteTimeout > 0, "server.write_timeout must be positive")
+	check(c.Server.ShutdownTimeout > 0, "server.shutdown_timeout must be positive")
+	check(c.Server.DrainDelay >= 0 && c.Server.DrainDelay < c.Server.ShutdownTimeout,
+		"server.drain_delay must be non-negative and shorter than server.shutdown_timeout")
+
+	check(c.Database.Host != "", "database.host is required")
+	check(c.Database.Name != "", "database.name is required")
+	check(c.Database.MaxOpenConns > 0, "database.max_open_conns must be positive")
+	check(c.Database.MaxIdleConns >= 0 && c.Database.MaxIdleConns <= c.Database.MaxOpenConns,
+		"database.max_idle_conns must be between 0 and database.max_open_conns")
+	check(c.Database.ConnMaxLifetime >= 0, "database.conn_max_lifetime must not be negative")
+	check(c.Database.ConnMaxIdleTime >= 0, "database.conn_max_idle_time must not be negative")
+	check(c.Database.ConnectTimeout > 0, "database.connect_timeout must be positive")
+
+	_, err = logger.ParseLevel(c.Log.Level)
+	check(err == nil, "log.level: %v", err)
+	check(len(c.Log.OutputPaths) > 0, "log.output_paths must not be empty")
+
+	check(c.RateLimit.RPS >= 0, "rate_limit.rps must not be negative")
+	check(c.RateLimit.Burst >= 0, "rate_limit.burst must not be negative")
+	_, err = ratelimit.ParseRoutes(c.RateLimit.Routes)
+	check(err == nil, "rate_limit.routes: %v", err)
+	check(c.RateLimit.IPRPS >= 0, "rate_limit.ip_rps must not be negative")
+	check(c.RateLimit.IPBurst >= 0, "rate_limit.ip_burst must not be negative")
+
+	check(!c.Auth.BootstrapAdmin || c.Auth.AdminToken != "", "auth.admin_token is required when auth.bootstrap_admin is enabled")
+
+	check(c.Idempotency.KeyTTL > 0, "idempotency.key_ttl must be positive")
+
+	switch c.Tracing.Exporter {
+	case tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterOTLP:
+	default:
+		errs = append(errs, fmt.Errorf("tracing.exporter: unknown exporter %q, expected none, stdout or otlp", c.Tracing.Exporter))
+	}
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio must be between 0 and 1")
+	check(c.Tracing.ServiceName != "", "tracing.service_name is required")
+
+	return errors.Join(errs...)
+}
+
+// ServerOptions настройки HTTP-сервера
+func (c *Config) ServerOptions() server.Config {
+	return server.Config{
+		Port:         c.Server.Port,
+		ReadTimeout:  c.Server.ReadTimeout,
+		WriteTimeout: c.Server.WriteTimeout,
+		DrainDelay:   c.Server.DrainDelay,
+	}
+}
+
+// DatabaseOptions настройки подключения и пула соединений
+func (c *Config) DatabaseOptions() database.Config {
+	return database.Config{
+		Host:            c.Database.Host,
+		Port:            c.Database.Port,
+		User:            c.Database.User,
+		Password:        c.Database.Password,
+		DBName:          c.Database.Name,
+		SSLMode:         c.Database.SSLMode,
+		MaxOpenConns:    c.Database.MaxOpenConns,
+		MaxIdleConns:    c.Database.MaxIdleConns,
+		ConnMaxLifetime: c.Database.ConnMaxLifetime,
+		ConnMaxIdleTime: c.Database.ConnMaxIdleTime,
+	}
+}
+
+// LoggerOptions настройки логгера
+func (c *Config) LoggerOptions() logger.Config {
+	return logger.Config{
+		Level:            c.Log.Level,
+		OutputPaths:      c.Log.OutputPaths,
+		ErrorOutputPaths: c.Log.ErrorOutputPaths,
+	}
+}
+
+// JWTOptions настройки проверки JWT
+func (c *Config) JWTOptions() auth.Config {
+	return auth.Config{
+		HS256Secret: c.Auth.JWTHS256Secret,
+		JWKSFile:    c.Auth.JWTJWKSFile,
+		Issuer:      c.Auth.JWTIssuer,
+		Audience:    c.Auth.JWTAudience,
+	}
+}
+
+// BootstrapAdminToken статический токен администратора; пустой, если auth.bootstrap_admin выключен
+func (c *Config) BootstrapAdminToken() string {
+	if !c.Auth.BootstrapAdmin {
+		return ""
+	}
+	return c.Auth.AdminToken
+}
+
+// TracingOptions настройки экспорта трейсов
+func (c *Config) TracingOptions() tracing.Config {
+	return tracing.Config{
+		Exporter:     c.Tracing.Exporter,
+		OTLPEndpoint: c.Tracing.OTLPEndpoint,
+		SampleRatio:  c.Tracing.SampleRatio,
+		ServiceName:  c.Tracing.ServiceName,
+	}
+}
+
+// RateLimitOptions лимиты запросов. Маршруты уже проверены в Validate
+func (c *Config) RateLimitOptions() (ratelimit.Config, error) {
+	routes, err := ratelimit.ParseRoutes(c.RateLimit.Routes)
+	if err != nil {
+		return ratelimit.Config{}, err
+	}
+	return ratelimit.Config{
+		Default: ratelimit.Limit{RPS: c.RateLimit.RPS, Burst: c.RateLimit.Burst},
+		Routes:  routes,
+	}, nil
+}
+
+// IPRateLimitOptions лимит запросов с одного IP до аутентификации, общий для всех маршрутов
+func (c *Config) IPRateLimitOptions() ratelimit.Config {
+	return ratelimit.Config{
+		Default: ratelimit.Limit{RPS: c.RateLimit.IPRPS, Burst: c.RateLimit.IPBurst},
+	}
+}
@@ -0,0 +1,148 @@
+package config
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func envMap(values map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := values[key]
+		return value, ok
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	cfg, rest, err := load("app", []string{"status"}, envMap(nil), io.Discard)
+	require.NoError(t, err)
+
+	assert.Equal(t, Default(), *cfg)
+	assert.Equal(t, []string{"status"}, rest)
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	path := writeFile(t, "config.yaml", `
+server:
+  port: "9000"
+  read_timeout: 3s
+database:
+  host: db.internal
+  max_open_conns: 20
+log:
+  level: warn
+`)
+	env := envMap(map[string]string{
+		"CONFIG_FILE":       path,
+		"DB_HOST":           "db.env",
+		"DB_MAX_OPEN_CONNS": "30",
+		"LOG_OUTPUT_PATHS":  "stdout, logs/app.log",
+	})
+
+	cfg, _, err := load("app", []string{"--db-max-open-conns=40"}, env, io.Discard)
+	require.NoError(t, err)
+
+	assert.Equal(t, "9000", cfg.Server.Port, "file overrides default")
+	assert.Equal(t, 3*time.Second, cfg.Server.ReadTimeout)
+	assert.Equal(t, "warn", cfg.Log.Level)
+	assert.Equal(t, "db.env", cfg.Database.Host, "env overrides file")
+	assert.Equal(t, []string{"stdout", "logs/app.log"}, cfg.Log.OutputPaths)
+	assert.Equal(t, 40, cfg.Database.MaxOpenConns, "flag overrides env")
+	assert.Equal(t, 15*time.Second, cfg.Server.WriteTimeout, "untouched values keep defaults")
+}
+
+func TestLoad_TOML(t *testing.T) {
+	path := writeFile(t, "config.toml", `
+[server]
+shutdown_timeout = "1m"
+
+[migrations]
+on_start = true
+`)
+
+	cfg, _, err := load("app", []string{"--config", path}, envMap(nil), io.Discard)
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Minute, cfg.Server.ShutdownTimeout)
+	assert.True(t, cfg.Migrations.OnStart)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		args []string
+		env  map[string]string
+	}{
+		{"unknown yaml key", "server:\n  prot: \"80\"\n", nil, nil},
+		{"bad env value", "", nil, map[string]string{"HTTP_READ_TIMEOUT": "fast"}},
+		{"bad flag value", "", []string{"--db-max-idle-conns=many"}, nil},
+		{"unknown flag", "", []string{"--no-such-flag"}, nil},
+		{"invalid port", "", []string{"--api-port=http"}, nil},
+		{"idle above open", "", []string{"--db-max-open-conns=2", "--db-max-idle-conns=3"}, nil},
+		{"drain longer than shutdown", "", []string{"--shutdown-drain-delay=1m"}, nil},
+		{"unknown log level", "", []string{"--log-level=verbose"}, nil},
+		{"bad rate limit routes", "", []string{"--rate-limit-routes=users"}, nil},
+		{"unknown trace exporter", "", nil, map[string]string{"OTEL_TRACES_EXPORTER": "jaeger"}},
+		{"sample ratio above one", "", []string{"--otel-traces-sampler-arg=2"}, nil},
+		{"bootstrap admin without token", "", nil, map[string]string{"BOOTSTRAP_ADMIN": "true"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := tt.args
+			if tt.file != "" {
+				args = append([]string{"--config", writeFile(t, "config.yaml", tt.file)}, args...)
+			}
+
+			_, _, err := load("app", args, envMap(tt.env), io.Discard)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestBootstrapAdminToken(t *testing.T) {
+	cfg, _, err := load("app", nil, envMap(map[string]string{"ADMIN_TOKEN": "admin-secret"}), io.Discard)
+	require.NoError(t, err)
+	assert.Empty(t, cfg.BootstrapAdminToken(), "admin token must be ignored unless bootstrap_admin is enabled")
+
+	cfg, _, err = load("app", nil, envMap(map[string]string{"ADMIN_TOKEN": "admin-secret", "BOOTSTRAP_ADMIN": "true"}), io.Discard)
+	require.NoError(t, err)
+	assert.Equal(t, "admin-secret", cfg.BootstrapAdminToken())
+}
+
+func TestPrint_RedactsSecrets(t *testing.T) {
+	cfg := Default()
+	cfg.Auth.AdminToken = "admin-secret"
+	cfg.Database.Password = "db-secret"
+
+	var out bytes.Buffer
+	require.NoError(t, Print(&out, &cfg))
+
+	assert.NotContains(t, out.String(), "admin-secret")
+	assert.NotContains(t, out.String(), "db-secret")
+	assert.Contains(t, out.String(), "admin_token: "+redacted)
+	assert.Equal(t, "admin-secret", cfg.Auth.AdminToken, "printing must not modify the config")
+
+	// Вывод без секретов можно загрузить обратно как файл конфигурации
+	cfg.Auth.AdminToken = ""
+	cfg.Database.Password = ""
+	out.Reset()
+	require.NoError(t, Print(&out, &cfg))
+	loaded, _, err := load("app", []string{"--config", writeFile(t, "printed.yaml", out.String())}, envMap(nil), io.Discard)
+	require.NoError(t, err)
+	assert.Equal(t, cfg, *loaded)
+}
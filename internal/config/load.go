@@ -0,0 +1,199 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// FileEnv переменная окружения с путём к файлу конфигурации; флаг --config важнее
+const FileEnv = "CONFIG_FILE"
+
+// Load собирает конфигурацию: значения по умолчанию, файл из --config или CONFIG_FILE, переменные окружения, флаги.
+// Возвращает аргументы после флагов (например, команду migrate) и ошибку валидации.
+// При -h/--help возвращает flag.ErrHelp, справка уже выведена в stderr
+func Load(name string, args []string) (*Config, []string, error) {
+	return load(name, args, os.LookupEnv, os.Stderr)
+}
+
+func load(name string, args []string, lookupEnv func(string) (string, bool), output io.Writer) (*Config, []string, error) {
+	cfg := Default()
+	fields := collectFields(reflect.ValueOf(&cfg).Elem(), "")
+
+	// Флаги разбираются первыми, чтобы узнать путь к файлу, но применяются последними
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(output)
+	configFile := fs.String("config", "", "path to YAML or TOML config file (env "+FileEnv+")")
+	flagValues := make(map[string]string, len(fields))
+	for _, f := range fields {
+		fs.Func(f.flagName(), f.desc+" (env "+f.env+")", func(value string) error {
+			if err := f.set(value); err != nil {
+				return err
+			}
+			flagValues[f.env] = value
+			return nil
+		})
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	// Разбор флагов уже записал их значения в cfg: начинаем заново с умолчаний
+	cfg = Default()
+	fields = collectFields(reflect.ValueOf(&cfg).Elem(), "")
+
+	path := *configFile
+	if path == "" {
+		path, _ = lookupEnv(FileEnv)
+	}
+	if path != "" {
+		if err := loadFile(path, &cfg); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for _, f := range fields {
+		if value, ok := lookupEnv(f.env); ok && value != "" {
+			if err := f.set(value); err != nil {
+				return nil, nil, fmt.Errorf("%s: %w", f.env, err)
+			}
+		}
+	}
+	for _, f := range fields {
+		if value, ok := flagValues[f.env]; ok {
+			if err := f.set(value); err != nil {
+				return nil, nil, fmt.Errorf("--%s: %w", f.flagName(), err)
+			}
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	return &cfg, fs.Args(), nil
+}
+
+// loadFile читает YAML (.yaml, .yml) или TOML (.toml). Неизвестные ключи считаются ошибкой, чтобы опечатки не терялись молча
+func loadFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("parse %s: %w", path, err)
+		}
+	case ".toml":
+		meta, err := toml.Decode(string(data), cfg)
+		if err != nil {
+			return fmt.Errorf("parse %s: %w", path, err)
+		}
+		if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+			return fmt.Errorf("parse %s: unknown keys %v", path, undecoded)
+		}
+	default:
+		return fmt.Errorf("config file %s: unsupported extension, expected .yaml, .yml or .toml", path)
+	}
+	return nil
+}
+
+// field лист конфигурации, который можно задать через окружение и флаг
+type field struct {
+	path   string
+	env    string
+	desc   string
+	secret bool
+	value  reflect.Value
+}
+
+func (f field) flagName() string {
+	return strings.ReplaceAll(strings.ToLower(f.env), "_", "-")
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+func (f field) set(raw string) error {
+	v := f.value
+	switch {
+	case v.Type() == durationType:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+	case v.Kind() == reflect.String:
+		v.SetString(raw)
+	case v.Kind() == reflect.Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(n))
+	case v.Kind() == reflect.Float64:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	case v.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String:
+		parts := strings.Split(raw, ",")
+		items := make([]string, 0, len(parts))
+		for _, part := range parts {
+			if part = strings.TrimSpace(part); part != "" {
+				items = append(items, part)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported config field type %s", v.Type())
+	}
+	return nil
+}
+
+func collectFields(v reflect.Value, prefix string) []field {
+	var fields []field
+	t := v.Type()
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		key := sf.Tag.Get("yaml")
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		if sf.Type.Kind() == reflect.Struct && sf.Type != durationType {
+			fields = append(fields, collectFields(v.Field(i), key)...)
+			continue
+		}
+		if env := sf.Tag.Get("env"); env != "" {
+			fields = append(fields, field{
+				path:   key,
+				env:    env,
+				desc:   sf.Tag.Get("desc"),
+				secret: sf.Tag.Get("secret") == "true",
+				value:  v.Field(i),
+			})
+		}
+	}
+	return fields
+}
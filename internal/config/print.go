@@ -0,0 +1,27 @@
+package config
+
+import (
+	"io"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "<redacted>"
+
+// Print выводит итоговую конфигурацию в YAML, пригодном как файл конфигурации. Секреты заменяются на <redacted>
+func Print(w io.Writer, cfg *Config) error {
+	masked := *cfg
+	for _, f := range collectFields(reflect.ValueOf(&masked).Elem(), "") {
+		if f.secret && f.value.String() != "" {
+			f.value.SetString(redacted)
+		}
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&masked); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
@@ -0,0 +1,53 @@
+package domain
+
+import "context"
+
+// Role роль пользователя, определяет доступные ему операции
+type Role string
+
+// Validate проверяет, что роль может быть выдана пользователю. RoleService выдаётся только ключам API
+func (r Role) Validate() bool {
+	switch r {
+	case RoleAdmin, RoleTeamLead, RoleMember:
+		return true
+	default:
+		return false
+	}
+}
+
+// Actor описывает того, кто выполняет запрос
+type Actor struct {
+	ID     string
+	Role   Role
+	Scopes []APIKeyScope // Разрешения ключа API, у пользователей пусто
+}
+
+// IsAdmin сообщает, есть ли у инициатора права администратора
+func (a Actor) IsAdmin() bool {
+	return a.Role == RoleAdmin
+}
+
+// HasScope сообщает, выдано ли инициатору разрешение ключа API
+func (a Actor) HasScope(scope APIKeyScope) bool {
+	for _, s := range a.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type actorContextKey struct{}
+
+// ContextWithActor сохраняет инициатора запроса в контексте
+func ContextWithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext возвращает инициатора запроса. Если он не задан, возвращается анонимный пользователь без прав администратора
+func ActorFromContext(ctx context.Context) Actor {
+	if actor, ok := ctx.Value(actorContextKey{}).(Actor); ok {
+		return actor
+	}
+	return Actor{ID: AnonymousActorID}
+}
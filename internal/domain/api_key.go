@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKeyScope разрешение ключа API на группу изменяющих операций. Чтение доступно любому действующему ключу
+type APIKeyScope string
+
+// Validate проверяет, что разрешение известно
+func (s APIKeyScope) Validate() bool {
+	switch s {
+	case ScopePullRequestWrite, ScopeTeamAdmin:
+		return true
+	default:
+		return false
+	}
+}
+
+// APIKey ключ для межсервисных вызовов. В БД хранится только хеш ключа
+type APIKey struct {
+	ID         uuid.UUID     `json:"id"`
+	Name       string        `json:"name"`
+	Key        string        `json:"key,omitempty"` // Отдаётся только при создании ключа
+	Prefix     string        `json:"prefix"`        // Начало ключа, чтобы отличать ключи в списке
+	Scopes     []APIKeyScope `json:"scopes"`
+	CreatedBy  string        `json:"created_by"`
+	ExpiresAt  *time.Time    `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time    `json:"last_used_at,omitempty"`
+	CreatedAt  *time.Time    `json:"created_at,omitempty"`
+	RevokedAt  *time.Time    `json:"revoked_at,omitempty"`
+}
+
+// ActorID идентификатор инициатора запросов, выполненных по этому ключу
+func (k *APIKey) ActorID() string {
+	return APIKeyActorPrefix + k.ID.String()
+}
+
+type CreateAPIKeyReq struct {
+	Name      string        `json:"name"`
+	Scopes    []APIKeyScope `json:"scopes"`
+	ExpiresAt *time.Time    `json:"expires_at,omitempty"` // Если не задан, ключ бессрочный
+}
+
+type RevokeAPIKeyReq struct {
+	ID uuid.UUID `json:"id"`
+}
+
+type APIKeyResponse struct {
+	APIKey *APIKey `json:"api_key"`
+}
+
+type ListAPIKeysResponse struct {
+	APIKeys []APIKey `json:"api_keys"`
+}
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// AuditEntityType тип сущности, над которой выполнена операция
+type AuditEntityType string
+
+// Validate проверяет, что тип сущности известен
+func (e AuditEntityType) Validate() bool {
+	switch e {
+	case AuditEntityTeam, AuditEntityUser, AuditEntityPullRequest, AuditEntityWebhook, AuditEntityAPIKey:
+		return true
+	default:
+		return false
+	}
+}
+
+// AuditEvent запись журнала аудита об одной изменяющей операции
+type AuditEvent struct {
+	ID         int64           `json:"id"`
+	Actor      string          `json:"actor"`
+	Operation  string          `json:"operation"`
+	EntityType AuditEntityType `json:"entity_type"`
+	EntityID   string          `json:"entity_id"`
+	TargetIDs  []string        `json:"target_ids"`       // Другие затронутые сущности: ревьюверы, пользователи
+	Params     json.RawMessage `json:"params,omitempty"` // Параметры вызова API, например force при слиянии
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	RequestID  string          `json:"request_id,omitempty"`
+	CreatedAt  *time.Time      `json:"created_at,omitempty"`
+}
+
+// AuditChange изменение сущности, которое репозиторий записывает в журнал аудита в транзакции самого изменения.
+// Before и After читаются в той же транзакции, поэтому соответствуют зафиксированному изменению
+type AuditChange struct {
+	Operation  string // Используется, если операция API не задана в контексте
+	EntityType AuditEntityType
+	EntityID   string
+	TargetIDs  []string
+	Before     interface{}
+	After      interface{}
+}
+
+type auditOperationContextKey struct{}
+
+type auditOperation struct {
+	name   string
+	params interface{}
+}
+
+// ContextWithAuditOperation сохраняет в контексте имя вызванной операции API и её параметры для журнала аудита.
+// Секреты (ключи, секреты подписок) в params передавать нельзя
+func ContextWithAuditOperation(ctx context.Context, name string, params interface{}) context.Context {
+	return context.WithValue(ctx, auditOperationContextKey{}, auditOperation{name: name, params: params})
+}
+
+// AuditOperationFromContext возвращает имя операции API и её параметры, если они заданы
+func AuditOperationFromContext(ctx context.Context) (string, interface{}, bool) {
+	op, ok := ctx.Value(auditOperationContextKey{}).(auditOperation)
+	return op.name, op.params, ok
+}
+
+type ListAuditEventsReq struct {
+	EntityType AuditEntityType
+	EntityID   string
+	Actor      string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Cursor     *PageCursor
+}
+
+type ListAuditEventsResponse struct {
+	Events     []AuditEvent `json:"events"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+}
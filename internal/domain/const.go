@@ -1,8 +1,168 @@
 package domain
 
+import "time"
+
 const (
+	PRStatusDraft  PRStatus = "DRAFT"
 	PRStatusOpen   PRStatus = "OPEN"
 	PRStatusMerged PRStatus = "MERGED"
+	PRStatusClosed PRStatus = "CLOSED"
+)
+
+const (
+	ReviewVerdictPending          ReviewVerdict = "PENDING"
+	ReviewVerdictApproved         ReviewVerdict = "APPROVED"
+	ReviewVerdictChangesRequested ReviewVerdict = "CHANGES_REQUESTED"
+)
+
+const (
+	SelectionStrategyRandom      SelectionStrategy = "random"
+	SelectionStrategyLeastLoaded SelectionStrategy = "least_loaded"
+	SelectionStrategyRoundRobin  SelectionStrategy = "round_robin"
+)
+
+// Значения настроек команды по умолчанию, если они не заданы явно
+const (
+	DefaultRequiredReviewers int = 2
+	DefaultMinReviewers      int = 1
+	MaxRequiredReviewers     int = 10
+)
+
+const (
+	SortOrderAsc  SortOrder = "asc"
+	SortOrderDesc SortOrder = "desc"
+)
+
+// Размер страницы для списков с курсорной пагинацией
+const (
+	DefaultPageLimit int = 50
+	MaxPageLimit     int = 100
 )
 
-const MaxReviewersCount int = 2
+// Идентификаторы инициаторов запросов без пользователя: вне HTTP-запроса и по токену администратора при BOOTSTRAP_ADMIN
+const (
+	AnonymousActorID string = "anonymous"
+	AdminActorID     string = "admin"
+)
+
+const (
+	RoleAdmin    Role = "admin"
+	RoleTeamLead Role = "team_lead"
+	RoleMember   Role = "member"
+	// RoleService роль вызовов по ключу API: права определяются разрешениями ключа, в JWT не выдаётся
+	RoleService Role = "service"
+)
+
+const (
+	ScopePullRequestWrite APIKeyScope = "pr:write"
+	ScopeTeamAdmin        APIKeyScope = "team:admin"
+)
+
+// Ключи API имеют вид prk_<base64url>; по префиксу ключ отличается от JWT, а его начало показывается в списке ключей.
+// Время последнего использования обновляется не чаще раза в APIKeyTouchInterval, чтобы частые запросы не писали в одну строку
+const (
+	APIKeyPrefix        string        = "prk_"
+	APIKeyDisplayLength int           = 12
+	APIKeyActorPrefix   string        = "apikey:"
+	APIKeyTouchInterval time.Duration = time.Minute
+)
+
+// Ключ идемпотентности: заголовок, максимальная длина ключа, срок хранения ответа по умолчанию и период очистки истёкших ключей
+const (
+	IdempotencyKeyHeader       string        = "Idempotency-Key"
+	IdempotencyReplayedHeader  string        = "Idempotent-Replayed"
+	MaxIdempotencyKeyLength    int           = 255
+	DefaultIdempotencyKeyTTL   time.Duration = 24 * time.Hour
+	IdempotencyCleanupInterval time.Duration = time.Hour
+)
+
+// Как часто пересчитываются метрики нагрузки ревьюверов и команд. События из outbox запускают пересчёт раньше
+const LoadMetricsRefreshInterval time.Duration = 30 * time.Second
+
+// Период аналитики ревью, если границы не заданы в запросе
+const DefaultStatsWindow time.Duration = 30 * 24 * time.Hour
+
+// Причины снятия ревьювера с PR. При слиянии назначения завершаются временем слияния с причиной merged,
+// но ревьюверы слитого PR по-прежнему отдаются в его карточке
+const (
+	UnassignReasonManualReassign UnassignReason = "manual_reassign"
+	UnassignReasonDeactivation   UnassignReason = "deactivation"
+	UnassignReasonMerged         UnassignReason = "merged"
+	UnassignReasonClosed         UnassignReason = "closed"
+)
+
+// Выгрузка истории ревью: форматы по заголовку Accept, как часто отправлять клиенту накопленные строки
+// и на сколько продлевать дедлайн записи после каждой отправки, чтобы долгая выгрузка не упиралась в server.write_timeout
+const (
+	ExportFormatCSV    string        = "text/csv"
+	ExportFormatNDJSON string        = "application/x-ndjson"
+	ExportFlushRows    int           = 500
+	ExportWriteTimeout time.Duration = 30 * time.Second
+)
+
+// Статусы проверок здоровья и сколько ждать одну проверку готовности
+const (
+	HealthStatusOK          string        = "ok"
+	HealthStatusUnavailable string        = "unavailable"
+	ReadinessCheckTimeout   time.Duration = 2 * time.Second
+)
+
+// RequestIDHeader заголовок с идентификатором запроса: принимается от клиента или генерируется и возвращается в ответе
+const RequestIDHeader = "X-Request-ID"
+
+const (
+	AuditEntityTeam        AuditEntityType = "team"
+	AuditEntityUser        AuditEntityType = "user"
+	AuditEntityPullRequest AuditEntityType = "pull_request"
+	AuditEntityWebhook     AuditEntityType = "webhook"
+	AuditEntityAPIKey      AuditEntityType = "api_key"
+)
+
+const (
+	EventPullRequestCreated     EventType = "pull_request_created"
+	EventPullRequestMerged      EventType = "pull_request_merged"
+	EventReviewerReassigned     EventType = "reviewer_reassigned"
+	EventTeamMembersDeactivated EventType = "team_members_deactivated"
+)
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "PENDING"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "SUCCEEDED"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "FAILED"
+)
+
+// Разбор outbox: сколько событий забирается за раз и на сколько они закрепляются за экземпляром сервиса,
+// как часто проверяются новые, после скольких неудачных попыток событие отбрасывается,
+// сколько хранятся обработанные события и как часто они удаляются
+const (
+	OutboxBatchSize       int           = 100
+	OutboxClaimTimeout    time.Duration = time.Minute
+	OutboxPollInterval    time.Duration = time.Second
+	OutboxMaxAttempts     int           = 8
+	OutboxRetention       time.Duration = 7 * 24 * time.Hour
+	OutboxCleanupInterval time.Duration = time.Hour
+)
+
+// Политика доставки вебхуков: экспоненциальная задержка между попытками, начиная с WebhookBaseBackoff
+const (
+	WebhookMaxAttempts    int           = 5
+	WebhookBaseBackoff    time.Duration = time.Second
+	WebhookMaxBackoff     time.Duration = time.Minute
+	WebhookRequestTimeout time.Duration = 10 * time.Second
+)
+
+// Возобновление доставок, брошенных остановленным или упавшим процессом: как часто искать и сколько брать за раз.
+// Доставка считается брошенной, если очередная попытка просрочена больше чем на WebhookResumeOverdue —
+// этого с запасом хватает, чтобы живой процесс успел выполнить попытку и записать её результат
+const (
+	WebhookResumeInterval  time.Duration = 30 * time.Second
+	WebhookResumeOverdue   time.Duration = time.Minute
+	WebhookResumeBatchSize int           = 100
+)
+
+// Заголовки запроса к подписчику. Подпись — HMAC-SHA256 тела запроса в hex с префиксом "sha256="
+const (
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+)
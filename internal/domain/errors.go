@@ -14,6 +14,15 @@ var (
 	ErrInternalError          = errors.New("internal server error")
 	ErrFailedToDecodeJSON     = errors.New("failed to decode JSON")
 	ErrQueryParameterRequired = errors.New("query parameter is required")
+	ErrNotApproved            = errors.New("PR does not have enough approvals to be merged")
+	ErrForbidden              = errors.New("operation is not permitted")
+	ErrInvalidTransition      = errors.New("illegal PR status transition")
+	ErrPRNotOpen              = errors.New("operation requires an OPEN PR")
+	ErrUnauthorized           = errors.New("authentication required")
+	ErrIdempotencyKeyReused   = errors.New("idempotency key was already used with a different request")
+	ErrIdempotencyInProgress  = errors.New("request with this idempotency key is still in progress")
+	ErrIdempotencyNotReplayed = errors.New("request with this idempotency key was already executed, its response contains a secret and is not stored")
+	ErrNotAcceptable          = errors.New("none of the accepted media types is supported")
 )
 
 type ErrorCode string
@@ -29,6 +38,16 @@ const (
 	ErrorCodeInternalError          ErrorCode = "INTERNAL_ERROR"
 	ErrorCodeFailedToDecodeJSON     ErrorCode = "FAILED_TO_DECODE_JSON"
 	ErrorCodeQueryParameterRequired ErrorCode = "QUERY_PARAMETER_REQUIRED"
+	ErrorCodeNotApproved            ErrorCode = "NOT_APPROVED"
+	ErrorCodeForbidden              ErrorCode = "FORBIDDEN"
+	ErrorCodeInvalidTransition      ErrorCode = "INVALID_TRANSITION"
+	ErrorCodePRNotOpen              ErrorCode = "PR_NOT_OPEN"
+	ErrorCodeUnauthorized           ErrorCode = "UNAUTHORIZED"
+	ErrorCodeRateLimited            ErrorCode = "RATE_LIMITED"
+	ErrorCodeIdempotencyKeyReused   ErrorCode = "IDEMPOTENCY_KEY_REUSED"
+	ErrorCodeIdempotencyInProgress  ErrorCode = "IDEMPOTENCY_IN_PROGRESS"
+	ErrorCodeIdempotencyNotReplayed ErrorCode = "IDEMPOTENCY_NOT_REPLAYED"
+	ErrorCodeNotAcceptable          ErrorCode = "NOT_ACCEPTABLE"
 )
 
 type ErrorResponse struct {
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventType тип доменного события. События пишутся в outbox в той же транзакции, что и изменение состояния
+type EventType string
+
+// Validate проверяет, что тип события известен
+func (e EventType) Validate() bool {
+	switch e {
+	case EventPullRequestCreated, EventPullRequestMerged, EventReviewerReassigned, EventTeamMembersDeactivated:
+		return true
+	default:
+		return false
+	}
+}
+
+// Event конверт доменного события. Data зависит от типа события
+type Event struct {
+	ID         uuid.UUID       `json:"id"`
+	Type       EventType       `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// NewEvent создаёт событие с новым идентификатором и сериализованными данными
+func NewEvent(eventType EventType, data interface{}) (*Event, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Event{
+		ID:         uuid.New(),
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		Data:       raw,
+	}, nil
+}
+
+// OutboxEvent событие, забранное из outbox на обработку: Seq — номер записи в очереди,
+// Attempts — сколько раз обработка уже завершалась ошибкой
+type OutboxEvent struct {
+	Seq      int64
+	Attempts int
+	Event    Event
+}
+
+// ReviewerReassignedEvent данные события reviewer_reassigned
+type ReviewerReassignedEvent struct {
+	PullRequestID string `json:"pull_request_id"`
+	OldReviewerID string `json:"old_reviewer_id"`
+	NewReviewerID string `json:"new_reviewer_id"`
+}
+
+// TeamMembersDeactivatedEvent данные события team_members_deactivated
+type TeamMembersDeactivatedEvent struct {
+	TeamName           string                 `json:"team_name"`
+	DeactivatedUserIDs []string               `json:"deactivated_user_ids"`
+	Reassignments      []ReviewerReassignment `json:"reassignments"`
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// ReviewExportRow одно назначение ревьювера в выгрузке истории ревью. Пока назначение действует,
+// removed_at и reason пусты. team_name — команда автора PR
+type ReviewExportRow struct {
+	PullRequestID string         `json:"pull_request_id"`
+	AuthorID      string         `json:"author_id"`
+	ReviewerID    string         `json:"reviewer_id"`
+	TeamName      string         `json:"team_name"`
+	AssignedAt    *time.Time     `json:"assigned_at"`
+	RemovedAt     *time.Time     `json:"removed_at"`
+	Reason        UnassignReason `json:"reason,omitempty"`
+	MergedAt      *time.Time     `json:"merged_at"`
+}
+
+// ExportReviewsReq фильтры выгрузки: команда автора и период назначения [From, To). Пустые поля не ограничивают выборку
+type ExportReviewsReq struct {
+	TeamName string
+	From     *time.Time
+	To       *time.Time
+}
@@ -0,0 +1,7 @@
+package domain
+
+// HealthResponse ответ /healthz и /readyz. Checks содержит результат каждой проверки готовности: "ok" или текст ошибки
+type HealthResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
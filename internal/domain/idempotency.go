@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// IdempotencyRecord сохранённый результат запроса с заголовком Idempotency-Key.
+// Ключ принадлежит инициатору запроса: одинаковые ключи разных пользователей не пересекаются
+type IdempotencyRecord struct {
+	Principal   string
+	Key         string
+	RequestHash string // SHA-256 метода, пути и тела запроса: повтор с другим запросом отклоняется
+	StatusCode  int    // 0, пока первый запрос ещё выполняется
+	Body        []byte
+	CreatedAt   *time.Time
+	ExpiresAt   *time.Time
+}
+
+// Completed сообщает, сохранён ли уже ответ на первый запрос
+func (r *IdempotencyRecord) Completed() bool {
+	return r.StatusCode != 0
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SortOrder направление сортировки списков
+type SortOrder string
+
+// Validate проверяет валидность направления сортировки
+func (o SortOrder) Validate() bool {
+	switch o {
+	case SortOrderAsc, SortOrderDesc:
+		return true
+	default:
+		return false
+	}
+}
+
+// PageCursor позиция последнего элемента страницы в порядке (created_at, id)
+type PageCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// Encode кодирует курсор в непрозрачную для клиента строку
+func (c PageCursor) Encode() string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodePageCursor разбирает курсор, выданный Encode
+func DecodePageCursor(value string) (*PageCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidRequest)
+	}
+
+	createdAt, id, found := strings.Cut(string(raw), "|")
+	if !found || id == "" {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidRequest)
+	}
+
+	ts, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidRequest)
+	}
+
+	return &PageCursor{CreatedAt: ts, ID: id}, nil
+}
@@ -1,45 +1,151 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 type PRStatus string
 
 // Validate проверяет валидность статуса PR
 func (s PRStatus) Validate() bool {
 	switch s {
-	case PRStatusOpen, PRStatusMerged:
+	case PRStatusDraft, PRStatusOpen, PRStatusMerged, PRStatusClosed:
 		return true
 	default:
 		return false
 	}
 }
 
+// prStatusTransitions допустимые переходы жизненного цикла PR. MERGED — конечное состояние
+var prStatusTransitions = map[PRStatus][]PRStatus{
+	PRStatusDraft:  {PRStatusOpen, PRStatusClosed},
+	PRStatusOpen:   {PRStatusMerged, PRStatusClosed},
+	PRStatusClosed: {PRStatusOpen},
+}
+
+// CanTransitionTo проверяет, допустим ли переход PR в статус next
+func (s PRStatus) CanTransitionTo(next PRStatus) bool {
+	for _, allowed := range prStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateStatusTransition возвращает ErrInvalidTransition, если переход from -> to запрещён
+func ValidateStatusTransition(from, to PRStatus) error {
+	if !from.CanTransitionTo(to) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, from, to)
+	}
+	return nil
+}
+
+// ReviewVerdict решение ревьювера по PR
+type ReviewVerdict string
+
+// Validate проверяет валидность решения ревьювера
+func (v ReviewVerdict) Validate() bool {
+	switch v {
+	case ReviewVerdictPending, ReviewVerdictApproved, ReviewVerdictChangesRequested:
+		return true
+	default:
+		return false
+	}
+}
+
+// ReviewerState состояние ревью конкретного назначенного ревьювера
+type ReviewerState struct {
+	ReviewerID string        `json:"reviewer_id"`
+	Verdict    ReviewVerdict `json:"verdict"`
+	Comment    string        `json:"comment,omitempty"`
+	AssignedAt *time.Time    `json:"assigned_at,omitempty"`
+	ReviewedAt *time.Time    `json:"reviewed_at,omitempty"`
+}
+
+// UnassignReason почему ревьювер перестал быть назначен на PR
+type UnassignReason string
+
+// ReviewerAssignment одно назначение ревьювера в истории PR. У действующего назначения нет unassigned_at и reason
+type ReviewerAssignment struct {
+	ReviewerID   string         `json:"reviewer_id"`
+	Verdict      ReviewVerdict  `json:"verdict"`
+	Comment      string         `json:"comment,omitempty"`
+	AssignedAt   *time.Time     `json:"assigned_at,omitempty"`
+	ReviewedAt   *time.Time     `json:"reviewed_at,omitempty"`
+	UnassignedAt *time.Time     `json:"unassigned_at,omitempty"`
+	Reason       UnassignReason `json:"reason,omitempty"`
+}
+
 type PullRequest struct {
-	PullRequestID     string     `json:"pull_request_id" db:"pull_request_id"`
-	PullRequestName   string     `json:"pull_request_name" db:"pull_request_name"`
-	AuthorID          string     `json:"author_id" db:"author_id"`
-	Status            PRStatus   `json:"status" db:"status"` // Используем ENUM в качестве статуса так-как скорее всего изменять его не будут
-	AssignedReviewers []string   `json:"assigned_reviewers" db:"assigned_reviewers"`
-	NeedMoreReviewers *bool      `json:"need_more_reviewers,omitempty"`
-	CreatedAt         *time.Time `json:"createdAt" db:"created_at"`
-	MergedAt          *time.Time `json:"mergedAt" db:"merged_at"`
+	PullRequestID     string          `json:"pull_request_id" db:"pull_request_id"`
+	PullRequestName   string          `json:"pull_request_name" db:"pull_request_name"`
+	AuthorID          string          `json:"author_id" db:"author_id"`
+	AuthorUsername    string          `json:"author_username,omitempty"`
+	TeamName          string          `json:"team_name,omitempty"` // Команда автора
+	Status            PRStatus        `json:"status" db:"status"`  // Используем ENUM в качестве статуса так-как скорее всего изменять его не будут
+	AssignedReviewers []string        `json:"assigned_reviewers" db:"assigned_reviewers"`
+	Reviews           []ReviewerState `json:"reviews,omitempty"`
+	NeedMoreReviewers *bool           `json:"need_more_reviewers,omitempty"`
+	CreatedAt         *time.Time      `json:"createdAt" db:"created_at"`
+	MergedAt          *time.Time      `json:"mergedAt" db:"merged_at"`
+	ClosedAt          *time.Time      `json:"closedAt,omitempty" db:"closed_at"`
+}
+
+// SetReviews обновляет состояния ревью и синхронизирует с ними список назначенных ревьюверов
+func (pr *PullRequest) SetReviews(reviews []ReviewerState) {
+	reviewerIDs := make([]string, 0, len(reviews))
+	for _, review := range reviews {
+		reviewerIDs = append(reviewerIDs, review.ReviewerID)
+	}
+	pr.Reviews = reviews
+	pr.AssignedReviewers = reviewerIDs
+}
+
+// CountVerdicts считает одобрения и запросы изменений среди назначенных ревьюверов
+func (pr *PullRequest) CountVerdicts() (approved, changesRequested int) {
+	for _, review := range pr.Reviews {
+		switch review.Verdict {
+		case ReviewVerdictApproved:
+			approved++
+		case ReviewVerdictChangesRequested:
+			changesRequested++
+		}
+	}
+	return approved, changesRequested
 }
 
 type PullRequestShort struct {
-	PullRequestID   string   `json:"pull_request_id"`
-	PullRequestName string   `json:"pull_request_name"`
-	AuthorID        string   `json:"author_id"`
-	Status          PRStatus `json:"status"`
+	PullRequestID   string     `json:"pull_request_id"`
+	PullRequestName string     `json:"pull_request_name"`
+	AuthorID        string     `json:"author_id"`
+	Status          PRStatus   `json:"status"`
+	CreatedAt       *time.Time `json:"createdAt,omitempty"`
 }
 
 type CreatePullRequestReq struct {
 	PullRequestID   string `json:"pull_request_id"`
 	PullRequestName string `json:"pull_request_name"`
 	AuthorID        string `json:"author_id"`
+	Draft           bool   `json:"draft,omitempty"` // Черновик создаётся без ревьюверов до перевода в OPEN
 }
 
 type MergePullRequestReq struct {
 	PullRequestID string `json:"pull_request_id"`
+	Force         bool   `json:"force,omitempty"` // Слить в обход проверки одобрений, доступно только администраторам
+}
+
+type ClosePullRequestReq struct {
+	PullRequestID string `json:"pull_request_id"`
+}
+
+type ReopenPullRequestReq struct {
+	PullRequestID string `json:"pull_request_id"`
+}
+
+type MarkReadyReq struct {
+	PullRequestID string `json:"pull_request_id"`
 }
 
 type ReassignReviewerReq struct {
@@ -47,16 +153,50 @@ type ReassignReviewerReq struct {
 	OldUserID     string `json:"old_user_id"`
 }
 
+type SubmitReviewReq struct {
+	PullRequestID string        `json:"pull_request_id"`
+	ReviewerID    string        `json:"reviewer_id"`
+	Verdict       ReviewVerdict `json:"verdict"`
+	Comment       string        `json:"comment,omitempty"`
+}
+
 type ReviewerReassignment struct {
 	PrID          string `json:"pr_id"`
 	OldReviewerID string `json:"old_reviewer_id"`
 	NewReviewerID string `json:"new_reviewer_id,omitempty"`
 }
 
+// ListPullRequestsReq фильтры и параметры страницы для списка PR. Пустые поля не ограничивают выборку
+type ListPullRequestsReq struct {
+	Statuses          []PRStatus
+	AuthorID          string
+	TeamName          string // Команда автора
+	ReviewerID        string
+	NeedMoreReviewers *bool
+	CreatedFrom       *time.Time
+	CreatedTo         *time.Time
+	MergedFrom        *time.Time
+	MergedTo          *time.Time
+	Order             SortOrder // Сортировка по created_at
+	Limit             int
+	Cursor            *PageCursor
+}
+
+type ListPullRequestsResponse struct {
+	PullRequests []PullRequest `json:"pull_requests"`
+	NextCursor   string        `json:"next_cursor,omitempty"`
+}
+
 type PullRequestResponse struct {
 	PR *PullRequest `json:"pr"`
 }
 
+type PullRequestHistoryResponse struct {
+	PullRequestID string               `json:"pull_request_id"`
+	Status        PRStatus             `json:"status"`
+	History       []ReviewerAssignment `json:"history"`
+}
+
 type ReassignReviewerResponse struct {
 	PR         *PullRequest `json:"pr"`
 	ReplacedBy string       `json:"replaced_by"`
@@ -0,0 +1,16 @@
+package domain
+
+import "context"
+
+type requestIDContextKey struct{}
+
+// ContextWithRequestID сохраняет идентификатор запроса в контексте
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, requestID)
+}
+
+// RequestIDFromContext возвращает идентификатор запроса или пустую строку, если он не задан
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDContextKey{}).(string)
+	return requestID
+}
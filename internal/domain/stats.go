@@ -0,0 +1,105 @@
+package domain
+
+import "time"
+
+// ReviewerLoad текущая нагрузка пользователя: сколько открытых PR ему назначено
+type ReviewerLoad struct {
+	UserID      string
+	TeamName    string
+	IsActive    bool
+	OpenReviews int
+}
+
+// TeamLoad срез нагрузки команды. Открытые PR относятся к команде автора
+type TeamLoad struct {
+	TeamName          string
+	OpenPullRequests  int
+	NeedMoreReviewers int
+	ActiveMembers     int
+	InactiveMembers   int
+	Reviewers         []ReviewerLoad
+}
+
+// DurationStats распределение длительностей в секундах. Если измерений нет, медиана и p90 — null
+type DurationStats struct {
+	Count         int      `json:"count"`
+	MedianSeconds *float64 `json:"median_seconds"`
+	P90Seconds    *float64 `json:"p90_seconds"`
+}
+
+// PullRequestThroughput сколько PR создано, слито и закрыто без слияния за период
+type PullRequestThroughput struct {
+	Created int `json:"created"`
+	Merged  int `json:"merged"`
+	Closed  int `json:"closed"`
+}
+
+// ReviewCounts вердикты, вынесенные за период
+type ReviewCounts struct {
+	Approved         int `json:"approved"`
+	ChangesRequested int `json:"changes_requested"`
+}
+
+// TeamOpenLoad текущая нагрузка команды, от периода не зависит
+type TeamOpenLoad struct {
+	OpenPullRequests  int `json:"open_pull_requests"`
+	NeedMoreReviewers int `json:"need_more_reviewers"`
+	OpenReviews       int `json:"open_reviews"` // Открытые PR, назначенные участникам команды
+}
+
+// TeamStats аналитика ревью команды за период [from, to). PR относятся к команде автора,
+// вердикты — к команде ревьювера
+type TeamStats struct {
+	TeamName          string                `json:"team_name"`
+	From              time.Time             `json:"from"`
+	To                time.Time             `json:"to"`
+	PullRequests      PullRequestThroughput `json:"pull_requests"`
+	TimeToFirstReview DurationStats         `json:"time_to_first_review"` // От создания PR до первого вердикта
+	TimeToMerge       DurationStats         `json:"time_to_merge"`        // От создания PR до слияния
+	Reviews           ReviewCounts          `json:"reviews"`
+	Reassignments     int                   `json:"reassignments"` // Переназначения ревьюверов на PR команды
+	OpenLoad          TeamOpenLoad          `json:"open_load"`
+}
+
+// UserReassignments сколько раз пользователя сняли с ревью и сколько раз назначили на замену
+type UserReassignments struct {
+	ReassignedAway int `json:"reassigned_away"`
+	ReassignedIn   int `json:"reassigned_in"`
+}
+
+// UserStats аналитика пользователя за период [from, to): как автора и как ревьювера
+type UserStats struct {
+	UserID            string                `json:"user_id"`
+	TeamName          string                `json:"team_name"`
+	From              time.Time             `json:"from"`
+	To                time.Time             `json:"to"`
+	PullRequests      PullRequestThroughput `json:"pull_requests"`        // Свои PR
+	TimeToFirstReview DurationStats         `json:"time_to_first_review"` // Свои PR
+	TimeToMerge       DurationStats         `json:"time_to_merge"`        // Свои PR
+	Reviews           ReviewCounts          `json:"reviews"`              // Вынесенные пользователем вердикты
+	ReviewTurnaround  DurationStats         `json:"review_turnaround"`    // От назначения до вердикта пользователя
+	Reassignments     UserReassignments     `json:"reassignments"`
+	OpenReviews       int                   `json:"open_reviews"`
+}
+
+// GetTeamStatsReq фильтры аналитики команды. Пустые границы периода заполняет сервис:
+// to — текущим временем, from — to минус DefaultStatsWindow
+type GetTeamStatsReq struct {
+	TeamName string
+	From     *time.Time
+	To       *time.Time
+}
+
+type GetUserStatsReq struct {
+	UserID string
+	From   *time.Time
+	To     *time.Time
+}
+
+type TeamStatsResponse struct {
+	Stats *TeamStats `json:"stats"`
+}
+
+type UserStatsResponse struct {
+	Stats *UserStats `json:"stats"`
+}
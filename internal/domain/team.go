@@ -1,5 +1,20 @@
 package domain
 
+import "time"
+
+// SelectionStrategy определяет политику выбора ревьюверов внутри команды
+type SelectionStrategy string
+
+// Validate проверяет валидность стратегии выбора ревьюверов
+func (s SelectionStrategy) Validate() bool {
+	switch s {
+	case SelectionStrategyRandom, SelectionStrategyLeastLoaded, SelectionStrategyRoundRobin:
+		return true
+	default:
+		return false
+	}
+}
+
 type TeamMember struct {
 	UserID   string `json:"user_id"`
 	Username string `json:"username"`
@@ -7,8 +22,59 @@ type TeamMember struct {
 }
 
 type Team struct {
-	TeamName string       `json:"team_name"`
-	Members  []TeamMember `json:"members"`
+	TeamName string        `json:"team_name"`
+	Members  []TeamMember  `json:"members"`
+	Settings *TeamSettings `json:"settings,omitempty"` // Если не указаны, используются значения по умолчанию
+}
+
+// EffectiveSettings возвращает настройки команды или значения по умолчанию, если они не загружены
+func (t *Team) EffectiveSettings() TeamSettings {
+	if t.Settings == nil {
+		return DefaultTeamSettings()
+	}
+	return *t.Settings
+}
+
+// Strategy возвращает стратегию выбора ревьюверов команды с учётом значения по умолчанию
+func (t *Team) Strategy() SelectionStrategy {
+	if strategy := t.EffectiveSettings().SelectionStrategy; strategy != "" {
+		return strategy
+	}
+	return SelectionStrategyRandom
+}
+
+// TeamSettings хранит политику команды, влияющую на назначение ревьюверов
+type TeamSettings struct {
+	RequiredReviewers      int               `json:"required_reviewers"`        // Сколько ревьюверов назначать на новый PR
+	MinReviewers           int               `json:"min_reviewers"`             // Ниже этого количества PR не может остаться при деактивации
+	SelectionStrategy      SelectionStrategy `json:"selection_strategy"`        // Стратегия выбора ревьюверов
+	AllowCrossTeamFallback bool              `json:"allow_cross_team_fallback"` // Добирать ревьюверов из других команд, если своих не хватает
+	RequiredApprovals      int               `json:"required_approvals"`        // Сколько одобрений нужно для слияния, 0 — проверка отключена
+	UpdatedAt              *time.Time        `json:"updated_at,omitempty"`
+}
+
+// DefaultTeamSettings возвращает настройки, применяемые к командам без явной конфигурации
+func DefaultTeamSettings() TeamSettings {
+	return TeamSettings{
+		RequiredReviewers: DefaultRequiredReviewers,
+		MinReviewers:      DefaultMinReviewers,
+		SelectionStrategy: SelectionStrategyRandom,
+	}
+}
+
+// UpdateTeamSettingsReq частично обновляет настройки: nil-поля остаются без изменений
+type UpdateTeamSettingsReq struct {
+	TeamName               string             `json:"team_name"`
+	RequiredReviewers      *int               `json:"required_reviewers,omitempty"`
+	MinReviewers           *int               `json:"min_reviewers,omitempty"`
+	SelectionStrategy      *SelectionStrategy `json:"selection_strategy,omitempty"`
+	AllowCrossTeamFallback *bool              `json:"allow_cross_team_fallback,omitempty"`
+	RequiredApprovals      *int               `json:"required_approvals,omitempty"`
+}
+
+type TeamSettingsResponse struct {
+	TeamName string        `json:"team_name"`
+	Settings *TeamSettings `json:"settings"`
 }
 
 type CreateTeamResponse struct {
@@ -16,7 +16,16 @@ type SetIsActiveResponse struct {
 	User *User `json:"user"`
 }
 
+// GetUserReviewsReq параметры страницы PR, где пользователь назначен ревьювером
+type GetUserReviewsReq struct {
+	UserID   string
+	Statuses []PRStatus
+	Limit    int
+	Cursor   *PageCursor
+}
+
 type GetUserReviewsResponse struct {
 	UserID       string             `json:"user_id"`
 	PullRequests []PullRequestShort `json:"pull_requests"`
+	NextCursor   string             `json:"next_cursor,omitempty"`
 }
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebhookSubscription struct {
+	ID        uuid.UUID   `json:"id"`
+	URL       string      `json:"url"`
+	Secret    string      `json:"secret,omitempty"` // Отдаётся только при создании подписки
+	Events    []EventType `json:"events"`
+	IsActive  bool        `json:"is_active"`
+	CreatedAt *time.Time  `json:"created_at,omitempty"`
+}
+
+type WebhookDeliveryStatus string
+
+// WebhookDelivery запись журнала доставки одного события одному подписчику
+type WebhookDelivery struct {
+	ID             uuid.UUID             `json:"id"`
+	SubscriptionID uuid.UUID             `json:"subscription_id"`
+	EventID        uuid.UUID             `json:"event_id"`
+	EventType      EventType             `json:"event_type"`
+	Payload        json.RawMessage       `json:"payload"` // Тело запроса, которое подписывается и отправляется как есть
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	LastStatusCode *int                  `json:"last_status_code,omitempty"`
+	LastError      string                `json:"last_error,omitempty"`
+	NextAttemptAt  *time.Time            `json:"next_attempt_at,omitempty"`
+	CreatedAt      *time.Time            `json:"created_at,omitempty"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty"`
+	ReplayOf       *uuid.UUID            `json:"replay_of,omitempty"` // Исходная доставка, если это ручной повтор
+}
+
+type CreateWebhookReq struct {
+	URL    string      `json:"url"`
+	Events []EventType `json:"events"`
+	Secret string      `json:"secret,omitempty"` // Если не задан, генерируется сервером
+}
+
+type DeleteWebhookReq struct {
+	ID uuid.UUID `json:"id"`
+}
+
+type ListWebhookDeliveriesReq struct {
+	SubscriptionID uuid.UUID
+	Limit          int
+}
+
+type ReplayWebhookDeliveryReq struct {
+	DeliveryID uuid.UUID `json:"delivery_id"`
+}
+
+type WebhookResponse struct {
+	Webhook *WebhookSubscription `json:"webhook"`
+}
+
+type ListWebhooksResponse struct {
+	Webhooks []WebhookSubscription `json:"webhooks"`
+}
+
+type WebhookDeliveryResponse struct {
+	Delivery *WebhookDelivery `json:"delivery"`
+}
+
+type ListWebhookDeliveriesResponse struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/service"
+	"AVITOSAMPISHU/pkg/logger"
+)
+
+type APIKeyHandler struct {
+	apiKeyService service.APIKeyService
+}
+
+func NewAPIKeyHandler(apiKeyService service.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{apiKeyService: apiKeyService}
+}
+
+func (h *APIKeyHandler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("/apiKeys/create", h.CreateAPIKey)
+	mux.HandleFunc("/apiKeys/list", h.ListAPIKeys)
+	mux.HandleFunc("/apiKeys/revoke", h.RevokeAPIKey)
+}
+
+func (h *APIKeyHandler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondMethodNotAllowed(w, r.Method)
+		return
+	}
+
+	var req domain.CreateAPIKeyReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, domain.ErrFailedToDecodeJSON)
+		return
+	}
+
+	// Валидация данных
+	if err := validateCreateAPIKeyReq(&req); err != nil {
+		respondError(w, err)
+		return
+	}
+
+	key, err := h.apiKeyService.CreateAPIKey(r.Context(), &req)
+	if err != nil {
+		logger.Logger.Errorw("failed to create api key", "name", req.Name, "error", err)
+		respondError(w, err)
+		return
+	}
+
+	logger.Logger.Infow("api key created", "api_key_id", key.ID.String())
+	writeJSON(w, statusCreated, domain.APIKeyResponse{APIKey: key})
+}
+
+func (h *APIKeyHandler) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondMethodNotAllowed(w, r.Method)
+		return
+	}
+
+	keys, err := h.apiKeyService.ListAPIKeys(r.Context())
+	if err != nil {
+		logger.Logger.Errorw("failed to list api keys", "error", err)
+		respondError(w, err)
+		return
+	}
+
+	writeJSON(w, statusOK, domain.ListAPIKeysResponse{APIKeys: keys})
+}
+
+func (h *APIKeyHandler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondMethodNotAllowed(w, r.Method)
+		return
+	}
+
+	var req domain.RevokeAPIKeyReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, domain.ErrFailedToDecodeJSON)
+		return
+	}
+
+	// Валидация данных
+	if err := validateRevokeAPIKeyReq(&req); err != nil {
+		respondError(w, err)
+		return
+	}
+
+	key, err := h.apiKeyService.RevokeAPIKey(r.Context(), &req)
+	if err != nil {
+		logger.Logger.Errorw("failed to revoke api key", "api_key_id", req.ID.String(), "error", err)
+		respondError(w, err)
+		return
+	}
+
+	logger.Logger.Infow("api key revoked", "api_key_id", key.ID.String())
+	writeJSON(w, statusOK, domain.APIKeyResponse{APIKey: key})
+}
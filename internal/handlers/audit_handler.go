@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"net/http"
+
+	"AVITOSAMPISHU/internal/service"
+	"AVITOSAMPISHU/pkg/logger"
+)
+
+type AuditHandler struct {
+	auditService service.AuditService
+}
+
+func NewAuditHandler(auditService service.AuditService) *AuditHandler {
+	return &AuditHandler{auditService: auditService}
+}
+
+func (h *AuditHandler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("/audit/list", h.ListAuditEvents)
+}
+
+func (h *AuditHandler) ListAuditEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondMethodNotAllowed(w, r.Method)
+		return
+	}
+
+	// Разбор и валидация фильтров
+	req, err := parseListAuditEventsQuery(r.URL.Query())
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	res, err := h.auditService.ListAuditEvents(r.Context(), req)
+	if err != nil {
+		logger.Logger.Errorw("failed to list audit events", "error", err)
+		respondError(w, err)
+		return
+	}
+
+	logger.Logger.Infow("audit events listed", "events_count", len(res.Events), "has_next", res.NextCursor != "")
+	writeJSON(w, statusOK, res)
+}
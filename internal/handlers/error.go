@@ -11,11 +11,17 @@ import (
 
 const (
 	statusBadRequest          = 400
+	statusUnauthorized        = 401
+	statusForbidden           = 403
 	statusNotFound            = 404
+	statusNotAcceptable       = 406
 	statusConflict            = 409
 	statusInternalServerError = 500
+	statusServiceUnavailable  = 503
 	statusMethodNotAllowed    = 405
 	statusCreated             = 201
+	statusAccepted            = 202
+	statusNoContent           = 204
 	statusOK                  = 200
 )
 
@@ -61,6 +67,8 @@ func writeJSON(w http.ResponseWriter, status int, payload any) {
 // resolveError маппит доменную ошибку на HTTP статус и доменный код ошибки
 func resolveError(err error) errorMapping {
 	switch {
+	case errors.Is(err, domain.ErrUnauthorized):
+		return errorMapping{statusUnauthorized, domain.ErrorCodeUnauthorized, err.Error()}
 	case errors.Is(err, domain.ErrInvalidRequest):
 		return errorMapping{statusBadRequest, domain.ErrorCodeInvalidRequest, err.Error()}
 	case errors.Is(err, domain.ErrTeamExists):
@@ -73,10 +81,20 @@ func resolveError(err error) errorMapping {
 		return errorMapping{statusConflict, domain.ErrorCodeNotAssigned, domain.ErrNotAssigned.Error()}
 	case errors.Is(err, domain.ErrNoCandidate):
 		return errorMapping{statusConflict, domain.ErrorCodeNoCandidate, domain.ErrNoCandidate.Error()}
+	case errors.Is(err, domain.ErrInvalidTransition):
+		return errorMapping{statusConflict, domain.ErrorCodeInvalidTransition, err.Error()}
+	case errors.Is(err, domain.ErrPRNotOpen):
+		return errorMapping{statusConflict, domain.ErrorCodePRNotOpen, domain.ErrPRNotOpen.Error()}
+	case errors.Is(err, domain.ErrNotApproved):
+		return errorMapping{statusConflict, domain.ErrorCodeNotApproved, err.Error()}
+	case errors.Is(err, domain.ErrForbidden):
+		return errorMapping{statusForbidden, domain.ErrorCodeForbidden, err.Error()}
 	case errors.Is(err, domain.ErrNotFound):
 		return errorMapping{statusNotFound, domain.ErrorCodeNotFound, domain.ErrNotFound.Error()}
 	case errors.Is(err, domain.ErrFailedToDecodeJSON):
 		return errorMapping{statusBadRequest, domain.ErrorCodeFailedToDecodeJSON, domain.ErrFailedToDecodeJSON.Error()}
+	case errors.Is(err, domain.ErrNotAcceptable):
+		return errorMapping{statusNotAcceptable, domain.ErrorCodeNotAcceptable, err.Error()}
 	case errors.Is(err, domain.ErrQueryParameterRequired):
 		return errorMapping{statusBadRequest, domain.ErrorCodeQueryParameterRequired, domain.ErrQueryParameterRequired.Error()}
 	default:
@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/service"
+	"AVITOSAMPISHU/pkg/logger"
+)
+
+// reviewExportColumns колонки CSV, совпадают с полями NDJSON
+var reviewExportColumns = []string{
+	"pull_request_id", "author_id", "reviewer_id", "team_name", "assigned_at", "removed_at", "reason", "merged_at",
+}
+
+type ExportHandler struct {
+	statsService service.StatsService
+}
+
+func NewExportHandler(statsService service.StatsService) *ExportHandler {
+	return &ExportHandler{statsService: statsService}
+}
+
+func (h *ExportHandler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("/export/reviews", h.ExportReviews)
+}
+
+// ExportReviews выгружает историю назначений ревьюверов в CSV или NDJSON, формат выбирается по Accept.
+// Строки отправляются по мере чтения из БД. Ошибка до первой строки возвращается обычным JSON-ответом,
+// после — только пишется в лог: статус уже отправлен, и клиент увидит оборванный ответ
+func (h *ExportHandler) ExportReviews(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondMethodNotAllowed(w, r.Method)
+		return
+	}
+
+	format, err := negotiateExportFormat(r.Header.Get("Accept"))
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	req, err := parseExportReviewsQuery(r.URL.Query())
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	export := newReviewExportWriter(w, format)
+	err = export.extendDeadline()
+	if err == nil {
+		err = h.statsService.ExportReviews(r.Context(), req, export.write)
+	}
+	if err == nil {
+		err = export.close()
+	}
+	if err != nil {
+		if !export.started {
+			logger.Logger.Errorw("failed to export reviews", "team_name", req.TeamName, "error", err)
+			respondError(w, err)
+			return
+		}
+		logger.Logger.Errorw("reviews export interrupted", "team_name", req.TeamName, "rows", export.rows, "error", err)
+		return
+	}
+
+	logger.Logger.Infow("reviews exported", "team_name", req.TeamName, "format", format, "rows", export.rows)
+}
+
+// negotiateExportFormat выбирает формат по заголовку Accept: наибольший q, при равном q — точный тип
+// предпочтительнее шаблона. Без заголовка — CSV
+func negotiateExportFormat(accept string) (string, error) {
+	if strings.TrimSpace(accept) == "" {
+		return domain.ExportFormatCSV, nil
+	}
+
+	best, bestQ, bestExact := "", 0.0, false
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if raw, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(raw, 64); err != nil {
+				continue
+			}
+		}
+
+		var format string
+		exact := false
+		switch mediaType {
+		case domain.ExportFormatCSV, domain.ExportFormatNDJSON:
+			format, exact = mediaType, true
+		case "text/*", "*/*":
+			format = domain.ExportFormatCSV
+		case "application/*":
+			format = domain.ExportFormatNDJSON
+		}
+		if format == "" || q <= 0 {
+			continue
+		}
+		if q > bestQ || (q == bestQ && exact && !bestExact) {
+			best, bestQ, bestExact = format, q, exact
+		}
+	}
+
+	if best == "" {
+		return "", fmt.Errorf("%w: use %s or %s", domain.ErrNotAcceptable, domain.ExportFormatCSV, domain.ExportFormatNDJSON)
+	}
+	return best, nil
+}
+
+// reviewExportWriter пишет строки выгрузки в ответ. Заголовки отправляются с первой строкой,
+// накопленное отдаётся клиенту каждые ExportFlushRows строк
+type reviewExportWriter struct {
+	w       http.ResponseWriter
+	rc      *http.ResponseController
+	format  string
+	csv     *csv.Writer
+	json    *json.Encoder
+	record  []string
+	started bool
+	rows    int
+}
+
+func newReviewExportWriter(w http.ResponseWriter, format string) *reviewExportWriter {
+	export := &reviewExportWriter{
+		w:      w,
+		rc:     http.NewResponseController(w),
+		format: format,
+	}
+	if format == domain.ExportFormatCSV {
+		export.csv = csv.NewWriter(w)
+		export.record = make([]string, len(reviewExportColumns))
+	} else {
+		export.json = json.NewEncoder(w)
+	}
+	return export
+}
+
+func (e *reviewExportWriter) start() error {
+	e.started = true
+	extension := "csv"
+	contentType := domain.ExportFormatCSV + "; charset=utf-8"
+	if e.format == domain.ExportFormatNDJSON {
+		extension = "ndjson"
+		contentType = domain.ExportFormatNDJSON
+	}
+	e.w.Header().Set("Content-Type", contentType)
+	e.w.Header().Set("Content-Disposition", `attachment; filename="reviews.`+extension+`"`)
+	e.w.WriteHeader(statusOK)
+
+	if e.csv != nil {
+		return e.csv.Write(reviewExportColumns)
+	}
+	return nil
+}
+
+func (e *reviewExportWriter) write(row *domain.ReviewExportRow) error {
+	if !e.started {
+		if err := e.start(); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	if e.csv != nil {
+		e.record[0] = csvCell(row.PullRequestID)
+		e.record[1] = csvCell(row.AuthorID)
+		e.record[2] = csvCell(row.ReviewerID)
+		e.record[3] = csvCell(row.TeamName)
+		e.record[4] = csvTime(row.AssignedAt)
+		e.record[5] = csvTime(row.RemovedAt)
+		e.record[6] = string(row.Reason)
+		e.record[7] = csvTime(row.MergedAt)
+		err = e.csv.Write(e.record)
+	} else {
+		err = e.json.Encode(row)
+	}
+	if err != nil {
+		return err
+	}
+
+	e.rows++
+	if e.rows%domain.ExportFlushRows == 0 {
+		return e.flush()
+	}
+	return nil
+}
+
+// close дописывает остаток. Пустая выгрузка — это ответ 200 с одной строкой заголовков CSV или пустым NDJSON
+func (e *reviewExportWriter) close() error {
+	if !e.started {
+		if err := e.start(); err != nil {
+			return err
+		}
+	}
+	return e.flush()
+}
+
+// flush отправляет накопленное клиенту и продлевает дедлайн записи: пока клиент читает, выгрузка
+// не ограничена server.write_timeout, а зависший клиент отпускает соединение с БД через ExportWriteTimeout
+func (e *reviewExportWriter) flush() error {
+	if e.csv != nil {
+		e.csv.Flush()
+		if err := e.csv.Error(); err != nil {
+			return err
+		}
+	}
+	if err := e.extendDeadline(); err != nil {
+		return err
+	}
+	if err := e.rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+// extendDeadline сдвигает дедлайн записи ответа на ExportWriteTimeout от текущего момента
+func (e *reviewExportWriter) extendDeadline() error {
+	if err := e.rc.SetWriteDeadline(time.Now().Add(domain.ExportWriteTimeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+// csvCell экранирует значения, которые табличные редакторы выполнили бы как формулу
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func csvTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/repository/mocks"
+	stats_service "AVITOSAMPISHU/internal/service/stats_service"
+	"AVITOSAMPISHU/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateExportFormat(t *testing.T) {
+	tests := []struct {
+		accept  string
+		want    string
+		wantErr bool
+	}{
+		{"", domain.ExportFormatCSV, false},
+		{"*/*", domain.ExportFormatCSV, false},
+		{"text/csv", domain.ExportFormatCSV, false},
+		{"application/x-ndjson", domain.ExportFormatNDJSON, false},
+		{"application/*", domain.ExportFormatNDJSON, false},
+		{"*/*, application/x-ndjson", domain.ExportFormatNDJSON, false},
+		{"text/csv;q=0.5, application/x-ndjson", domain.ExportFormatNDJSON, false},
+		{"text/csv;q=0, */*;q=0.1", domain.ExportFormatCSV, false},
+		{"application/json", "", true},
+		{"text/html, image/png", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			got, err := negotiateExportFormat(tt.accept)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, domain.ErrNotAcceptable)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestExportHandler_ExportReviews(t *testing.T) {
+	logger.InitLogger()
+
+	assigned := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	merged := assigned.Add(2 * time.Hour)
+	rows := []domain.ReviewExportRow{
+		{PullRequestID: "pr-1", AuthorID: "u1", ReviewerID: "u2", TeamName: "backend", AssignedAt: &assigned, RemovedAt: &merged, Reason: domain.UnassignReasonMerged, MergedAt: &merged},
+		{PullRequestID: "pr-2", AuthorID: "=cmd", ReviewerID: "u3", TeamName: "backend", AssignedAt: &assigned},
+	}
+
+	var gotFilter *domain.ExportReviewsReq
+	repo := &mocks.MockStatsRepository{
+		ExportReviewsFunc: func(_ context.Context, filter *domain.ExportReviewsReq, handle func(*domain.ReviewExportRow) error) error {
+			gotFilter = filter
+			for i := range rows {
+				if err := handle(&rows[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	handler := NewExportHandler(stats_service.NewStatsService(repo))
+
+	t.Run("csv by default", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ExportReviews(rec, httptest.NewRequest(http.MethodGet, "/export/reviews?team_name=backend&from=2025-03-01T00:00:00Z", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="reviews.csv"`, rec.Header().Get("Content-Disposition"))
+		assert.Equal(t, "backend", gotFilter.TeamName)
+		require.NotNil(t, gotFilter.From)
+		assert.Nil(t, gotFilter.To)
+
+		lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+		require.Len(t, lines, 3)
+		assert.Equal(t, "pull_request_id,author_id,reviewer_id,team_name,assigned_at,removed_at,reason,merged_at", lines[0])
+		assert.Equal(t, "pr-1,u1,u2,backend,2025-03-01T10:00:00Z,2025-03-01T12:00:00Z,merged,2025-03-01T12:00:00Z", lines[1])
+		assert.Equal(t, "pr-2,'=cmd,u3,backend,2025-03-01T10:00:00Z,,,", lines[2])
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/export/reviews", nil)
+		req.Header.Set("Accept", domain.ExportFormatNDJSON)
+		rec := httptest.NewRecorder()
+		handler.ExportReviews(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, domain.ExportFormatNDJSON, rec.Header().Get("Content-Type"))
+
+		lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+		require.Len(t, lines, 2)
+		var row domain.ReviewExportRow
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &row))
+		assert.Equal(t, "pr-1", row.PullRequestID)
+		assert.Equal(t, domain.UnassignReasonMerged, row.Reason)
+	})
+
+	t.Run("unsupported accept", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/export/reviews", nil)
+		req.Header.Set("Accept", "application/json")
+		rec := httptest.NewRecorder()
+		handler.ExportReviews(rec, req)
+
+		assert.Equal(t, http.StatusNotAcceptable, rec.Code)
+	})
+
+	t.Run("invalid window", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ExportReviews(rec, httptest.NewRequest(http.MethodGet, "/export/reviews?from=2025-03-02T00:00:00Z&to=2025-03-01T00:00:00Z", nil))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("error before first row", func(t *testing.T) {
+		failing := NewExportHandler(stats_service.NewStatsService(&mocks.MockStatsRepository{
+			ExportReviewsFunc: func(context.Context, *domain.ExportReviewsReq, func(*domain.ReviewExportRow) error) error {
+				return errors.New("connection refused")
+			},
+		}))
+		rec := httptest.NewRecorder()
+		failing.ExportReviews(rec, httptest.NewRequest(http.MethodGet, "/export/reviews", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Contains(t, rec.Header().Get("Content-Type"), "application/json")
+	})
+}
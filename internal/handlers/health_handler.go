@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+)
+
+// ReadinessCheck одна проверка готовности принимать трафик
+type ReadinessCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+type HealthHandler struct {
+	checks []ReadinessCheck
+}
+
+func NewHealthHandler(checks ...ReadinessCheck) *HealthHandler {
+	return &HealthHandler{checks: checks}
+}
+
+func (h *HealthHandler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("/healthz", h.Healthz)
+	mux.HandleFunc("/readyz", h.Readyz)
+}
+
+// Healthz отвечает, пока процесс жив и обслуживает HTTP; зависимости не проверяются
+func (h *HealthHandler) Healthz(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondMethodNotAllowed(w, r.Method)
+		return
+	}
+
+	writeJSON(w, statusOK, domain.HealthResponse{Status: domain.HealthStatusOK})
+}
+
+// Readyz выполняет все проверки готовности, каждую со своим таймаутом. Хотя бы одна неудачная — 503
+func (h *HealthHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondMethodNotAllowed(w, r.Method)
+		return
+	}
+
+	resp := domain.HealthResponse{
+		Status: domain.HealthStatusOK,
+		Checks: make(map[string]string, len(h.checks)),
+	}
+	for _, check := range h.checks {
+		ctx, cancel := context.WithTimeout(r.Context(), domain.ReadinessCheckTimeout)
+		err := check.Check(ctx)
+		cancel()
+
+		if err != nil {
+			logger.Logger.Warnw("readiness check failed", "check", check.Name, "error", err)
+			resp.Status = domain.HealthStatusUnavailable
+			resp.Checks[check.Name] = err.Error()
+			continue
+		}
+		resp.Checks[check.Name] = domain.HealthStatusOK
+	}
+
+	status := statusOK
+	if resp.Status != domain.HealthStatusOK {
+		status = statusServiceUnavailable
+	}
+	writeJSON(w, status, resp)
+}
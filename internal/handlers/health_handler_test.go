@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthHandler_Readyz(t *testing.T) {
+	logger.InitLogger()
+
+	ok := ReadinessCheck{Name: "database", Check: func(context.Context) error { return nil }}
+	failing := ReadinessCheck{Name: "shutdown", Check: func(context.Context) error { return errors.New("server is shutting down") }}
+	deadline := ReadinessCheck{Name: "migrations", Check: func(ctx context.Context) error {
+		if _, ok := ctx.Deadline(); !ok {
+			return errors.New("check has no deadline")
+		}
+		return nil
+	}}
+
+	tests := []struct {
+		name       string
+		checks     []ReadinessCheck
+		wantStatus int
+		wantChecks map[string]string
+	}{
+		{"all checks pass", []ReadinessCheck{ok, deadline}, http.StatusOK, map[string]string{"database": "ok", "migrations": "ok"}},
+		{"one check fails", []ReadinessCheck{ok, failing}, http.StatusServiceUnavailable, map[string]string{"database": "ok", "shutdown": "server is shutting down"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			NewHealthHandler(tt.checks...).Readyz(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			var resp domain.HealthResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantChecks, resp.Checks)
+		})
+	}
+}
+
+func TestHealthHandler_Healthz(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewHealthHandler().Healthz(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, rec.Body.String())
+}
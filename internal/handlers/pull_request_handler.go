@@ -19,8 +19,15 @@ func NewPullRequestHandler(prService service.PullRequestService) *PullRequestHan
 
 func (h *PullRequestHandler) Register(mux *http.ServeMux) {
 	mux.HandleFunc("/pullRequest/create", h.CreatePullRequest)
+	mux.HandleFunc("/pullRequest/get", h.GetPullRequest)
+	mux.HandleFunc("/pullRequest/history", h.GetPullRequestHistory)
+	mux.HandleFunc("/pullRequest/list", h.ListPullRequests)
 	mux.HandleFunc("/pullRequest/merge", h.MergePullRequest)
 	mux.HandleFunc("/pullRequest/reassign", h.ReassignReviewer)
+	mux.HandleFunc("/pullRequest/review", h.SubmitReview)
+	mux.HandleFunc("/pullRequest/close", h.ClosePullRequest)
+	mux.HandleFunc("/pullRequest/reopen", h.ReopenPullRequest)
+	mux.HandleFunc("/pullRequest/markReady", h.MarkReady)
 }
 
 func (h *PullRequestHandler) CreatePullRequest(w http.ResponseWriter, r *http.Request) {
@@ -112,3 +119,187 @@ func (h *PullRequestHandler) ReassignReviewer(w http.ResponseWriter, r *http.Req
 		ReplacedBy: newReviewerID,
 	})
 }
+
+func (h *PullRequestHandler) SubmitReview(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondMethodNotAllowed(w, r.Method)
+		return
+	}
+
+	var req domain.SubmitReviewReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, domain.ErrFailedToDecodeJSON)
+		return
+	}
+
+	// Валидация данных
+	if err := validateSubmitReviewReq(&req); err != nil {
+		respondError(w, err)
+		return
+	}
+
+	pr, err := h.prService.SubmitReview(r.Context(), &req)
+	if err != nil {
+		logger.Logger.Errorw("failed to submit review", "pr_id", req.PullRequestID, "reviewer_id", req.ReviewerID, "error", err)
+		respondError(w, err)
+		return
+	}
+
+	logger.Logger.Infow("review submitted", "pr_id", pr.PullRequestID, "reviewer_id", req.ReviewerID, "verdict", req.Verdict)
+	writeJSON(w, statusOK, domain.PullRequestResponse{PR: pr})
+}
+
+func (h *PullRequestHandler) ClosePullRequest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondMethodNotAllowed(w, r.Method)
+		return
+	}
+
+	var req domain.ClosePullRequestReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, domain.ErrFailedToDecodeJSON)
+		return
+	}
+
+	// Валидация данных
+	if err := validateClosePullRequestReq(&req); err != nil {
+		respondError(w, err)
+		return
+	}
+
+	pr, err := h.prService.ClosePullRequest(r.Context(), &req)
+	if err != nil {
+		logger.Logger.Errorw("failed to close pull request", "pr_id", req.PullRequestID, "error", err)
+		respondError(w, err)
+		return
+	}
+
+	logger.Logger.Infow("pull request closed", "pr_id", pr.PullRequestID, "status", pr.Status)
+	writeJSON(w, statusOK, domain.PullRequestResponse{PR: pr})
+}
+
+func (h *PullRequestHandler) ReopenPullRequest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondMethodNotAllowed(w, r.Method)
+		return
+	}
+
+	var req domain.ReopenPullRequestReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, domain.ErrFailedToDecodeJSON)
+		return
+	}
+
+	// Валидация данных
+	if err := validateReopenPullRequestReq(&req); err != nil {
+		respondError(w, err)
+		return
+	}
+
+	pr, err := h.prService.ReopenPullRequest(r.Context(), &req)
+	if err != nil {
+		logger.Logger.Errorw("failed to reopen pull request", "pr_id", req.PullRequestID, "error", err)
+		respondError(w, err)
+		return
+	}
+
+	logger.Logger.Infow("pull request reopened", "pr_id", pr.PullRequestID, "status", pr.Status)
+	writeJSON(w, statusOK, domain.PullRequestResponse{PR: pr})
+}
+
+func (h *PullRequestHandler) MarkReady(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondMethodNotAllowed(w, r.Method)
+		return
+	}
+
+	var req domain.MarkReadyReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, domain.ErrFailedToDecodeJSON)
+		return
+	}
+
+	// Валидация данных
+	if err := validateMarkReadyReq(&req); err != nil {
+		respondError(w, err)
+		return
+	}
+
+	pr, err := h.prService.MarkReady(r.Context(), &req)
+	if err != nil {
+		logger.Logger.Errorw("failed to mark pull request ready", "pr_id", req.PullRequestID, "error", err)
+		respondError(w, err)
+		return
+	}
+
+	logger.Logger.Infow("pull request marked ready", "pr_id", pr.PullRequestID, "status", pr.Status)
+	writeJSON(w, statusOK, domain.PullRequestResponse{PR: pr})
+}
+
+func (h *PullRequestHandler) GetPullRequest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondMethodNotAllowed(w, r.Method)
+		return
+	}
+
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		respondError(w, domain.ErrQueryParameterRequired)
+		return
+	}
+
+	pr, err := h.prService.GetPullRequest(r.Context(), prID)
+	if err != nil {
+		logger.Logger.Errorw("failed to get pull request", "pr_id", prID, "error", err)
+		respondError(w, err)
+		return
+	}
+
+	writeJSON(w, statusOK, domain.PullRequestResponse{PR: pr})
+}
+
+func (h *PullRequestHandler) GetPullRequestHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondMethodNotAllowed(w, r.Method)
+		return
+	}
+
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		respondError(w, domain.ErrQueryParameterRequired)
+		return
+	}
+
+	res, err := h.prService.GetPullRequestHistory(r.Context(), prID)
+	if err != nil {
+		logger.Logger.Errorw("failed to get pull request history", "pr_id", prID, "error", err)
+		respondError(w, err)
+		return
+	}
+
+	writeJSON(w, statusOK, res)
+}
+
+func (h *PullRequestHandler) ListPullRequests(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondMethodNotAllowed(w, r.Method)
+		return
+	}
+
+	// Разбор и валидация фильтров
+	req, err := parseListPullRequestsQuery(r.URL.Query())
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	res, err := h.prService.ListPullRequests(r.Context(), req)
+	if err != nil {
+		logger.Logger.Errorw("failed to list pull requests", "error", err)
+		respondError(w, err)
+		return
+	}
+
+	logger.Logger.Infow("pull requests listed", "prs_count", len(res.PullRequests), "has_next", res.NextCursor != "")
+	writeJSON(w, statusOK, res)
+}
@@ -11,8 +11,17 @@ func RegisterRoutes(
 	teamService service.TeamService,
 	userService service.UserService,
 	prService service.PullRequestService,
+	webhookService service.WebhookService,
+	auditService service.AuditService,
+	apiKeyService service.APIKeyService,
+	statsService service.StatsService,
 ) {
 	NewTeamHandler(teamService).Register(mux)
 	NewUserHandler(userService).Register(mux)
 	NewPullRequestHandler(prService).Register(mux)
+	NewWebhookHandler(webhookService).Register(mux)
+	NewAuditHandler(auditService).Register(mux)
+	NewAPIKeyHandler(apiKeyService).Register(mux)
+	NewStatsHandler(statsService).Register(mux)
+	NewExportHandler(statsService).Register(mux)
 }
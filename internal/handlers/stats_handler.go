@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"net/http"
+
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/service"
+	"AVITOSAMPISHU/pkg/logger"
+)
+
+type StatsHandler struct {
+	statsService service.StatsService
+}
+
+func NewStatsHandler(statsService service.StatsService) *StatsHandler {
+	return &StatsHandler{statsService: statsService}
+}
+
+func (h *StatsHandler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("/stats/team", h.GetTeamStats)
+	mux.HandleFunc("/stats/user", h.GetUserStats)
+}
+
+func (h *StatsHandler) GetTeamStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondMethodNotAllowed(w, r.Method)
+		return
+	}
+
+	req, err := parseGetTeamStatsQuery(r.URL.Query())
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	stats, err := h.statsService.GetTeamStats(r.Context(), req)
+	if err != nil {
+		logger.Logger.Errorw("failed to get team stats", "team_name", req.TeamName, "error", err)
+		respondError(w, err)
+		return
+	}
+
+	logger.Logger.Infow("team stats retrieved", "team_name", req.TeamName, "from", stats.From, "to", stats.To)
+	writeJSON(w, statusOK, domain.TeamStatsResponse{Stats: stats})
+}
+
+func (h *StatsHandler) GetUserStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondMethodNotAllowed(w, r.Method)
+		return
+	}
+
+	req, err := parseGetUserStatsQuery(r.URL.Query())
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	stats, err := h.statsService.GetUserStats(r.Context(), req)
+	if err != nil {
+		logger.Logger.Errorw("failed to get user stats", "user_id", req.UserID, "error", err)
+		respondError(w, err)
+		return
+	}
+
+	logger.Logger.Infow("user stats retrieved", "user_id", req.UserID, "from", stats.From, "to", stats.To)
+	writeJSON(w, statusOK, domain.UserStatsResponse{Stats: stats})
+}
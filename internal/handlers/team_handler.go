@@ -20,6 +20,8 @@ func NewTeamHandler(teamService service.TeamService) *TeamHandler {
 func (h *TeamHandler) Register(mux *http.ServeMux) {
 	mux.HandleFunc("/team/add", h.CreateTeam)
 	mux.HandleFunc("/team/get", h.GetTeam)
+	mux.HandleFunc("/team/settings/get", h.GetTeamSettings)
+	mux.HandleFunc("/team/settings/update", h.UpdateTeamSettings)
 }
 
 func (h *TeamHandler) CreateTeam(w http.ResponseWriter, r *http.Request) {
@@ -73,3 +75,54 @@ func (h *TeamHandler) GetTeam(w http.ResponseWriter, r *http.Request) {
 	logger.Logger.Infow("team retrieved successfully", "team_name", teamName, "members_count", len(team.Members))
 	writeJSON(w, statusOK, team)
 }
+
+func (h *TeamHandler) GetTeamSettings(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondMethodNotAllowed(w, r.Method)
+		return
+	}
+
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		respondError(w, domain.ErrQueryParameterRequired)
+		return
+	}
+
+	settings, err := h.teamService.GetTeamSettings(r.Context(), teamName)
+	if err != nil {
+		logger.Logger.Errorw("failed to get team settings", "team_name", teamName, "error", err)
+		respondError(w, err)
+		return
+	}
+
+	writeJSON(w, statusOK, domain.TeamSettingsResponse{TeamName: teamName, Settings: settings})
+}
+
+func (h *TeamHandler) UpdateTeamSettings(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondMethodNotAllowed(w, r.Method)
+		return
+	}
+
+	var req domain.UpdateTeamSettingsReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, domain.ErrFailedToDecodeJSON)
+		return
+	}
+
+	// Валидация данных
+	if err := validateUpdateTeamSettingsReq(&req); err != nil {
+		respondError(w, err)
+		return
+	}
+
+	settings, err := h.teamService.UpdateTeamSettings(r.Context(), &req)
+	if err != nil {
+		logger.Logger.Errorw("failed to update team settings", "team_name", req.TeamName, "error", err)
+		respondError(w, err)
+		return
+	}
+
+	logger.Logger.Infow("team settings updated", "team_name", req.TeamName)
+	writeJSON(w, statusOK, domain.TeamSettingsResponse{TeamName: req.TeamName, Settings: settings})
+}
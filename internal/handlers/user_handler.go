@@ -57,24 +57,22 @@ func (h *UserHandler) GetUserReviews(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userID := r.URL.Query().Get("user_id")
-	if userID == "" {
-		respondError(w, domain.ErrQueryParameterRequired)
+	// Разбор и валидация параметров страницы
+	req, err := parseGetUserReviewsQuery(r.URL.Query())
+	if err != nil {
+		respondError(w, err)
 		return
 	}
 
-	prs, err := h.userService.GetUserReviews(r.Context(), userID)
+	res, err := h.userService.GetUserReviews(r.Context(), req)
 	if err != nil {
-		logger.Logger.Errorw("failed to get user reviews", "user_id", userID, "error", err)
+		logger.Logger.Errorw("failed to get user reviews", "user_id", req.UserID, "error", err)
 		respondError(w, err)
 		return
 	}
 
-	logger.Logger.Infow("user reviews retrieved", "user_id", userID, "prs_count", len(prs))
-	writeJSON(w, statusOK, domain.GetUserReviewsResponse{
-		UserID:       userID,
-		PullRequests: prs,
-	})
+	logger.Logger.Infow("user reviews retrieved", "user_id", req.UserID, "prs_count", len(res.PullRequests))
+	writeJSON(w, statusOK, res)
 }
 
 func (h *UserHandler) DeactivateTeamMembers(w http.ResponseWriter, r *http.Request) {
@@ -2,14 +2,25 @@ package handlers
 
 import (
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"AVITOSAMPISHU/internal/domain"
+
+	"github.com/google/uuid"
 )
 
 func validateTeam(team *domain.Team) error {
 	if team.TeamName == "" {
 		return fmt.Errorf("%w: team_name is required", domain.ErrInvalidRequest)
 	}
+	if team.Settings != nil {
+		if err := validateTeamSettings(team.Settings); err != nil {
+			return err
+		}
+	}
 	if len(team.Members) == 0 {
 		return fmt.Errorf("%w: team must have at least one member", domain.ErrInvalidRequest)
 	}
@@ -24,6 +35,43 @@ func validateTeam(team *domain.Team) error {
 	return nil
 }
 
+// validateTeamSettings проверяет настройки команды при создании.
+// Нулевые значения допустимы: вместо них будут применены значения по умолчанию.
+func validateTeamSettings(settings *domain.TeamSettings) error {
+	if settings.SelectionStrategy != "" && !settings.SelectionStrategy.Validate() {
+		return fmt.Errorf("%w: unknown selection_strategy %q", domain.ErrInvalidRequest, settings.SelectionStrategy)
+	}
+	if settings.RequiredReviewers < 0 || settings.RequiredReviewers > domain.MaxRequiredReviewers {
+		return fmt.Errorf("%w: required_reviewers must be between 1 and %d", domain.ErrInvalidRequest, domain.MaxRequiredReviewers)
+	}
+	if settings.MinReviewers < 0 {
+		return fmt.Errorf("%w: min_reviewers cannot be negative", domain.ErrInvalidRequest)
+	}
+	if settings.RequiredApprovals < 0 {
+		return fmt.Errorf("%w: required_approvals cannot be negative", domain.ErrInvalidRequest)
+	}
+	return nil
+}
+
+func validateUpdateTeamSettingsReq(req *domain.UpdateTeamSettingsReq) error {
+	if req.TeamName == "" {
+		return fmt.Errorf("%w: team_name is required", domain.ErrInvalidRequest)
+	}
+	if req.RequiredReviewers != nil && (*req.RequiredReviewers < 1 || *req.RequiredReviewers > domain.MaxRequiredReviewers) {
+		return fmt.Errorf("%w: required_reviewers must be between 1 and %d", domain.ErrInvalidRequest, domain.MaxRequiredReviewers)
+	}
+	if req.MinReviewers != nil && *req.MinReviewers < 0 {
+		return fmt.Errorf("%w: min_reviewers cannot be negative", domain.ErrInvalidRequest)
+	}
+	if req.SelectionStrategy != nil && !req.SelectionStrategy.Validate() {
+		return fmt.Errorf("%w: unknown selection_strategy %q", domain.ErrInvalidRequest, *req.SelectionStrategy)
+	}
+	if req.RequiredApprovals != nil && *req.RequiredApprovals < 0 {
+		return fmt.Errorf("%w: required_approvals cannot be negative", domain.ErrInvalidRequest)
+	}
+	return nil
+}
+
 func validateSetIsActiveRequest(req *domain.SetIsActiveRequest) error {
 	if req.UserID == "" {
 		return fmt.Errorf("%w: user_id is required", domain.ErrInvalidRequest)
@@ -65,6 +113,27 @@ func validateMergePullRequestReq(req *domain.MergePullRequestReq) error {
 	return nil
 }
 
+func validateClosePullRequestReq(req *domain.ClosePullRequestReq) error {
+	if req.PullRequestID == "" {
+		return fmt.Errorf("%w: pull_request_id is required", domain.ErrInvalidRequest)
+	}
+	return nil
+}
+
+func validateReopenPullRequestReq(req *domain.ReopenPullRequestReq) error {
+	if req.PullRequestID == "" {
+		return fmt.Errorf("%w: pull_request_id is required", domain.ErrInvalidRequest)
+	}
+	return nil
+}
+
+func validateMarkReadyReq(req *domain.MarkReadyReq) error {
+	if req.PullRequestID == "" {
+		return fmt.Errorf("%w: pull_request_id is required", domain.ErrInvalidRequest)
+	}
+	return nil
+}
+
 func validateReassignReviewerReq(req *domain.ReassignReviewerReq) error {
 	if req.PullRequestID == "" {
 		return fmt.Errorf("%w: pull_request_id is required", domain.ErrInvalidRequest)
@@ -74,3 +143,314 @@ func validateReassignReviewerReq(req *domain.ReassignReviewerReq) error {
 	}
 	return nil
 }
+
+func validateSubmitReviewReq(req *domain.SubmitReviewReq) error {
+	if req.PullRequestID == "" {
+		return fmt.Errorf("%w: pull_request_id is required", domain.ErrInvalidRequest)
+	}
+	if req.ReviewerID == "" {
+		return fmt.Errorf("%w: reviewer_id is required", domain.ErrInvalidRequest)
+	}
+	// PENDING — начальное состояние, отправить можно только итоговое решение
+	if req.Verdict != domain.ReviewVerdictApproved && req.Verdict != domain.ReviewVerdictChangesRequested {
+		return fmt.Errorf("%w: verdict must be %s or %s", domain.ErrInvalidRequest, domain.ReviewVerdictApproved, domain.ReviewVerdictChangesRequested)
+	}
+	return nil
+}
+
+func validateCreateWebhookReq(req *domain.CreateWebhookReq) error {
+	target, err := url.Parse(req.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http(s) URL", domain.ErrInvalidRequest)
+	}
+	if len(req.Events) == 0 {
+		return fmt.Errorf("%w: events must not be empty", domain.ErrInvalidRequest)
+	}
+	for _, event := range req.Events {
+		if !event.Validate() {
+			return fmt.Errorf("%w: unknown event %q", domain.ErrInvalidRequest, event)
+		}
+	}
+	return nil
+}
+
+func validateDeleteWebhookReq(req *domain.DeleteWebhookReq) error {
+	if req.ID == uuid.Nil {
+		return fmt.Errorf("%w: id is required", domain.ErrInvalidRequest)
+	}
+	return nil
+}
+
+func validateReplayWebhookDeliveryReq(req *domain.ReplayWebhookDeliveryReq) error {
+	if req.DeliveryID == uuid.Nil {
+		return fmt.Errorf("%w: delivery_id is required", domain.ErrInvalidRequest)
+	}
+	return nil
+}
+
+func validateCreateAPIKeyReq(req *domain.CreateAPIKeyReq) error {
+	if strings.TrimSpace(req.Name) == "" {
+		return fmt.Errorf("%w: name is required", domain.ErrInvalidRequest)
+	}
+	if len(req.Scopes) == 0 {
+		return fmt.Errorf("%w: scopes must not be empty", domain.ErrInvalidRequest)
+	}
+	for _, scope := range req.Scopes {
+		if !scope.Validate() {
+			return fmt.Errorf("%w: unknown scope %q", domain.ErrInvalidRequest, scope)
+		}
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return fmt.Errorf("%w: expires_at must be in the future", domain.ErrInvalidRequest)
+	}
+	return nil
+}
+
+func validateRevokeAPIKeyReq(req *domain.RevokeAPIKeyReq) error {
+	if req.ID == uuid.Nil {
+		return fmt.Errorf("%w: id is required", domain.ErrInvalidRequest)
+	}
+	return nil
+}
+
+// parseListWebhookDeliveriesQuery разбирает параметры журнала доставок /webhooks/deliveries
+func parseListWebhookDeliveriesQuery(query url.Values) (*domain.ListWebhookDeliveriesReq, error) {
+	raw := query.Get("webhook_id")
+	if raw == "" {
+		return nil, domain.ErrQueryParameterRequired
+	}
+
+	subscriptionID, err := uuid.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: webhook_id must be a UUID", domain.ErrInvalidRequest)
+	}
+
+	limit, err := parseLimitParam(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ListWebhookDeliveriesReq{SubscriptionID: subscriptionID, Limit: limit}, nil
+}
+
+// parseListPullRequestsQuery разбирает фильтры /pullRequest/list из query-параметров
+func parseListPullRequestsQuery(query url.Values) (*domain.ListPullRequestsReq, error) {
+	req := &domain.ListPullRequestsReq{
+		AuthorID:   query.Get("author_id"),
+		TeamName:   query.Get("team_name"),
+		ReviewerID: query.Get("reviewer_id"),
+		Order:      domain.SortOrderDesc,
+	}
+
+	var err error
+	if req.Statuses, err = parseStatusesParam(query, "status"); err != nil {
+		return nil, err
+	}
+
+	if raw := query.Get("need_more_reviewers"); raw != "" {
+		needMore, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%w: need_more_reviewers must be true or false", domain.ErrInvalidRequest)
+		}
+		req.NeedMoreReviewers = &needMore
+	}
+
+	if req.CreatedFrom, err = parseTimeParam(query, "created_from"); err != nil {
+		return nil, err
+	}
+	if req.CreatedTo, err = parseTimeParam(query, "created_to"); err != nil {
+		return nil, err
+	}
+	if req.MergedFrom, err = parseTimeParam(query, "merged_from"); err != nil {
+		return nil, err
+	}
+	if req.MergedTo, err = parseTimeParam(query, "merged_to"); err != nil {
+		return nil, err
+	}
+
+	if raw := query.Get("order"); raw != "" {
+		req.Order = domain.SortOrder(strings.ToLower(raw))
+		if !req.Order.Validate() {
+			return nil, fmt.Errorf("%w: order must be asc or desc", domain.ErrInvalidRequest)
+		}
+	}
+
+	if req.Limit, err = parseLimitParam(query); err != nil {
+		return nil, err
+	}
+	if req.Cursor, err = parseCursorParam(query); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func parseGetUserReviewsQuery(query url.Values) (*domain.GetUserReviewsReq, error) {
+	req := &domain.GetUserReviewsReq{UserID: query.Get("user_id")}
+	if req.UserID == "" {
+		return nil, domain.ErrQueryParameterRequired
+	}
+
+	var err error
+	if req.Statuses, err = parseStatusesParam(query, "status"); err != nil {
+		return nil, err
+	}
+	if req.Limit, err = parseLimitParam(query); err != nil {
+		return nil, err
+	}
+	if req.Cursor, err = parseCursorParam(query); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// parseListAuditEventsQuery разбирает фильтры /audit/list из query-параметров
+func parseListAuditEventsQuery(query url.Values) (*domain.ListAuditEventsReq, error) {
+	req := &domain.ListAuditEventsReq{
+		EntityType: domain.AuditEntityType(query.Get("entity_type")),
+		EntityID:   query.Get("entity_id"),
+		Actor:      query.Get("actor"),
+	}
+
+	if req.EntityType != "" && !req.EntityType.Validate() {
+		return nil, fmt.Errorf("%w: unknown entity_type %q", domain.ErrInvalidRequest, req.EntityType)
+	}
+	// Идентификаторы разных сущностей могут совпадать, поэтому entity_id без типа не имеет смысла
+	if req.EntityID != "" && req.EntityType == "" {
+		return nil, fmt.Errorf("%w: entity_id requires entity_type", domain.ErrInvalidRequest)
+	}
+
+	var err error
+	if req.From, err = parseTimeParam(query, "from"); err != nil {
+		return nil, err
+	}
+	if req.To, err = parseTimeParam(query, "to"); err != nil {
+		return nil, err
+	}
+	if req.From != nil && req.To != nil && !req.From.Before(*req.To) {
+		return nil, fmt.Errorf("%w: from must be before to", domain.ErrInvalidRequest)
+	}
+	if req.Limit, err = parseLimitParam(query); err != nil {
+		return nil, err
+	}
+	if req.Cursor, err = parseCursorParam(query); err != nil {
+		return nil, err
+	}
+	// Записи журнала идентифицируются числом
+	if req.Cursor != nil {
+		if _, err := strconv.ParseInt(req.Cursor.ID, 10, 64); err != nil {
+			return nil, fmt.Errorf("%w: malformed cursor", domain.ErrInvalidRequest)
+		}
+	}
+	return req, nil
+}
+
+// parseGetTeamStatsQuery разбирает параметры /stats/team
+func parseGetTeamStatsQuery(query url.Values) (*domain.GetTeamStatsReq, error) {
+	req := &domain.GetTeamStatsReq{TeamName: query.Get("team_name")}
+	if req.TeamName == "" {
+		return nil, domain.ErrQueryParameterRequired
+	}
+
+	var err error
+	if req.From, req.To, err = parseStatsWindow(query); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// parseGetUserStatsQuery разбирает параметры /stats/user
+func parseGetUserStatsQuery(query url.Values) (*domain.GetUserStatsReq, error) {
+	req := &domain.GetUserStatsReq{UserID: query.Get("user_id")}
+	if req.UserID == "" {
+		return nil, domain.ErrQueryParameterRequired
+	}
+
+	var err error
+	if req.From, req.To, err = parseStatsWindow(query); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// parseExportReviewsQuery разбирает фильтры /export/reviews: команда автора и период назначения
+func parseExportReviewsQuery(query url.Values) (*domain.ExportReviewsReq, error) {
+	req := &domain.ExportReviewsReq{TeamName: query.Get("team_name")}
+
+	var err error
+	if req.From, req.To, err = parseStatsWindow(query); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// parseStatsWindow разбирает необязательные границы периода from и to
+func parseStatsWindow(query url.Values) (*time.Time, *time.Time, error) {
+	from, err := parseTimeParam(query, "from")
+	if err != nil {
+		return nil, nil, err
+	}
+	to, err := parseTimeParam(query, "to")
+	if err != nil {
+		return nil, nil, err
+	}
+	if from != nil && to != nil && !from.Before(*to) {
+		return nil, nil, fmt.Errorf("%w: from must be before to", domain.ErrInvalidRequest)
+	}
+	return from, to, nil
+}
+
+// parseStatusesParam разбирает список статусов PR через запятую
+func parseStatusesParam(query url.Values, name string) ([]domain.PRStatus, error) {
+	raw := query.Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(raw, ",")
+	statuses := make([]domain.PRStatus, 0, len(parts))
+	for _, part := range parts {
+		status := domain.PRStatus(strings.ToUpper(strings.TrimSpace(part)))
+		if !status.Validate() {
+			return nil, fmt.Errorf("%w: unknown %s %q", domain.ErrInvalidRequest, name, part)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// parseTimeParam разбирает время в формате RFC3339
+func parseTimeParam(query url.Values, name string) (*time.Time, error) {
+	raw := query.Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s must be RFC3339 timestamp", domain.ErrInvalidRequest, name)
+	}
+	return &t, nil
+}
+
+// parseLimitParam разбирает размер страницы. 0 означает размер по умолчанию
+func parseLimitParam(query url.Values) (int, error) {
+	raw := query.Get("limit")
+	if raw == "" {
+		return 0, nil
+	}
+
+	limit, err := strconv.Atoi(raw)
+	if err != nil || limit < 1 || limit > domain.MaxPageLimit {
+		return 0, fmt.Errorf("%w: limit must be between 1 and %d", domain.ErrInvalidRequest, domain.MaxPageLimit)
+	}
+	return limit, nil
+}
+
+func parseCursorParam(query url.Values) (*domain.PageCursor, error) {
+	raw := query.Get("cursor")
+	if raw == "" {
+		return nil, nil
+	}
+	return domain.DecodePageCursor(raw)
+}
@@ -2,9 +2,13 @@ package handlers
 
 import (
 	"AVITOSAMPISHU/internal/domain"
+	"net/url"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestValidateTeam(t *testing.T) {
@@ -34,6 +38,39 @@ func TestValidateTeam(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid settings",
+			team: &domain.Team{
+				TeamName: "team1",
+				Settings: &domain.TeamSettings{SelectionStrategy: domain.SelectionStrategyLeastLoaded, RequiredReviewers: 3},
+				Members: []domain.TeamMember{
+					{UserID: "user1", Username: "User1"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown selection strategy",
+			team: &domain.Team{
+				TeamName: "team1",
+				Settings: &domain.TeamSettings{SelectionStrategy: "fastest"},
+				Members: []domain.TeamMember{
+					{UserID: "user1", Username: "User1"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "required reviewers above limit",
+			team: &domain.Team{
+				TeamName: "team1",
+				Settings: &domain.TeamSettings{RequiredReviewers: domain.MaxRequiredReviewers + 1},
+				Members: []domain.TeamMember{
+					{UserID: "user1", Username: "User1"},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "empty members list",
 			team: &domain.Team{
@@ -303,3 +340,429 @@ func TestValidateReassignReviewerReq(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateUpdateTeamSettingsReq(t *testing.T) {
+	required := 3
+	zero := 0
+	negative := -1
+	strategy := domain.SelectionStrategyRoundRobin
+	unknownStrategy := domain.SelectionStrategy("fastest")
+
+	tests := []struct {
+		name    string
+		req     *domain.UpdateTeamSettingsReq
+		wantErr bool
+	}{
+		{
+			name: "valid request",
+			req: &domain.UpdateTeamSettingsReq{
+				TeamName:          "team1",
+				RequiredReviewers: &required,
+				MinReviewers:      &zero,
+				SelectionStrategy: &strategy,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "only team_name",
+			req:     &domain.UpdateTeamSettingsReq{TeamName: "team1"},
+			wantErr: false,
+		},
+		{
+			name:    "empty team_name",
+			req:     &domain.UpdateTeamSettingsReq{RequiredReviewers: &required},
+			wantErr: true,
+		},
+		{
+			name:    "zero required_reviewers",
+			req:     &domain.UpdateTeamSettingsReq{TeamName: "team1", RequiredReviewers: &zero},
+			wantErr: true,
+		},
+		{
+			name:    "negative min_reviewers",
+			req:     &domain.UpdateTeamSettingsReq{TeamName: "team1", MinReviewers: &negative},
+			wantErr: true,
+		},
+		{
+			name:    "disable approvals gate",
+			req:     &domain.UpdateTeamSettingsReq{TeamName: "team1", RequiredApprovals: &zero},
+			wantErr: false,
+		},
+		{
+			name:    "negative required_approvals",
+			req:     &domain.UpdateTeamSettingsReq{TeamName: "team1", RequiredApprovals: &negative},
+			wantErr: true,
+		},
+		{
+			name:    "unknown selection_strategy",
+			req:     &domain.UpdateTeamSettingsReq{TeamName: "team1", SelectionStrategy: &unknownStrategy},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateUpdateTeamSettingsReq(tt.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.ErrorIs(t, err, domain.ErrInvalidRequest)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateSubmitReviewReq(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *domain.SubmitReviewReq
+		wantErr bool
+	}{
+		{
+			name: "approve",
+			req: &domain.SubmitReviewReq{
+				PullRequestID: "pr1",
+				ReviewerID:    "user1",
+				Verdict:       domain.ReviewVerdictApproved,
+			},
+			wantErr: false,
+		},
+		{
+			name: "request changes with comment",
+			req: &domain.SubmitReviewReq{
+				PullRequestID: "pr1",
+				ReviewerID:    "user1",
+				Verdict:       domain.ReviewVerdictChangesRequested,
+				Comment:       "please add tests",
+			},
+			wantErr: false,
+		},
+		{
+			name: "empty pull_request_id",
+			req: &domain.SubmitReviewReq{
+				ReviewerID: "user1",
+				Verdict:    domain.ReviewVerdictApproved,
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty reviewer_id",
+			req: &domain.SubmitReviewReq{
+				PullRequestID: "pr1",
+				Verdict:       domain.ReviewVerdictApproved,
+			},
+			wantErr: true,
+		},
+		{
+			name: "pending verdict",
+			req: &domain.SubmitReviewReq{
+				PullRequestID: "pr1",
+				ReviewerID:    "user1",
+				Verdict:       domain.ReviewVerdictPending,
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown verdict",
+			req: &domain.SubmitReviewReq{
+				PullRequestID: "pr1",
+				ReviewerID:    "user1",
+				Verdict:       "LGTM",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSubmitReviewReq(tt.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.ErrorIs(t, err, domain.ErrInvalidRequest)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidatePullRequestLifecycleReqs(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate func(prID string) error
+	}{
+		{
+			name: "close",
+			validate: func(prID string) error {
+				return validateClosePullRequestReq(&domain.ClosePullRequestReq{PullRequestID: prID})
+			},
+		},
+		{
+			name: "reopen",
+			validate: func(prID string) error {
+				return validateReopenPullRequestReq(&domain.ReopenPullRequestReq{PullRequestID: prID})
+			},
+		},
+		{
+			name: "mark ready",
+			validate: func(prID string) error {
+				return validateMarkReadyReq(&domain.MarkReadyReq{PullRequestID: prID})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NoError(t, tt.validate("pr1"))
+
+			err := tt.validate("")
+			assert.Error(t, err)
+			assert.ErrorIs(t, err, domain.ErrInvalidRequest)
+		})
+	}
+}
+
+func TestParseListPullRequestsQuery(t *testing.T) {
+	cursor := domain.PageCursor{CreatedAt: time.Date(2025, 10, 24, 10, 0, 0, 0, time.UTC), ID: "pr1"}.Encode()
+
+	t.Run("defaults", func(t *testing.T) {
+		req, err := parseListPullRequestsQuery(url.Values{})
+		require.NoError(t, err)
+		assert.Equal(t, domain.SortOrderDesc, req.Order)
+		assert.Zero(t, req.Limit)
+		assert.Nil(t, req.Cursor)
+		assert.Nil(t, req.NeedMoreReviewers)
+	})
+
+	t.Run("all filters", func(t *testing.T) {
+		req, err := parseListPullRequestsQuery(url.Values{
+			"status":              {"open,merged"},
+			"author_id":           {"u1"},
+			"team_name":           {"backend"},
+			"reviewer_id":         {"u2"},
+			"need_more_reviewers": {"true"},
+			"created_from":        {"2025-10-01T00:00:00Z"},
+			"merged_to":           {"2025-11-01T00:00:00+03:00"},
+			"order":               {"ASC"},
+			"limit":               {"20"},
+			"cursor":              {cursor},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []domain.PRStatus{domain.PRStatusOpen, domain.PRStatusMerged}, req.Statuses)
+		assert.Equal(t, "u1", req.AuthorID)
+		assert.Equal(t, "backend", req.TeamName)
+		assert.Equal(t, "u2", req.ReviewerID)
+		require.NotNil(t, req.NeedMoreReviewers)
+		assert.True(t, *req.NeedMoreReviewers)
+		require.NotNil(t, req.CreatedFrom)
+		require.NotNil(t, req.MergedTo)
+		assert.Equal(t, domain.SortOrderAsc, req.Order)
+		assert.Equal(t, 20, req.Limit)
+		require.NotNil(t, req.Cursor)
+		assert.Equal(t, "pr1", req.Cursor.ID)
+	})
+
+	invalid := []struct {
+		name  string
+		query url.Values
+	}{
+		{"unknown status", url.Values{"status": {"REVIEWING"}}},
+		{"bad need_more_reviewers", url.Values{"need_more_reviewers": {"maybe"}}},
+		{"bad created_from", url.Values{"created_from": {"yesterday"}}},
+		{"bad order", url.Values{"order": {"random"}}},
+		{"zero limit", url.Values{"limit": {"0"}}},
+		{"limit too large", url.Values{"limit": {"1000"}}},
+		{"malformed cursor", url.Values{"cursor": {"%%%"}}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseListPullRequestsQuery(tt.query)
+			assert.ErrorIs(t, err, domain.ErrInvalidRequest)
+		})
+	}
+}
+
+func TestParseGetUserReviewsQuery(t *testing.T) {
+	t.Run("user_id required", func(t *testing.T) {
+		_, err := parseGetUserReviewsQuery(url.Values{"limit": {"10"}})
+		assert.ErrorIs(t, err, domain.ErrQueryParameterRequired)
+	})
+
+	t.Run("status limit and cursor", func(t *testing.T) {
+		cursor := domain.PageCursor{CreatedAt: time.Date(2025, 10, 24, 10, 0, 0, 0, time.UTC), ID: "pr1"}.Encode()
+		req, err := parseGetUserReviewsQuery(url.Values{
+			"user_id": {"u1"},
+			"status":  {"OPEN"},
+			"limit":   {"5"},
+			"cursor":  {cursor},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "u1", req.UserID)
+		assert.Equal(t, []domain.PRStatus{domain.PRStatusOpen}, req.Statuses)
+		assert.Equal(t, 5, req.Limit)
+		require.NotNil(t, req.Cursor)
+		assert.Equal(t, "pr1", req.Cursor.ID)
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		_, err := parseGetUserReviewsQuery(url.Values{"user_id": {"u1"}, "status": {"DONE"}})
+		assert.ErrorIs(t, err, domain.ErrInvalidRequest)
+	})
+}
+
+func TestValidateCreateWebhookReq(t *testing.T) {
+	events := []domain.EventType{domain.EventPullRequestCreated, domain.EventTeamMembersDeactivated}
+
+	tests := []struct {
+		name    string
+		req     *domain.CreateWebhookReq
+		wantErr bool
+	}{
+		{"valid", &domain.CreateWebhookReq{URL: "https://hooks.example.com/pr", Events: events}, false},
+		{"relative url", &domain.CreateWebhookReq{URL: "/hooks", Events: events}, true},
+		{"unsupported scheme", &domain.CreateWebhookReq{URL: "ftp://example.com", Events: events}, true},
+		{"no events", &domain.CreateWebhookReq{URL: "https://example.com"}, true},
+		{"unknown event", &domain.CreateWebhookReq{URL: "https://example.com", Events: []domain.EventType{"pull_request_deleted"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCreateWebhookReq(tt.req)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, domain.ErrInvalidRequest)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateCreateAPIKeyReq(t *testing.T) {
+	scopes := []domain.APIKeyScope{domain.ScopePullRequestWrite}
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		req     *domain.CreateAPIKeyReq
+		wantErr bool
+	}{
+		{"valid", &domain.CreateAPIKeyReq{Name: "ci-bot", Scopes: scopes}, false},
+		{"valid with expiry", &domain.CreateAPIKeyReq{Name: "ci-bot", Scopes: scopes, ExpiresAt: &future}, false},
+		{"blank name", &domain.CreateAPIKeyReq{Name: "  ", Scopes: scopes}, true},
+		{"no scopes", &domain.CreateAPIKeyReq{Name: "ci-bot"}, true},
+		{"unknown scope", &domain.CreateAPIKeyReq{Name: "ci-bot", Scopes: []domain.APIKeyScope{"admin"}}, true},
+		{"expired", &domain.CreateAPIKeyReq{Name: "ci-bot", Scopes: scopes, ExpiresAt: &past}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCreateAPIKeyReq(tt.req)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, domain.ErrInvalidRequest)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParseListWebhookDeliveriesQuery(t *testing.T) {
+	id := uuid.New()
+
+	req, err := parseListWebhookDeliveriesQuery(url.Values{"webhook_id": {id.String()}, "limit": {"10"}})
+	require.NoError(t, err)
+	assert.Equal(t, id, req.SubscriptionID)
+	assert.Equal(t, 10, req.Limit)
+
+	_, err = parseListWebhookDeliveriesQuery(url.Values{})
+	assert.ErrorIs(t, err, domain.ErrQueryParameterRequired)
+
+	_, err = parseListWebhookDeliveriesQuery(url.Values{"webhook_id": {"not-a-uuid"}})
+	assert.ErrorIs(t, err, domain.ErrInvalidRequest)
+}
+
+func TestParseListAuditEventsQuery(t *testing.T) {
+	t.Run("all filters", func(t *testing.T) {
+		cursor := domain.PageCursor{CreatedAt: time.Date(2025, 10, 24, 10, 0, 0, 0, time.UTC), ID: "42"}.Encode()
+		req, err := parseListAuditEventsQuery(url.Values{
+			"entity_type": {"pull_request"},
+			"entity_id":   {"pr1"},
+			"actor":       {"admin"},
+			"from":        {"2025-10-01T00:00:00Z"},
+			"to":          {"2025-11-01T00:00:00Z"},
+			"limit":       {"20"},
+			"cursor":      {cursor},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, domain.AuditEntityPullRequest, req.EntityType)
+		assert.Equal(t, "pr1", req.EntityID)
+		assert.Equal(t, "admin", req.Actor)
+		require.NotNil(t, req.From)
+		require.NotNil(t, req.To)
+		assert.Equal(t, 20, req.Limit)
+		require.NotNil(t, req.Cursor)
+		assert.Equal(t, "42", req.Cursor.ID)
+	})
+
+	invalid := []struct {
+		name  string
+		query url.Values
+	}{
+		{"unknown entity type", url.Values{"entity_type": {"repo"}}},
+		{"entity id without type", url.Values{"entity_id": {"pr1"}}},
+		{"from after to", url.Values{"from": {"2025-11-01T00:00:00Z"}, "to": {"2025-10-01T00:00:00Z"}}},
+		{"non numeric cursor id", url.Values{"cursor": {domain.PageCursor{CreatedAt: time.Now(), ID: "pr1"}.Encode()}}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseListAuditEventsQuery(tt.query)
+			assert.ErrorIs(t, err, domain.ErrInvalidRequest)
+		})
+	}
+}
+
+func TestParseStatsQueries(t *testing.T) {
+	t.Run("team with window", func(t *testing.T) {
+		req, err := parseGetTeamStatsQuery(url.Values{
+			"team_name": {"backend"},
+			"from":      {"2025-10-01T00:00:00Z"},
+			"to":        {"2025-11-01T00:00:00Z"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "backend", req.TeamName)
+		require.NotNil(t, req.From)
+		require.NotNil(t, req.To)
+		assert.Equal(t, time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC), *req.From)
+	})
+
+	t.Run("user without window", func(t *testing.T) {
+		req, err := parseGetUserStatsQuery(url.Values{"user_id": {"u1"}})
+		require.NoError(t, err)
+		assert.Equal(t, "u1", req.UserID)
+		assert.Nil(t, req.From)
+		assert.Nil(t, req.To)
+	})
+
+	t.Run("required parameters", func(t *testing.T) {
+		_, err := parseGetTeamStatsQuery(url.Values{})
+		assert.ErrorIs(t, err, domain.ErrQueryParameterRequired)
+		_, err = parseGetUserStatsQuery(url.Values{"team_name": {"backend"}})
+		assert.ErrorIs(t, err, domain.ErrQueryParameterRequired)
+	})
+
+	invalid := []struct {
+		name  string
+		query url.Values
+	}{
+		{"malformed from", url.Values{"team_name": {"backend"}, "from": {"2025-10-01"}}},
+		{"from after to", url.Values{"team_name": {"backend"}, "from": {"2025-11-01T00:00:00Z"}, "to": {"2025-10-01T00:00:00Z"}}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseGetTeamStatsQuery(tt.query)
+			assert.ErrorIs(t, err, domain.ErrInvalidRequest)
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/service"
+	"AVITOSAMPISHU/pkg/logger"
+)
+
+type WebhookHandler struct {
+	webhookService service.WebhookService
+}
+
+func NewWebhookHandler(webhookService service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{webhookService: webhookService}
+}
+
+func (h *WebhookHandler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("/webhooks/create", h.CreateWebhook)
+	mux.HandleFunc("/webhooks/list", h.ListWebhooks)
+	mux.HandleFunc("/webhooks/delete", h.DeleteWebhook)
+	mux.HandleFunc("/webhooks/deliveries", h.ListDeliveries)
+	mux.HandleFunc("/webhooks/replay", h.ReplayDelivery)
+}
+
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondMethodNotAllowed(w, r.Method)
+		return
+	}
+
+	var req domain.CreateWebhookReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, domain.ErrFailedToDecodeJSON)
+		return
+	}
+
+	// Валидация данных
+	if err := validateCreateWebhookReq(&req); err != nil {
+		respondError(w, err)
+		return
+	}
+
+	webhook, err := h.webhookService.CreateWebhook(r.Context(), &req)
+	if err != nil {
+		logger.Logger.Errorw("failed to create webhook", "url", req.URL, "error", err)
+		respondError(w, err)
+		return
+	}
+
+	logger.Logger.Infow("webhook created", "webhook_id", webhook.ID.String())
+	writeJSON(w, statusCreated, domain.WebhookResponse{Webhook: webhook})
+}
+
+func (h *WebhookHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondMethodNotAllowed(w, r.Method)
+		return
+	}
+
+	webhooks, err := h.webhookService.ListWebhooks(r.Context())
+	if err != nil {
+		logger.Logger.Errorw("failed to list webhooks", "error", err)
+		respondError(w, err)
+		return
+	}
+
+	writeJSON(w, statusOK, domain.ListWebhooksResponse{Webhooks: webhooks})
+}
+
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondMethodNotAllowed(w, r.Method)
+		return
+	}
+
+	var req domain.DeleteWebhookReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, domain.ErrFailedToDecodeJSON)
+		return
+	}
+
+	// Валидация данных
+	if err := validateDeleteWebhookReq(&req); err != nil {
+		respondError(w, err)
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(r.Context(), &req); err != nil {
+		logger.Logger.Errorw("failed to delete webhook", "webhook_id", req.ID.String(), "error", err)
+		respondError(w, err)
+		return
+	}
+
+	logger.Logger.Infow("webhook deleted", "webhook_id", req.ID.String())
+	w.WriteHeader(statusNoContent)
+}
+
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondMethodNotAllowed(w, r.Method)
+		return
+	}
+
+	req, err := parseListWebhookDeliveriesQuery(r.URL.Query())
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	deliveries, err := h.webhookService.ListDeliveries(r.Context(), req)
+	if err != nil {
+		logger.Logger.Errorw("failed to list webhook deliveries", "webhook_id", req.SubscriptionID.String(), "error", err)
+		respondError(w, err)
+		return
+	}
+
+	writeJSON(w, statusOK, domain.ListWebhookDeliveriesResponse{Deliveries: deliveries})
+}
+
+func (h *WebhookHandler) ReplayDelivery(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondMethodNotAllowed(w, r.Method)
+		return
+	}
+
+	var req domain.ReplayWebhookDeliveryReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, domain.ErrFailedToDecodeJSON)
+		return
+	}
+
+	// Валидация данных
+	if err := validateReplayWebhookDeliveryReq(&req); err != nil {
+		respondError(w, err)
+		return
+	}
+
+	delivery, err := h.webhookService.ReplayDelivery(r.Context(), &req)
+	if err != nil {
+		logger.Logger.Errorw("failed to replay webhook delivery", "delivery_id", req.DeliveryID.String(), "error", err)
+		respondError(w, err)
+		return
+	}
+
+	logger.Logger.Infow("webhook delivery replayed", "delivery_id", delivery.ID.String())
+	writeJSON(w, statusAccepted, domain.WebhookDeliveryResponse{Delivery: delivery})
+}
@@ -0,0 +1,144 @@
+package auth
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// clockSkew допустимое расхождение часов с выпускающей токены стороной
+const clockSkew = 30 * time.Second
+
+// Причины отказа в аутентификации. Все они означают 401, различаются только сообщением клиенту
+var (
+	ErrMalformedToken   = errors.New("malformed token")
+	ErrTokenExpired     = errors.New("token is expired")
+	ErrInvalidSignature = errors.New("invalid token signature")
+	ErrInvalidClaims    = errors.New("invalid token claims")
+)
+
+type Config struct {
+	HS256Secret string // Общий секрет для токенов HS256
+	JWKSFile    string // Путь к JWKS с открытыми ключами для токенов RS256
+	Issuer      string // Если задан, claim iss обязан совпадать
+	Audience    string // Если задан, claim aud обязан его содержать
+}
+
+// claims содержимое токена: стандартные claims и роль пользователя
+type claims struct {
+	jwt.RegisteredClaims
+	Role domain.Role `json:"role,omitempty"`
+}
+
+// Verifier проверяет JWT и возвращает инициатора запроса из claims sub и role.
+// Принимаются только алгоритмы, для которых настроены ключи: HS256 и RS256
+type Verifier struct {
+	hsSecret []byte
+	rsaKeys  map[string]*rsa.PublicKey
+	parser   *jwt.Parser
+}
+
+func NewVerifier(cfg Config) (*Verifier, error) {
+	v := &Verifier{}
+	methods := make([]string, 0, 2)
+
+	if cfg.HS256Secret != "" {
+		v.hsSecret = []byte(cfg.HS256Secret)
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if cfg.JWKSFile != "" {
+		keys, err := LoadJWKS(cfg.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("load JWKS: %w", err)
+		}
+		v.rsaKeys = keys
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(clockSkew),
+	}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+	v.parser = jwt.NewParser(opts...)
+
+	return v, nil
+}
+
+// Enabled сообщает, настроен ли хотя бы один способ проверки. Без него ни один токен не пройдёт проверку
+func (v *Verifier) Enabled() bool {
+	return v.hsSecret != nil || len(v.rsaKeys) > 0
+}
+
+// Verify проверяет подпись, срок действия и claims токена и возвращает инициатора запроса.
+// Токен без claim role выдаётся обычному участнику команды
+func (v *Verifier) Verify(token string) (domain.Actor, error) {
+	if !v.Enabled() {
+		return domain.Actor{}, ErrInvalidSignature
+	}
+
+	var c claims
+	if _, err := v.parser.ParseWithClaims(token, &c, v.key); err != nil {
+		return domain.Actor{}, classify(err)
+	}
+	if c.Subject == "" {
+		return domain.Actor{}, fmt.Errorf("%w: sub is required", ErrInvalidClaims)
+	}
+
+	role := c.Role
+	if role == "" {
+		role = domain.RoleMember
+	}
+	if !role.Validate() {
+		return domain.Actor{}, fmt.Errorf("%w: unknown role %q", ErrInvalidClaims, role)
+	}
+
+	return domain.Actor{ID: c.Subject, Role: role}, nil
+}
+
+// key выбирает ключ проверки по алгоритму и kid из заголовка токена
+func (v *Verifier) key(token *jwt.Token) (interface{}, error) {
+	switch token.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		return v.hsSecret, nil
+	case jwt.SigningMethodRS256.Alg():
+		kid, _ := token.Header["kid"].(string)
+		if key, ok := v.rsaKeys[kid]; ok {
+			return key, nil
+		}
+		// Токен без kid допустим, если ключ в JWKS единственный
+		if kid == "" && len(v.rsaKeys) == 1 {
+			for _, key := range v.rsaKeys {
+				return key, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	default:
+		return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+	}
+}
+
+// classify сводит ошибки библиотеки к причинам отказа
+func classify(err error) error {
+	switch {
+	case errors.Is(err, jwt.ErrTokenMalformed):
+		return ErrMalformedToken
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return ErrTokenExpired
+	case errors.Is(err, jwt.ErrTokenSignatureInvalid),
+		errors.Is(err, jwt.ErrTokenUnverifiable):
+		return ErrInvalidSignature
+	default:
+		return fmt.Errorf("%w: %v", ErrInvalidClaims, err)
+	}
+}
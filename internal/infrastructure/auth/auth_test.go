@@ -0,0 +1,166 @@
+package auth
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "test-secret"
+
+func signHS256(t *testing.T, secret string, claims jwt.Claims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	require.NoError(t, err)
+	return token
+}
+
+func validClaims(subject string) jwt.RegisteredClaims {
+	return jwt.RegisteredClaims{
+		Subject:   subject,
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+}
+
+type roleClaims struct {
+	jwt.RegisteredClaims
+	Role string `json:"role"`
+}
+
+// writeJWKS сохраняет открытый ключ в JWKS-файл во временной директории теста
+func writeJWKS(t *testing.T, kid string, key *rsa.PublicKey) string {
+	t.Helper()
+	set := map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": kid,
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}},
+	}
+	data, err := json.Marshal(set)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func TestVerifier_HS256(t *testing.T) {
+	v, err := NewVerifier(Config{HS256Secret: testSecret})
+	require.NoError(t, err)
+
+	expired := validClaims("u1")
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+
+	tests := []struct {
+		name    string
+		token   string
+		wantSub string
+		wantErr error
+	}{
+		{"valid", signHS256(t, testSecret, validClaims("u1")), "u1", nil},
+		{"expired", signHS256(t, testSecret, expired), "", ErrTokenExpired},
+		{"wrong secret", signHS256(t, "other-secret", validClaims("u1")), "", ErrInvalidSignature},
+		{"malformed", "not-a-jwt", "", ErrMalformedToken},
+		{"no subject", signHS256(t, testSecret, validClaims("")), "", ErrInvalidClaims},
+		{"no expiration", signHS256(t, testSecret, jwt.RegisteredClaims{Subject: "u1"}), "", ErrInvalidClaims},
+		{"alg none", func() string {
+			token, err := jwt.NewWithClaims(jwt.SigningMethodNone, validClaims("u1")).SignedString(jwt.UnsafeAllowNoneSignatureType)
+			require.NoError(t, err)
+			return token
+		}(), "", ErrInvalidSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actor, err := v.Verify(tt.token)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSub, actor.ID)
+			assert.Equal(t, domain.RoleMember, actor.Role)
+		})
+	}
+}
+
+func TestVerifier_RS256WithJWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	v, err := NewVerifier(Config{JWKSFile: writeJWKS(t, "key-1", &key.PublicKey), Issuer: "idp"})
+	require.NoError(t, err)
+
+	sign := func(kid, issuer string) string {
+		claims := validClaims("u2")
+		claims.Issuer = issuer
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		if kid != "" {
+			token.Header["kid"] = kid
+		}
+		signed, err := token.SignedString(key)
+		require.NoError(t, err)
+		return signed
+	}
+
+	actor, err := v.Verify(sign("key-1", "idp"))
+	require.NoError(t, err)
+	assert.Equal(t, "u2", actor.ID)
+
+	// Единственный ключ подходит и для токена без kid
+	_, err = v.Verify(sign("", "idp"))
+	assert.NoError(t, err)
+
+	_, err = v.Verify(sign("key-2", "idp"))
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	_, err = v.Verify(sign("key-1", "other-idp"))
+	assert.ErrorIs(t, err, ErrInvalidClaims)
+
+	// HS256 не настроен, поэтому такие токены отклоняются
+	_, err = v.Verify(signHS256(t, testSecret, validClaims("u2")))
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestVerifier_Role(t *testing.T) {
+	v, err := NewVerifier(Config{HS256Secret: testSecret})
+	require.NoError(t, err)
+
+	actor, err := v.Verify(signHS256(t, testSecret, roleClaims{RegisteredClaims: validClaims("lead"), Role: "team_lead"}))
+	require.NoError(t, err)
+	assert.Equal(t, domain.Actor{ID: "lead", Role: domain.RoleTeamLead}, actor)
+
+	_, err = v.Verify(signHS256(t, testSecret, roleClaims{RegisteredClaims: validClaims("u1"), Role: "root"}))
+	assert.ErrorIs(t, err, ErrInvalidClaims)
+}
+
+func TestVerifier_NotConfigured(t *testing.T) {
+	v, err := NewVerifier(Config{})
+	require.NoError(t, err)
+	assert.False(t, v.Enabled())
+
+	_, err = v.Verify(signHS256(t, testSecret, validClaims("u1")))
+	assert.Error(t, err)
+}
+
+func TestLoadJWKS_NoRSAKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"keys":[{"kty":"EC","kid":"ec-1"}]}`), 0o600))
+
+	_, err := LoadJWKS(path)
+	assert.Error(t, err)
+}
@@ -0,0 +1,180 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"runtime"
+	"strings"
+	"time"
+
+	"AVITOSAMPISHU/internal/infrastructure/tracing"
+	"AVITOSAMPISHU/pkg/metrics"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentDriver оборачивает драйвер БД: каждый запрос и каждая транзакция получают спан,
+// а запросы ещё и метрики длительности и ошибок. Операция — метод репозитория, из которого выполнен запрос
+func InstrumentDriver(d driver.Driver) driver.Driver {
+	return instrumentedDriver{Driver: d}
+}
+
+type instrumentedDriver struct {
+	driver.Driver
+}
+
+func (d instrumentedDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.Driver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedConn{Conn: conn}, nil
+}
+
+// instrumentedConn добавляет спаны и метрики к QueryContext, ExecContext и BeginTx, остальное передаёт соединению драйвера
+type instrumentedConn struct {
+	driver.Conn
+}
+
+func (c *instrumentedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	ctx, done := startQuery(ctx, query)
+	rows, err := queryer.QueryContext(ctx, query, args)
+	done(err)
+	return rows, err
+}
+
+func (c *instrumentedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	ctx, done := startQuery(ctx, query)
+	result, err := execer.ExecContext(ctx, query, args)
+	done(err)
+	return result, err
+}
+
+func (c *instrumentedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return preparer.PrepareContext(ctx, query)
+	}
+	return c.Conn.Prepare(query)
+}
+
+// BeginTx открывает спан db.transaction, который закрывается на Commit или Rollback
+func (c *instrumentedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	_, span := tracing.Start(ctx, "db.transaction", trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("db.system", "postgresql"), attribute.String("code.function", queryOperation())))
+
+	var tx driver.Tx
+	var err error
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		tx, err = beginner.BeginTx(ctx, opts)
+	} else {
+		tx, err = c.Conn.Begin() //nolint:staticcheck // запасной путь для драйверов без BeginTx
+	}
+	if err != nil {
+		endSpan(span, err)
+		return nil, err
+	}
+	return &instrumentedTx{Tx: tx, span: span}, nil
+}
+
+func (c *instrumentedConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *instrumentedConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *instrumentedConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+type instrumentedTx struct {
+	driver.Tx
+	span trace.Span
+}
+
+func (tx *instrumentedTx) Commit() error {
+	err := tx.Tx.Commit()
+	tx.span.SetAttributes(attribute.Bool("db.transaction.committed", err == nil))
+	endSpan(tx.span, err)
+	return err
+}
+
+func (tx *instrumentedTx) Rollback() error {
+	err := tx.Tx.Rollback()
+	tx.span.SetAttributes(attribute.Bool("db.transaction.committed", false))
+	endSpan(tx.span, err)
+	return err
+}
+
+// startQuery открывает спан запроса и возвращает функцию, которая закрывает его и пишет метрики операции
+func startQuery(ctx context.Context, query string) (context.Context, func(err error)) {
+	start := time.Now()
+	operation := queryOperation()
+
+	ctx, span := tracing.Start(ctx, operation, trace.WithSpanKind(trace.SpanKindClient))
+	if span.IsRecording() {
+		span.SetAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.query.text", query),
+			attribute.String("code.function", operation),
+		)
+	}
+
+	return ctx, func(err error) {
+		metrics.DBQueryDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+		if err != nil {
+			metrics.DBQueryErrors.WithLabelValues(operation).Inc()
+		}
+		endSpan(span, err)
+	}
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// queryOperation возвращает первую функцию вне database/sql и этого файла, например
+// reviewer_repository.(*PrReviewersStorage).GetAssignedReviewers. Число таких мест в коде ограничено,
+// поэтому имя годится и в имя спана, и в метку метрики
+func queryOperation() string {
+	pcs := make([]uintptr, 16)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		fn := frame.Function
+		if !strings.HasPrefix(fn, "database/sql.") && !strings.HasPrefix(fn, "runtime.") &&
+			!strings.Contains(fn, "/infrastructure/database.") {
+			return fn[strings.LastIndex(fn, "/")+1:]
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
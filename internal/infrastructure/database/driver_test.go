@@ -0,0 +1,108 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"AVITOSAMPISHU/internal/infrastructure/database"
+	"AVITOSAMPISHU/internal/infrastructure/tracing"
+	"AVITOSAMPISHU/pkg/metrics"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func openTracedMock(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+	mockDB, mock, err := sqlmock.NewWithDSN(t.Name())
+	require.NoError(t, err)
+	t.Cleanup(func() { mockDB.Close() })
+
+	driverName := "sqlmock+instrumented+" + t.Name()
+	sql.Register(driverName, database.InstrumentDriver(mockDB.Driver()))
+	db, err := sql.Open(driverName, t.Name())
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db, mock
+}
+
+func getAssignedReviewers(ctx context.Context, db *sql.DB) error {
+	rows, err := db.QueryContext(ctx, "SELECT user_id FROM reviewers WHERE pr_id = $1", "pr-1")
+	if err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
+func TestInstrumentDriver_QuerySpanNamedAfterCaller(t *testing.T) {
+	recorder := newRecorder(t)
+	db, mock := openTracedMock(t)
+	mock.ExpectQuery("SELECT user_id FROM reviewers").WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow("u1"))
+
+	ctx, parent := tracing.Start(context.Background(), "UserService.DeactivateTeamMembers")
+	require.NoError(t, getAssignedReviewers(ctx, db))
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	query := spans[0]
+	assert.Equal(t, "database_test.getAssignedReviewers", query.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), query.Parent().SpanID())
+	assert.Contains(t, query.Attributes(), attribute.String("db.query.text", "SELECT user_id FROM reviewers WHERE pr_id = $1"))
+}
+
+func deleteTeams(ctx context.Context, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, "DELETE FROM teams")
+	return err
+}
+
+func TestInstrumentDriver_FailedExecMarksSpanAndCountsError(t *testing.T) {
+	recorder := newRecorder(t)
+	db, mock := openTracedMock(t)
+	mock.ExpectExec("DELETE FROM teams").WillReturnError(errors.New("deadlock detected"))
+	errorsBefore := testutil.ToFloat64(metrics.DBQueryErrors.WithLabelValues("database_test.deleteTeams"))
+
+	require.Error(t, deleteTeams(context.Background(), db))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "deadlock detected", spans[0].Status().Description)
+	assert.Equal(t, errorsBefore+1, testutil.ToFloat64(metrics.DBQueryErrors.WithLabelValues("database_test.deleteTeams")))
+}
+
+func TestInstrumentDriver_TransactionSpan(t *testing.T) {
+	recorder := newRecorder(t)
+	db, mock := openTracedMock(t)
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE users").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	tx, err := db.BeginTx(context.Background(), nil)
+	require.NoError(t, err)
+	_, err = tx.ExecContext(context.Background(), "UPDATE users SET is_active = false")
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "db.transaction", spans[1].Name())
+	assert.Contains(t, spans[1].Attributes(), attribute.Bool("db.transaction.committed", true))
+}
@@ -0,0 +1,372 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"AVITOSAMPISHU/pkg/logger"
+)
+
+// advisoryLockID ключ pg_advisory_lock, под которым применяются миграции, чтобы реплики не накатывали их одновременно
+const advisoryLockID int64 = 7_305_914_221
+
+const (
+	createVersionTableQuery = `CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)`
+	selectVersionQuery      = `SELECT version, dirty FROM schema_migrations LIMIT 1`
+	clearVersionQuery       = `TRUNCATE schema_migrations`
+	insertVersionQuery      = `INSERT INTO schema_migrations (version, dirty) VALUES ($1, $2)`
+)
+
+var (
+	// ErrSchemaOutdated схема БД не готова для этой версии кода: миграции не применены или остались в грязном состоянии
+	ErrSchemaOutdated = errors.New("database schema is outdated")
+	// ErrSchemaTooNew схема БД новее последней встроенной миграции: бинарник не знает, как с ней работать
+	ErrSchemaTooNew = errors.New("database schema is newer than the binary")
+	// ErrDirty схема помечена грязной (например, миграцией golang-migrate, упавшей посередине): нужна ручная починка и force
+	ErrDirty = errors.New("database schema is dirty")
+)
+
+// Migration одна пара up/down файлов
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status состояние схемы относительно встроенных миграций
+type Status struct {
+	Version int64 // 0, если миграции ещё не применялись
+	Dirty   bool
+	Latest  int64
+	Pending []Migration
+}
+
+// Migrator применяет миграции и ведёт таблицу schema_migrations в том же формате, что golang-migrate,
+// поэтому подхватывает базы, мигрированные раньше его CLI
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+func New(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Load читает файлы NNNN_name.up.sql и NNNN_name.down.sql из корня fsys и возвращает миграции по возрастанию версии.
+// У каждой миграции должны быть оба файла
+func Load(fsys fs.FS) ([]Migration, error) {
+	files, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration, len(files)/2)
+	for _, file := range files {
+		base, direction, ok := cutDirection(file)
+		if !ok {
+			return nil, fmt.Errorf("migration %s: expected .up.sql or .down.sql suffix", file)
+		}
+		prefix, name, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, fmt.Errorf("migration %s: expected NNNN_name prefix", file)
+		}
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s: invalid version %q", file, prefix)
+		}
+
+		body, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+
+		m, exists := byVersion[version]
+		if !exists {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		}
+		if m.Name != name {
+			return nil, fmt.Errorf("migration %d: conflicting names %q and %q", version, m.Name, name)
+		}
+		if direction == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s: both up and down files are required", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+func cutDirection(file string) (base, direction string, ok bool) {
+	name := path.Base(file)
+	if base, ok := strings.CutSuffix(name, ".up.sql"); ok {
+		return base, "up", true
+	}
+	if base, ok := strings.CutSuffix(name, ".down.sql"); ok {
+		return base, "down", true
+	}
+	return "", "", false
+}
+
+// Latest версия последней встроенной миграции
+func (m *Migrator) Latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Status читает текущую версию схемы и список ещё не применённых миграций
+func (m *Migrator) Status(ctx context.Context) (Status, error) {
+	version, dirty, err := readVersion(ctx, m.db)
+	if err != nil {
+		return Status{}, err
+	}
+
+	status := Status{Version: version, Dirty: dirty, Latest: m.Latest()}
+	for _, migration := range m.migrations {
+		if migration.Version > version {
+			status.Pending = append(status.Pending, migration)
+		}
+	}
+	return status, nil
+}
+
+// Check сверяет схему БД со встроенными миграциями: отстающая или грязная схема — ErrSchemaOutdated,
+// более новая — ErrSchemaTooNew
+func (m *Migrator) Check(ctx context.Context) error {
+	version, dirty, err := readVersion(ctx, m.db)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case dirty:
+		return fmt.Errorf("%w: migration %d failed and left the schema dirty", ErrSchemaOutdated, version)
+	case version == 0:
+		return fmt.Errorf("%w: no migrations applied, expected version %d", ErrSchemaOutdated, m.Latest())
+	case version < m.Latest():
+		return fmt.Errorf("%w: version %d, expected %d", ErrSchemaOutdated, version, m.Latest())
+	case version > m.Latest():
+		return fmt.Errorf("%w: version %d, binary knows up to %d", ErrSchemaTooNew, version, m.Latest())
+	}
+	return nil
+}
+
+// Up применяет все миграции новее текущей версии и возвращает их число.
+// Схему новее бинарника не трогает и возвращает ErrSchemaTooNew
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	applied := 0
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		version, err := lockedVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if version > m.Latest() {
+			return fmt.Errorf("%w: version %d, binary knows up to %d", ErrSchemaTooNew, version, m.Latest())
+		}
+
+		for _, migration := range m.migrations {
+			if migration.Version <= version {
+				continue
+			}
+			if err := m.apply(ctx, conn, migration.Version, migration.Name, migration.Up, migration.Version); err != nil {
+				return err
+			}
+			applied++
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down откатывает n последних применённых миграций и возвращает число откаченных
+func (m *Migrator) Down(ctx context.Context, n int) (int, error) {
+	if n <= 0 {
+		return 0, fmt.Errorf("number of migrations to roll back must be positive, got %d", n)
+	}
+
+	reverted := 0
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		version, err := lockedVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		index := m.indexOf(version)
+		if version != 0 && index < 0 {
+			return fmt.Errorf("%w: version %d is not among embedded migrations", ErrSchemaTooNew, version)
+		}
+
+		for ; index >= 0 && reverted < n; index-- {
+			migration := m.migrations[index]
+			var previous int64
+			if index > 0 {
+				previous = m.migrations[index-1].Version
+			}
+			if err := m.apply(ctx, conn, migration.Version, migration.Name, migration.Down, previous); err != nil {
+				return err
+			}
+			reverted++
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Force записывает версию схемы без выполнения миграций и снимает признак dirty.
+// Используется после ручной починки схемы, на которой упала миграция; 0 очищает версию
+func (m *Migrator) Force(ctx context.Context, version int64) error {
+	if version != 0 && m.indexOf(version) < 0 {
+		return fmt.Errorf("version %d is not among embedded migrations", version)
+	}
+
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		if _, err := conn.ExecContext(ctx, createVersionTableQuery); err != nil {
+			return err
+		}
+		return setVersion(ctx, conn, version)
+	})
+}
+
+// apply выполняет SQL миграции и запись новой версии в одной транзакции, поэтому упавшая миграция
+// откатывается целиком и не оставляет схему грязной. Из-за этого в миграциях нельзя использовать CREATE INDEX CONCURRENTLY
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, version int64, name, query string, target int64) error {
+	logger.Logger.Infow("applying migration", "version", version, "name", name, "target_version", target)
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("migration %d_%s: %w", version, name, err)
+	}
+	if _, err := tx.ExecContext(ctx, clearVersionQuery); err != nil {
+		return err
+	}
+	if target > 0 {
+		if _, err := tx.ExecContext(ctx, insertVersionQuery, target, false); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("migration %d_%s: %w", version, name, err)
+	}
+	return nil
+}
+
+// withLock выполняет fn на отдельном соединении под pg_advisory_lock: блокировка сессионная,
+// поэтому все запросы миграции должны идти через одно соединение
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, advisoryLockID); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer func() {
+		// Снимаем блокировку и при отменённом контексте, иначе она останется на соединении в пуле
+		if _, err := conn.ExecContext(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock($1)`, advisoryLockID); err != nil {
+			logger.Logger.Errorw("failed to release migration lock", "error", err)
+		}
+	}()
+
+	return fn(conn)
+}
+
+func (m *Migrator) indexOf(version int64) int {
+	for i, migration := range m.migrations {
+		if migration.Version == version {
+			return i
+		}
+	}
+	return -1
+}
+
+// lockedVersion создаёт таблицу версий при первом запуске и отказывает, если схема грязная
+func lockedVersion(ctx context.Context, conn *sql.Conn) (int64, error) {
+	if _, err := conn.ExecContext(ctx, createVersionTableQuery); err != nil {
+		return 0, err
+	}
+
+	version, dirty, err := readVersion(ctx, conn)
+	if err != nil {
+		return 0, err
+	}
+	if dirty {
+		return 0, fmt.Errorf("%w: migration %d failed, fix the schema manually and run migrate force", ErrDirty, version)
+	}
+	return version, nil
+}
+
+type queryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// readVersion возвращает 0, если таблицы версий ещё нет или она пуста
+func readVersion(ctx context.Context, q queryer) (int64, bool, error) {
+	var exists bool
+	if err := q.QueryRowContext(ctx, `SELECT to_regclass('schema_migrations') IS NOT NULL`).Scan(&exists); err != nil {
+		return 0, false, err
+	}
+	if !exists {
+		return 0, false, nil
+	}
+
+	var version int64
+	var dirty bool
+	err := q.QueryRowContext(ctx, selectVersionQuery).Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return version, dirty, nil
+}
+
+func setVersion(ctx context.Context, conn *sql.Conn, version int64) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, clearVersionQuery); err != nil {
+		return err
+	}
+	if version > 0 {
+		if _, err := tx.ExecContext(ctx, insertVersionQuery, version, false); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
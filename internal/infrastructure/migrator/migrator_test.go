@@ -0,0 +1,202 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"testing/fstest"
+
+	"AVITOSAMPISHU/migrations"
+	"AVITOSAMPISHU/pkg/logger"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logger.InitLogger()
+}
+
+func testFS() fstest.MapFS {
+	return fstest.MapFS{
+		"0001_init_teams.up.sql":   {Data: []byte("CREATE TABLE teams (id INT)")},
+		"0001_init_teams.down.sql": {Data: []byte("DROP TABLE teams")},
+		"0002_init_users.up.sql":   {Data: []byte("CREATE TABLE users (id INT)")},
+		"0002_init_users.down.sql": {Data: []byte("DROP TABLE users")},
+	}
+}
+
+func TestLoad_EmbeddedMigrations(t *testing.T) {
+	loaded, err := Load(migrations.FS)
+	require.NoError(t, err)
+	require.NotEmpty(t, loaded)
+
+	for i, m := range loaded {
+		assert.Equal(t, int64(i+1), m.Version, "migrations must be numbered without gaps")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	loaded, err := Load(testFS())
+	require.NoError(t, err)
+	require.Len(t, loaded, 2)
+	assert.Equal(t, Migration{Version: 1, Name: "init_teams", Up: "CREATE TABLE teams (id INT)", Down: "DROP TABLE teams"}, loaded[0])
+	assert.Equal(t, int64(2), loaded[1].Version)
+
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{"missing down", fstest.MapFS{"0001_a.up.sql": {Data: []byte("SELECT 1")}}},
+		{"bad version", fstest.MapFS{"x_a.up.sql": {Data: []byte("SELECT 1")}, "x_a.down.sql": {Data: []byte("SELECT 1")}}},
+		{"bad suffix", fstest.MapFS{"0001_a.sql": {Data: []byte("SELECT 1")}}},
+		{"conflicting names", fstest.MapFS{"0001_a.up.sql": {Data: []byte("SELECT 1")}, "0001_b.down.sql": {Data: []byte("SELECT 1")}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(tt.fsys)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func expectVersion(mock sqlmock.Sqlmock, version int64, dirty bool) {
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT to_regclass('schema_migrations') IS NOT NULL`)).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(regexp.QuoteMeta(selectVersionQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(version, dirty))
+}
+
+func expectLock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_lock($1)`)).WithArgs(advisoryLockID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(createVersionTableQuery)).WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func expectUnlock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_unlock($1)`)).WithArgs(advisoryLockID).WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func newTestMigrator(t *testing.T) (*Migrator, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	m, err := New(db, testFS())
+	require.NoError(t, err)
+	return m, mock
+}
+
+func TestMigrator_UpAppliesPendingUnderLock(t *testing.T) {
+	m, mock := newTestMigrator(t)
+
+	expectLock(mock)
+	expectVersion(mock, 1, false)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE users (id INT)")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(clearVersionQuery)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(insertVersionQuery)).WithArgs(int64(2), false).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	expectUnlock(mock)
+
+	applied, err := m.Up(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, applied)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_UpFailureRollsBack(t *testing.T) {
+	m, mock := newTestMigrator(t)
+
+	expectLock(mock)
+	expectVersion(mock, 1, false)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE users (id INT)")).WillReturnError(sql.ErrConnDone)
+	mock.ExpectRollback()
+	expectUnlock(mock)
+
+	applied, err := m.Up(context.Background())
+	require.ErrorIs(t, err, sql.ErrConnDone)
+	assert.Zero(t, applied)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_UpRefusesDirtyAndNewerSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		version int64
+		dirty   bool
+		wantErr error
+	}{
+		{"dirty", 1, true, ErrDirty},
+		{"newer schema", 3, false, ErrSchemaTooNew},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, mock := newTestMigrator(t)
+
+			expectLock(mock)
+			expectVersion(mock, tt.version, tt.dirty)
+			expectUnlock(mock)
+
+			_, err := m.Up(context.Background())
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMigrator_DownToZeroClearsVersion(t *testing.T) {
+	m, mock := newTestMigrator(t)
+
+	expectLock(mock)
+	expectVersion(mock, 1, false)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DROP TABLE teams")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(clearVersionQuery)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	expectUnlock(mock)
+
+	reverted, err := m.Down(context.Background(), 5)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reverted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Check(t *testing.T) {
+	tests := []struct {
+		name    string
+		version int64
+		dirty   bool
+		wantErr error
+	}{
+		{"latest", 2, false, nil},
+		{"outdated", 1, false, ErrSchemaOutdated},
+		{"dirty", 2, true, ErrSchemaOutdated},
+		{"newer", 3, false, ErrSchemaTooNew},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, mock := newTestMigrator(t)
+			expectVersion(mock, tt.version, tt.dirty)
+
+			err := m.Check(context.Background())
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMigrator_CheckWithoutVersionTable(t *testing.T) {
+	m, mock := newTestMigrator(t)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT to_regclass('schema_migrations') IS NOT NULL`)).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	assert.ErrorIs(t, m.Check(context.Background()), ErrSchemaOutdated)
+}
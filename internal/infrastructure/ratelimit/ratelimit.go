@@ -0,0 +1,153 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultRoute метка маршрутов без собственного лимита: у каждого клиента на них один общий бакет
+const DefaultRoute = "default"
+
+// sweepInterval как часто из памяти удаляются бакеты, которые успели заполниться и ничего не ограничивают
+const sweepInterval = time.Minute
+
+// Limit скорость пополнения бакета в запросах в секунду и его ёмкость. RPS <= 0 отключает ограничение
+type Limit struct {
+	RPS   float64
+	Burst int
+}
+
+type Config struct {
+	Default Limit            // Лимит для маршрутов без собственного
+	Routes  map[string]Limit // Лимиты отдельных маршрутов по пути запроса
+}
+
+// ParseRoutes разбирает лимиты маршрутов вида "/path=rps:burst" через запятую
+func ParseRoutes(spec string) (map[string]Limit, error) {
+	routes := make(map[string]Limit)
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		path, value, found := strings.Cut(item, "=")
+		if !found || !strings.HasPrefix(path, "/") {
+			return nil, fmt.Errorf("invalid route limit %q: expected /path=rps:burst", item)
+		}
+		rawRPS, rawBurst, found := strings.Cut(value, ":")
+		if !found {
+			return nil, fmt.Errorf("invalid route limit %q: expected /path=rps:burst", item)
+		}
+
+		rps, err := strconv.ParseFloat(rawRPS, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rps in %q: %w", item, err)
+		}
+		burst, err := strconv.Atoi(rawBurst)
+		if err != nil {
+			return nil, fmt.Errorf("invalid burst in %q: %w", item, err)
+		}
+		routes[path] = Limit{RPS: rps, Burst: burst}
+	}
+	return routes, nil
+}
+
+type bucketKey struct {
+	route string
+	key   string
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// Limiter ограничивает частоту запросов алгоритмом token bucket: у каждой пары маршрут + клиент свой бакет.
+// Состояние хранится в памяти процесса, поэтому при нескольких репликах лимит действует на каждую отдельно
+type Limiter struct {
+	cfg Config
+	now func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[bucketKey]*bucket
+	lastSweep time.Time
+}
+
+func NewLimiter(cfg Config) *Limiter {
+	return &Limiter{
+		cfg:       cfg,
+		now:       time.Now,
+		buckets:   make(map[bucketKey]*bucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// Route возвращает маршрут, по которому считается лимит для пути: сам путь, если у него свой лимит, иначе DefaultRoute
+func (l *Limiter) Route(path string) string {
+	if _, ok := l.cfg.Routes[path]; ok {
+		return path
+	}
+	return DefaultRoute
+}
+
+func (l *Limiter) limit(route string) Limit {
+	if limit, ok := l.cfg.Routes[route]; ok {
+		return limit
+	}
+	return l.cfg.Default
+}
+
+// Allow списывает токен из бакета клиента key на маршруте route.
+// Если токена нет, возвращает false и время, через которое он появится
+func (l *Limiter) Allow(route, key string) (bool, time.Duration) {
+	limit := l.limit(route)
+	if limit.RPS <= 0 {
+		return true, 0
+	}
+	burst := math.Max(float64(limit.Burst), 1)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	k := bucketKey{route: route, key: key}
+	b, ok := l.buckets[k]
+	if !ok {
+		b = &bucket{tokens: burst, updated: now}
+		l.buckets[k] = b
+	}
+
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.updated).Seconds()*limit.RPS)
+	b.updated = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+
+	wait := time.Duration((1 - b.tokens) / limit.RPS * float64(time.Second))
+	return false, wait
+}
+
+// sweep удаляет бакеты, которые за время простоя заполнились бы полностью: новый бакет будет таким же
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for k, b := range l.buckets {
+		limit := l.limit(k.route)
+		burst := math.Max(float64(limit.Burst), 1)
+		if b.tokens+now.Sub(b.updated).Seconds()*limit.RPS >= burst {
+			delete(l.buckets, k)
+		}
+	}
+}
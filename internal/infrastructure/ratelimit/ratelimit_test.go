@@ -0,0 +1,100 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLimiter(cfg Config) (*Limiter, *time.Time) {
+	now := time.Date(2025, 10, 24, 10, 0, 0, 0, time.UTC)
+	l := NewLimiter(cfg)
+	l.now = func() time.Time { return now }
+	l.lastSweep = now
+	return l, &now
+}
+
+func TestLimiter_Allow(t *testing.T) {
+	l, now := newTestLimiter(Config{Default: Limit{RPS: 2, Burst: 3}})
+
+	for i := 0; i < 3; i++ {
+		allowed, _ := l.Allow(DefaultRoute, "alice")
+		require.True(t, allowed, "request %d must fit into burst", i)
+	}
+
+	allowed, retryAfter := l.Allow(DefaultRoute, "alice")
+	assert.False(t, allowed)
+	assert.Equal(t, 500*time.Millisecond, retryAfter)
+
+	// У другого клиента свой бакет
+	allowed, _ = l.Allow(DefaultRoute, "bob")
+	assert.True(t, allowed)
+
+	*now = now.Add(500 * time.Millisecond)
+	allowed, _ = l.Allow(DefaultRoute, "alice")
+	assert.True(t, allowed)
+}
+
+func TestLimiter_RouteLimits(t *testing.T) {
+	l, _ := newTestLimiter(Config{
+		Default: Limit{RPS: 100, Burst: 100},
+		Routes:  map[string]Limit{"/users/getReview": {RPS: 1, Burst: 1}},
+	})
+
+	route := l.Route("/users/getReview")
+	require.Equal(t, "/users/getReview", route)
+	assert.Equal(t, DefaultRoute, l.Route("/team/get"))
+
+	allowed, _ := l.Allow(route, "alice")
+	assert.True(t, allowed)
+	allowed, retryAfter := l.Allow(route, "alice")
+	assert.False(t, allowed)
+	assert.Equal(t, time.Second, retryAfter)
+
+	// Лимит маршрута не расходует общий бакет
+	allowed, _ = l.Allow(DefaultRoute, "alice")
+	assert.True(t, allowed)
+}
+
+func TestLimiter_Disabled(t *testing.T) {
+	l, _ := newTestLimiter(Config{Default: Limit{RPS: 0, Burst: 1}})
+
+	for i := 0; i < 10; i++ {
+		allowed, _ := l.Allow(DefaultRoute, "alice")
+		require.True(t, allowed)
+	}
+	assert.Empty(t, l.buckets)
+}
+
+func TestLimiter_SweepsIdleBuckets(t *testing.T) {
+	l, now := newTestLimiter(Config{Default: Limit{RPS: 1, Burst: 2}})
+
+	l.Allow(DefaultRoute, "alice")
+	l.Allow(DefaultRoute, "alice")
+	require.Len(t, l.buckets, 1)
+
+	*now = now.Add(sweepInterval)
+	l.Allow(DefaultRoute, "bob")
+	assert.Len(t, l.buckets, 1)
+	assert.Contains(t, l.buckets, bucketKey{route: DefaultRoute, key: "bob"})
+}
+
+func TestParseRoutes(t *testing.T) {
+	routes, err := ParseRoutes("/users/getReview=5:10, /pullRequest/list=0.5:1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]Limit{
+		"/users/getReview":  {RPS: 5, Burst: 10},
+		"/pullRequest/list": {RPS: 0.5, Burst: 1},
+	}, routes)
+
+	routes, err = ParseRoutes("")
+	require.NoError(t, err)
+	assert.Empty(t, routes)
+
+	for _, spec := range []string{"users=1:1", "/users/getReview=5", "/users/getReview=a:1", "/users/getReview=1:b"} {
+		_, err := ParseRoutes(spec)
+		assert.Error(t, err, spec)
+	}
+}
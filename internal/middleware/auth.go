@@ -1,37 +1,134 @@
 package middleware
 
 import (
+	"context"
+	"crypto/subtle"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strings"
 
 	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
 )
 
 const (
-	statusUnauthorized = 401
+	statusUnauthorized        = 401
+	statusInternalServerError = 500
 )
 
-// AuthMiddleware проверяет наличие заголовка Authorization
-// Исключает /metrics из проверки авторизации для Prometheus
-func AuthMiddleware(next http.Handler) http.Handler {
+// Схемы заголовка Authorization: JWT или токен администратора у пользователей, ключ API у сервисов
+const (
+	schemeBearer = "Bearer"
+	schemeAPIKey = "ApiKey"
+)
+
+// TokenVerifier проверяет bearer-токен и возвращает инициатора запроса
+type TokenVerifier interface {
+	Verify(token string) (domain.Actor, error)
+}
+
+// APIKeyAuthenticator проверяет ключ API и возвращает инициатора запроса с разрешениями ключа.
+// Отклонённый ключ возвращается как domain.ErrUnauthorized, остальные ошибки считаются внутренними
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, key string) (domain.Actor, error)
+}
+
+// publicPaths пути, доступные без заголовка Authorization
+var publicPaths = map[string]struct{}{
+	"/metrics": {},
+	"/healthz": {},
+	"/readyz":  {},
+}
+
+// AuthMiddleware проверяет заголовок Authorization и кладёт инициатора запроса в контекст.
+// Схема Bearer: непустой adminToken (ADMIN_TOKEN при включённом BOOTSTRAP_ADMIN) даёт права администратора,
+// остальные токены проверяются как JWT.
+// Схема ApiKey: ключ проверяется по БД, права определяются его разрешениями.
+// Исключает из проверки авторизации /metrics для Prometheus и пробы /healthz, /readyz
+func AuthMiddleware(verifier TokenVerifier, apiKeys APIKeyAuthenticator, adminToken string, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Пропускаем /metrics без авторизации для Prometheus
-		if r.URL.Path == "/metrics" {
+		// Пропускаем /metrics и пробы здоровья без авторизации
+		if _, public := publicPaths[r.URL.Path]; public {
 			next.ServeHTTP(w, r)
 			return
 		}
 
-		authHeader := r.Header.Get("Authorization")
-		if authHeader == "" {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(statusUnauthorized)
+		scheme, token, ok := credentials(r.Header.Get("Authorization"))
+		if !ok {
+			respondUnauthorized(w, "Authorization header with Bearer token or ApiKey is required")
+			return
+		}
+
+		if scheme == schemeAPIKey {
+			actor, err := apiKeys.Authenticate(r.Context(), token)
+			if err != nil {
+				if !errors.Is(err, domain.ErrUnauthorized) {
+					logger.Logger.Errorw("api key authentication error", "path", r.URL.Path, "error", err)
+					respondInternalError(w)
+					return
+				}
+				logger.Logger.Warnw("authentication failed", "path", r.URL.Path, "scheme", scheme, "error", err)
+				respondUnauthorized(w, err.Error())
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(domain.ContextWithActor(r.Context(), actor)))
+			return
+		}
+
+		if adminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) == 1 {
+			actor := domain.Actor{ID: domain.AdminActorID, Role: domain.RoleAdmin}
+			next.ServeHTTP(w, r.WithContext(domain.ContextWithActor(r.Context(), actor)))
+			return
+		}
 
-			errorResp := domain.NewErrorResponse(domain.ErrorCodeInvalidRequest, "Authorization header is required")
-			data, _ := json.MarshalIndent(errorResp, "", "  ")
-			_, _ = w.Write(data)
+		actor, err := verifier.Verify(token)
+		if err != nil {
+			logger.Logger.Warnw("authentication failed", "path", r.URL.Path, "error", err)
+			respondUnauthorized(w, err.Error())
 			return
 		}
 
-		next.ServeHTTP(w, r)
+		next.ServeHTTP(w, r.WithContext(domain.ContextWithActor(r.Context(), actor)))
 	})
 }
+
+// credentials разбирает заголовок вида "<scheme> <token>" для схем Bearer и ApiKey, схема регистронезависима
+func credentials(header string) (string, string, bool) {
+	scheme, token, found := strings.Cut(header, " ")
+	if !found {
+		return "", "", false
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", "", false
+	}
+
+	switch {
+	case strings.EqualFold(scheme, schemeBearer):
+		return schemeBearer, token, true
+	case strings.EqualFold(scheme, schemeAPIKey):
+		return schemeAPIKey, token, true
+	default:
+		return "", "", false
+	}
+}
+
+func respondUnauthorized(w http.ResponseWriter, message string) {
+	w.Header().Add("WWW-Authenticate", `Bearer error="invalid_token"`)
+	w.Header().Add("WWW-Authenticate", schemeAPIKey)
+	writeError(w, statusUnauthorized, domain.NewErrorResponse(domain.ErrorCodeUnauthorized, message))
+}
+
+func respondInternalError(w http.ResponseWriter) {
+	writeError(w, statusInternalServerError, domain.NewErrorResponse(domain.ErrorCodeInternalError, domain.ErrInternalError.Error()))
+}
+
+func writeError(w http.ResponseWriter, status int, errorResp domain.ErrorResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	data, _ := json.MarshalIndent(errorResp, "", "  ")
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logger.InitLogger()
+}
+
+type stubVerifier map[string]domain.Actor
+
+func (v stubVerifier) Verify(token string) (domain.Actor, error) {
+	if actor, ok := v[token]; ok {
+		return actor, nil
+	}
+	return domain.Actor{}, errors.New("token is expired")
+}
+
+type stubAPIKeys map[string]domain.Actor
+
+func (k stubAPIKeys) Authenticate(_ context.Context, key string) (domain.Actor, error) {
+	if key == "broken" {
+		return domain.Actor{}, errors.New("connection refused")
+	}
+	if actor, ok := k[key]; ok {
+		return actor, nil
+	}
+	return domain.Actor{}, domain.ErrUnauthorized
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	var gotActor domain.Actor
+	bot := domain.Actor{ID: "apikey:1", Role: domain.RoleService, Scopes: []domain.APIKeyScope{domain.ScopePullRequestWrite}}
+	verifier := stubVerifier{"good": {ID: "user-1", Role: domain.RoleMember}}
+	handler := AuthMiddleware(verifier, stubAPIKeys{"prk_good": bot}, "admin-secret", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotActor = domain.ActorFromContext(r.Context())
+	}))
+
+	tests := []struct {
+		name       string
+		header     string
+		wantStatus int
+		wantActor  domain.Actor
+	}{
+		{"valid jwt", "Bearer good", http.StatusOK, domain.Actor{ID: "user-1", Role: domain.RoleMember}},
+		{"scheme is case insensitive", "bearer good", http.StatusOK, domain.Actor{ID: "user-1", Role: domain.RoleMember}},
+		{"admin token", "Bearer admin-secret", http.StatusOK, domain.Actor{ID: domain.AdminActorID, Role: domain.RoleAdmin}},
+		{"missing header", "", http.StatusUnauthorized, domain.Actor{}},
+		{"not bearer", "Basic dXNlcjpwYXNz", http.StatusUnauthorized, domain.Actor{}},
+		{"rejected token", "Bearer expired", http.StatusUnauthorized, domain.Actor{}},
+		{"api key", "ApiKey prk_good", http.StatusOK, bot},
+		{"api key scheme is case insensitive", "apikey prk_good", http.StatusOK, bot},
+		{"api key is not a bearer token", "Bearer prk_good", http.StatusUnauthorized, domain.Actor{}},
+		{"jwt is not an api key", "ApiKey good", http.StatusUnauthorized, domain.Actor{}},
+		{"empty api key", "ApiKey ", http.StatusUnauthorized, domain.Actor{}},
+		{"api key lookup failure", "ApiKey broken", http.StatusInternalServerError, domain.Actor{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotActor = domain.Actor{}
+			req := httptest.NewRequest(http.MethodGet, "/team/get", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantActor, gotActor)
+			if tt.wantStatus == http.StatusUnauthorized {
+				var resp domain.ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, domain.ErrorCodeUnauthorized, resp.Error.Code)
+				assert.NotEmpty(t, rec.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_PublicPaths(t *testing.T) {
+	handler := AuthMiddleware(stubVerifier{}, stubAPIKeys{}, "", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for _, path := range []string{"/metrics", "/healthz", "/readyz"} {
+		t.Run(path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+		})
+	}
+}
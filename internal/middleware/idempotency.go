@@ -0,0 +1,168 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+)
+
+const (
+	statusBadRequest          = 400
+	statusConflict            = 409
+	statusUnprocessableEntity = 422
+
+	// maxIdempotentBodyBytes ограничивает тело запроса с ключом идемпотентности: оно читается в память целиком
+	maxIdempotentBodyBytes = 1 << 20
+)
+
+// secretResponsePaths маршруты, успешный ответ которых содержит секрет (ключ API, секрет вебхука).
+// Таблица ключей идемпотентности не шифруется, поэтому тело такого ответа не сохраняется,
+// а повтор запроса получает 409 вместо ответа
+var secretResponsePaths = map[string]struct{}{
+	"/apiKeys/create":  {},
+	"/webhooks/create": {},
+}
+
+// IdempotencyStore хранит ответы на запросы с ключом идемпотентности
+type IdempotencyStore interface {
+	Begin(ctx context.Context, principal, key, requestHash string) (*domain.IdempotencyRecord, error)
+	Complete(ctx context.Context, principal, key string, statusCode int, body []byte) error
+	Release(ctx context.Context, principal, key string) error
+}
+
+// IdempotencyMiddleware обрабатывает POST-запросы с заголовком Idempotency-Key: первый ответ сохраняется
+// для пары инициатор + ключ, повтор с тем же методом, путём и телом получает его с заголовком Idempotent-Replayed.
+// Ответы 5xx не сохраняются, чтобы запрос можно было повторить. Успешные ответы с секретом (secretResponsePaths)
+// сохраняются без тела, повтор получает 409 с кодом IDEMPOTENCY_NOT_REPLAYED. Запросы без заголовка проходят как есть
+func IdempotencyMiddleware(store IdempotencyStore, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(domain.IdempotencyKeyHeader)
+		if r.Method != http.MethodPost || key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if len(key) > domain.MaxIdempotencyKeyLength {
+			writeError(w, statusBadRequest, domain.NewErrorResponse(domain.ErrorCodeInvalidRequest, "Idempotency-Key is too long"))
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotentBodyBytes))
+		if err != nil {
+			writeError(w, statusBadRequest, domain.NewErrorResponse(domain.ErrorCodeInvalidRequest, "request body is too large or unreadable"))
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := r.Context()
+		principal := domain.ActorFromContext(ctx).ID
+
+		record, err := store.Begin(ctx, principal, key, requestHash(r, body))
+		switch {
+		case errors.Is(err, domain.ErrIdempotencyKeyReused):
+			writeError(w, statusUnprocessableEntity, domain.NewErrorResponse(domain.ErrorCodeIdempotencyKeyReused, err.Error()))
+			return
+		case errors.Is(err, domain.ErrIdempotencyInProgress):
+			writeError(w, statusConflict, domain.NewErrorResponse(domain.ErrorCodeIdempotencyInProgress, err.Error()))
+			return
+		case err != nil:
+			logger.Logger.Errorw("idempotency key lookup failed", "path", r.URL.Path, "error", err)
+			respondInternalError(w)
+			return
+		case record != nil && hidesSecret(r, record.StatusCode):
+			writeError(w, statusConflict, domain.NewErrorResponse(domain.ErrorCodeIdempotencyNotReplayed, domain.ErrIdempotencyNotReplayed.Error()))
+			return
+		case record != nil:
+			replay(w, record)
+			return
+		}
+
+		// Ответ сохраняется и после отмены запроса клиентом, иначе ключ останется занятым до истечения срока
+		storeCtx := context.WithoutCancel(ctx)
+		rec := &recordingWriter{ResponseWriter: w}
+		finished := false
+		defer func() {
+			if finished {
+				return
+			}
+			// Обработчик упал с паникой: освобождаем ключ, чтобы запрос можно было повторить
+			if err := store.Release(storeCtx, principal, key); err != nil {
+				logger.Logger.Errorw("failed to release idempotency key", "path", r.URL.Path, "error", err)
+			}
+		}()
+
+		next.ServeHTTP(rec, r)
+		finished = true
+
+		status := rec.statusCode()
+		switch {
+		case status >= statusInternalServerError:
+			err = store.Release(storeCtx, principal, key)
+		case hidesSecret(r, status):
+			err = store.Complete(storeCtx, principal, key, status, nil)
+		default:
+			err = store.Complete(storeCtx, principal, key, status, rec.body.Bytes())
+		}
+		if err != nil {
+			logger.Logger.Errorw("failed to store idempotent response", "path", r.URL.Path, "status", status, "error", err)
+		}
+	})
+}
+
+// requestHash отпечаток запроса: тот же ключ с другим маршрутом или телом считается повторным использованием
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// hidesSecret успешный ответ маршрута содержит секрет и не сохраняется
+func hidesSecret(r *http.Request, status int) bool {
+	_, secret := secretResponsePaths[r.URL.Path]
+	return secret && status < statusBadRequest
+}
+
+func replay(w http.ResponseWriter, record *domain.IdempotencyRecord) {
+	if len(record.Body) > 0 {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	w.Header().Set(domain.IdempotencyReplayedHeader, "true")
+	w.WriteHeader(record.StatusCode)
+	_, _ = w.Write(record.Body)
+}
+
+// recordingWriter передаёт ответ клиенту и запоминает его статус и тело
+type recordingWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *recordingWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *recordingWriter) statusCode() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
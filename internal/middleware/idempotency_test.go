@@ -0,0 +1,206 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"AVITOSAMPISHU/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryIdempotencyStore повторяет правила IdempotencyServiceImpl в памяти
+type memoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]*domain.IdempotencyRecord
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{records: make(map[string]*domain.IdempotencyRecord)}
+}
+
+func (s *memoryIdempotencyStore) Begin(_ context.Context, principal, key, requestHash string) (*domain.IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.records[principal+"/"+key]
+	if !ok {
+		s.records[principal+"/"+key] = &domain.IdempotencyRecord{Principal: principal, Key: key, RequestHash: requestHash}
+		return nil, nil
+	}
+	if existing.RequestHash != requestHash {
+		return nil, domain.ErrIdempotencyKeyReused
+	}
+	if !existing.Completed() {
+		return nil, domain.ErrIdempotencyInProgress
+	}
+	return existing, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(_ context.Context, principal, key string, statusCode int, body []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record := s.records[principal+"/"+key]
+	record.StatusCode = statusCode
+	record.Body = body
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(_ context.Context, principal, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, principal+"/"+key)
+	return nil
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	calls := 0
+	status := http.StatusCreated
+	handler := IdempotencyMiddleware(newMemoryIdempotencyStore(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = fmt.Fprintf(w, `{"call":%d,"echo":%s}`, calls, body)
+	}))
+
+	send := func(actorID, key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/reassign", strings.NewReader(body))
+		req = req.WithContext(domain.ContextWithActor(req.Context(), domain.Actor{ID: actorID}))
+		if key != "" {
+			req.Header.Set(domain.IdempotencyKeyHeader, key)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	first := send("alice", "k1", `{"pr":1}`)
+	require.Equal(t, http.StatusCreated, first.Code)
+	assert.Empty(t, first.Header().Get(domain.IdempotencyReplayedHeader))
+
+	t.Run("duplicate replays first response", func(t *testing.T) {
+		rec := send("alice", "k1", `{"pr":1}`)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, first.Body.String(), rec.Body.String())
+		assert.Equal(t, "true", rec.Header().Get(domain.IdempotencyReplayedHeader))
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("different body is rejected", func(t *testing.T) {
+		rec := send("alice", "k1", `{"pr":2}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		var resp domain.ErrorResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, domain.ErrorCodeIdempotencyKeyReused, resp.Error.Code)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("keys are per principal", func(t *testing.T) {
+		rec := send("bob", "k1", `{"pr":1}`)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("without key every request runs", func(t *testing.T) {
+		send("alice", "", `{"pr":1}`)
+		send("alice", "", `{"pr":1}`)
+		assert.Equal(t, 4, calls)
+	})
+
+	t.Run("server errors are not stored", func(t *testing.T) {
+		status = http.StatusInternalServerError
+		send("alice", "k2", `{"pr":3}`)
+		status = http.StatusOK
+		rec := send("alice", "k2", `{"pr":3}`)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get(domain.IdempotencyReplayedHeader))
+		assert.Equal(t, 6, calls)
+	})
+}
+
+func TestIdempotencyMiddleware_ReleasesKeyOnPanic(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	handler := IdempotencyMiddleware(store, http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/team/add", strings.NewReader(`{}`))
+	req.Header.Set(domain.IdempotencyKeyHeader, "k1")
+
+	assert.Panics(t, func() { handler.ServeHTTP(httptest.NewRecorder(), req) })
+	assert.Empty(t, store.records)
+}
+
+func TestIdempotencyMiddleware_InProgress(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	handler := IdempotencyMiddleware(store, http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		t.Fatal("handler must not run while the first request is in progress")
+	}))
+	req := httptest.NewRequest(http.MethodPost, "/team/add", strings.NewReader(`{}`))
+	req.Header.Set(domain.IdempotencyKeyHeader, "k1")
+
+	// Первый запрос с тем же телом занял ключ, но ещё не сохранил ответ
+	store.records[domain.AnonymousActorID+"/k1"] = &domain.IdempotencyRecord{RequestHash: requestHash(req, []byte(`{}`))}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestIdempotencyMiddleware_DoesNotStoreSecrets(t *testing.T) {
+	for _, tc := range []struct {
+		path  string
+		field string
+	}{
+		{path: "/apiKeys/create", field: "key"},
+		{path: "/webhooks/create", field: "secret"},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			store := newMemoryIdempotencyStore()
+			calls := 0
+			handler := IdempotencyMiddleware(store, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusCreated)
+				_, _ = fmt.Fprintf(w, `{"id":"1","%s":"top-secret"}`, tc.field)
+			}))
+
+			send := func() *httptest.ResponseRecorder {
+				req := httptest.NewRequest(http.MethodPost, tc.path, strings.NewReader(`{"name":"ci"}`))
+				req = req.WithContext(domain.ContextWithActor(req.Context(), domain.Actor{ID: "admin"}))
+				req.Header.Set(domain.IdempotencyKeyHeader, "k1")
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, req)
+				return rec
+			}
+
+			first := send()
+			require.Equal(t, http.StatusCreated, first.Code)
+			assert.Contains(t, first.Body.String(), "top-secret")
+
+			stored := store.records["admin/k1"]
+			require.NotNil(t, stored)
+			assert.True(t, stored.Completed())
+			assert.NotContains(t, string(stored.Body), "top-secret")
+			assert.NotContains(t, string(stored.Body), `"`+tc.field+`"`)
+
+			rec := send()
+			assert.Equal(t, http.StatusConflict, rec.Code)
+			assert.NotContains(t, rec.Body.String(), "top-secret")
+			var resp domain.ErrorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, domain.ErrorCodeIdempotencyNotReplayed, resp.Error.Code)
+			assert.Equal(t, 1, calls)
+		})
+	}
+}
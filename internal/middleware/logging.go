@@ -2,15 +2,37 @@ package middleware
 
 import (
 	"net/http"
+	"strconv"
 	"time"
 
+	"AVITOSAMPISHU/internal/domain"
 	"AVITOSAMPISHU/pkg/logger"
+	"AVITOSAMPISHU/pkg/metrics"
 )
 
-func LoggingMiddleware(next http.Handler) http.Handler {
+// unmatchedRoute метка маршрута для запросов, не попавших ни в один обработчик.
+// Сырые пути в метки не пишутся, иначе сканеры раздуют кардинальность
+const unmatchedRoute = "unmatched"
+
+// RouteMatcher находит шаблон маршрута для запроса. Ему удовлетворяет *http.ServeMux
+type RouteMatcher interface {
+	Handler(r *http.Request) (h http.Handler, pattern string)
+}
+
+// LoggingMiddleware пишет access-лог и RED-метрики запросов по шаблону маршрута и статусу
+func LoggingMiddleware(routes RouteMatcher, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
 
+		route := unmatchedRoute
+		if _, pattern := routes.Handler(r); pattern != "" {
+			route = pattern
+		}
+
+		inFlight := metrics.HTTPRequestsInFlight.WithLabelValues(route)
+		inFlight.Inc()
+		defer inFlight.Dec()
+
 		// Создаем ResponseWriter для перехвата статуса
 		rw := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
 
@@ -18,9 +40,15 @@ func LoggingMiddleware(next http.Handler) http.Handler {
 
 		duration := time.Since(start)
 
-		logger.Logger.Infow("HTTP request",
+		status := strconv.Itoa(rw.statusCode)
+		metrics.HTTPRequestsTotal.WithLabelValues(r.Method, route, status).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(r.Method, route, status).Observe(duration.Seconds())
+
+		logger.WithContext(r.Context()).Infow("HTTP request",
+			"request_id", domain.RequestIDFromContext(r.Context()),
 			"method", r.Method,
 			"path", r.URL.Path,
+			"route", route,
 			"status", rw.statusCode,
 			"duration", duration,
 		)
@@ -36,3 +64,8 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.statusCode = code
 	rw.ResponseWriter.WriteHeader(code)
 }
+
+// Unwrap открывает исходный ResponseWriter для http.ResponseController: через обёртку доступны Flush и дедлайны записи
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"AVITOSAMPISHU/pkg/logger"
+	"AVITOSAMPISHU/pkg/metrics"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoggingMiddleware_Metrics(t *testing.T) {
+	logger.InitLogger()
+
+	mux := http.NewServeMux()
+	var inFlightDuringRequest float64
+	mux.HandleFunc("GET /users/getReview", func(w http.ResponseWriter, r *http.Request) {
+		inFlightDuringRequest = testutil.ToFloat64(metrics.HTTPRequestsInFlight.WithLabelValues("GET /users/getReview"))
+		w.WriteHeader(http.StatusNotFound)
+	})
+	handler := LoggingMiddleware(mux, mux)
+
+	send := func(path string) int {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec.Code
+	}
+
+	matchedBefore := testutil.ToFloat64(metrics.HTTPRequestsTotal.WithLabelValues(http.MethodGet, "GET /users/getReview", "404"))
+	unmatchedBefore := testutil.ToFloat64(metrics.HTTPRequestsTotal.WithLabelValues(http.MethodGet, unmatchedRoute, "404"))
+
+	require.Equal(t, http.StatusNotFound, send("/users/getReview?user_id=u1"))
+	require.Equal(t, http.StatusNotFound, send("/wp-admin/setup.php"))
+
+	assert.Equal(t, matchedBefore+1, testutil.ToFloat64(metrics.HTTPRequestsTotal.WithLabelValues(http.MethodGet, "GET /users/getReview", "404")))
+	assert.Equal(t, unmatchedBefore+1, testutil.ToFloat64(metrics.HTTPRequestsTotal.WithLabelValues(http.MethodGet, unmatchedRoute, "404")),
+		"unknown paths share one label instead of creating a series per path")
+	assert.Equal(t, 1.0, inFlightDuringRequest)
+	assert.Equal(t, 0.0, testutil.ToFloat64(metrics.HTTPRequestsInFlight.WithLabelValues("GET /users/getReview")))
+}
+
+func TestLoggingMiddleware_CountsUnauthorized(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /team/get", func(w http.ResponseWriter, r *http.Request) {})
+	handler := LoggingMiddleware(mux, AuthMiddleware(stubVerifier{}, stubAPIKeys{}, "", mux))
+
+	before := testutil.ToFloat64(metrics.HTTPRequestsTotal.WithLabelValues(http.MethodGet, "GET /team/get", "401"))
+
+	req := httptest.NewRequest(http.MethodGet, "/team/get?team_name=backend", nil)
+	req.Header.Set("Authorization", "Bearer expired")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, before+1, testutil.ToFloat64(metrics.HTTPRequestsTotal.WithLabelValues(http.MethodGet, "GET /team/get", "401")),
+		"requests rejected by auth are counted under their route")
+}
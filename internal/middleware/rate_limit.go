@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/metrics"
+)
+
+const (
+	statusTooManyRequests = 429
+)
+
+// RateLimiter решает, пропустить ли запрос клиента key на маршрут route
+type RateLimiter interface {
+	Route(path string) string
+	Allow(route, key string) (bool, time.Duration)
+}
+
+// RateLimitMiddleware ограничивает частоту запросов каждого клиента. Клиент — аутентифицированный инициатор
+// запроса, а для запросов без него (например, /metrics) — IP из RemoteAddr. X-Forwarded-For не учитывается:
+// его может подставить сам клиент. Превышение лимита — 429 с заголовком Retry-After и кодом RATE_LIMITED
+func RateLimitMiddleware(limiter RateLimiter, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, keyType := clientKey(r)
+		route := limiter.Route(r.URL.Path)
+
+		allowed, retryAfter := limiter.Allow(route, key)
+		if !allowed {
+			metrics.RateLimitedRequests.WithLabelValues(route, keyType).Inc()
+			respondRateLimited(w, retryAfter)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// IPRateLimitMiddleware ограничивает частоту запросов с одного IP до аутентификации. Стоит перед AuthMiddleware,
+// чтобы перебор токенов и ключей API не обходил лимит и не нагружал БД проверкой ключей.
+// Лимит общий для клиентов за одним NAT, поэтому должен быть заметно выше лимита на клиента в RateLimitMiddleware
+func IPRateLimitMiddleware(limiter RateLimiter, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := limiter.Route(r.URL.Path)
+
+		allowed, retryAfter := limiter.Allow(route, "ip:"+clientIP(r))
+		if !allowed {
+			metrics.RateLimitedRequests.WithLabelValues(route, "pre_auth_ip").Inc()
+			respondRateLimited(w, retryAfter)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// clientKey возвращает ключ бакета клиента и его тип для метрик
+func clientKey(r *http.Request) (string, string) {
+	if actor := domain.ActorFromContext(r.Context()); actor.ID != domain.AnonymousActorID {
+		return "actor:" + actor.ID, "actor"
+	}
+	return "ip:" + clientIP(r), "ip"
+}
+
+// clientIP адрес клиента из RemoteAddr без порта
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func respondRateLimited(w http.ResponseWriter, retryAfter time.Duration) {
+	// Retry-After задаётся в целых секундах, округляем вверх, чтобы повтор не пришёл раньше токена
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	writeError(w, statusTooManyRequests, domain.NewErrorResponse(domain.ErrorCodeRateLimited, "rate limit exceeded, retry later"))
+}
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/infrastructure/ratelimit"
+	"AVITOSAMPISHU/pkg/metrics"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	limiter := ratelimit.NewLimiter(ratelimit.Config{
+		Default: ratelimit.Limit{RPS: 1000, Burst: 1000},
+		Routes:  map[string]ratelimit.Limit{"/users/getReview": {RPS: 0.5, Burst: 1}},
+	})
+	handler := RateLimitMiddleware(limiter, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	send := func(actorID, remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/users/getReview", nil)
+		req.RemoteAddr = remoteAddr
+		if actorID != "" {
+			req = req.WithContext(domain.ContextWithActor(req.Context(), domain.Actor{ID: actorID, Role: domain.RoleMember}))
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	before := testutil.ToFloat64(metrics.RateLimitedRequests.WithLabelValues("/users/getReview", "actor"))
+
+	require.Equal(t, http.StatusOK, send("alice", "10.0.0.1:1234").Code)
+
+	rec := send("alice", "10.0.0.2:1234")
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get("Retry-After"))
+	var resp domain.ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, domain.ErrorCodeRateLimited, resp.Error.Code)
+	assert.Equal(t, before+1, testutil.ToFloat64(metrics.RateLimitedRequests.WithLabelValues("/users/getReview", "actor")))
+
+	// Другой пользователь с того же адреса ограничивается отдельно
+	assert.Equal(t, http.StatusOK, send("bob", "10.0.0.1:1234").Code)
+
+	// Без инициатора клиент определяется по IP
+	assert.Equal(t, http.StatusOK, send("", "10.0.0.1:1234").Code)
+	assert.Equal(t, http.StatusTooManyRequests, send("", "10.0.0.1:5678").Code)
+}
+
+func TestRespondRateLimitedRoundsUp(t *testing.T) {
+	rec := httptest.NewRecorder()
+	respondRateLimited(rec, 1200*time.Millisecond)
+	assert.Equal(t, "2", rec.Header().Get("Retry-After"))
+
+	rec = httptest.NewRecorder()
+	respondRateLimited(rec, 0)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+}
+
+func TestIPRateLimitMiddleware_LimitsBeforeAuthentication(t *testing.T) {
+	limiter := ratelimit.NewLimiter(ratelimit.Config{Default: ratelimit.Limit{RPS: 0.5, Burst: 2}})
+	authCalls := 0
+	auth := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authCalls++
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	handler := IPRateLimitMiddleware(limiter, auth)
+
+	send := func(remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/team/get", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("Authorization", "ApiKey wrong")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	before := testutil.ToFloat64(metrics.RateLimitedRequests.WithLabelValues(ratelimit.DefaultRoute, "pre_auth_ip"))
+
+	assert.Equal(t, http.StatusUnauthorized, send("10.0.0.1:1").Code)
+	assert.Equal(t, http.StatusUnauthorized, send("10.0.0.1:2").Code)
+
+	// Неверные ключи с того же адреса больше не доходят до проверки
+	rec := send("10.0.0.1:3")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, 2, authCalls)
+	assert.Equal(t, before+1, testutil.ToFloat64(metrics.RateLimitedRequests.WithLabelValues(ratelimit.DefaultRoute, "pre_auth_ip")))
+
+	// Другой адрес ограничивается отдельно
+	assert.Equal(t, http.StatusUnauthorized, send("10.0.0.2:1").Code)
+	assert.Equal(t, 3, authCalls)
+}
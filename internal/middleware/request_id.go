@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"net/http"
+
+	"AVITOSAMPISHU/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// maxRequestIDLength ограничивает длину идентификатора от клиента, чтобы он не раздувал логи и журнал аудита
+const maxRequestIDLength = 128
+
+// RequestIDMiddleware берёт идентификатор запроса из X-Request-ID или генерирует новый,
+// кладёт его в контекст и возвращает в ответе
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(domain.RequestIDHeader)
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = uuid.NewString()
+		}
+
+		w.Header().Set(domain.RequestIDHeader, requestID)
+		next.ServeHTTP(w, r.WithContext(domain.ContextWithRequestID(r.Context(), requestID)))
+	})
+}
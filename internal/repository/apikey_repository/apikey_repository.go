@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/helpers"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type APIKeyStorage struct {
+	db *sql.DB
+}
+
+func NewAPIKeyStorage(db *sql.DB) *APIKeyStorage {
+	return &APIKeyStorage{
+		db: db,
+	}
+}
+
+const apiKeyColumns = `id, name, prefix, scopes, created_by, expires_at, last_used_at, created_at, revoked_at`
+
+// rowScanner общий интерфейс *sql.Row и *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAPIKey(row rowScanner) (*domain.APIKey, error) {
+	var key domain.APIKey
+	var scopes []string
+	var expiresAt, lastUsedAt, revokedAt sql.NullTime
+	var createdAt time.Time
+
+	if err := row.Scan(
+		&key.ID, &key.Name, &key.Prefix, pq.Array(&scopes), &key.CreatedBy,
+		&expiresAt, &lastUsedAt, &createdAt, &revokedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	key.Scopes = make([]domain.APIKeyScope, 0, len(scopes))
+	for _, scope := range scopes {
+		key.Scopes = append(key.Scopes, domain.APIKeyScope(scope))
+	}
+	key.CreatedAt = &createdAt
+	if expiresAt.Valid {
+		key.ExpiresAt = &expiresAt.Time
+	}
+	if lastUsedAt.Valid {
+		key.LastUsedAt = &lastUsedAt.Time
+	}
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+	return &key, nil
+}
+
+func scopesArg(scopes []domain.APIKeyScope) interface{} {
+	values := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		values = append(values, string(scope))
+	}
+	return pq.Array(values)
+}
+
+// expiresAtArg передаёт необязательный срок действия ключа в колонку TIMESTAMP
+func expiresAtArg(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return helpers.TimestampArg(*t)
+}
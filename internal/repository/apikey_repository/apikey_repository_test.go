@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logger.InitLogger()
+}
+
+var apiKeyRowColumns = []string{
+	"id", "name", "prefix", "scopes", "created_by", "expires_at", "last_used_at", "created_at", "revoked_at",
+}
+
+func TestAPIKeyStorage_CreateAPIKey(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	expiresAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2025, 10, 24, 10, 0, 0, 0, time.UTC)
+	key := &domain.APIKey{
+		ID:        uuid.New(),
+		Name:      "ci-bot",
+		Prefix:    "prk_abcdefgh",
+		Scopes:    []domain.APIKeyScope{domain.ScopePullRequestWrite},
+		CreatedBy: domain.AdminActorID,
+		ExpiresAt: &expiresAt,
+		Key:       "prk_abcdefgh_secret",
+	}
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO api_keys`).
+		WithArgs(key.ID, "ci-bot", "hash", "prk_abcdefgh", sqlmock.AnyArg(), domain.AdminActorID, "2026-01-01 00:00:00").
+		WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(createdAt))
+	// Запись аудита пишется в той же транзакции и не содержит самого ключа
+	mock.ExpectExec(`INSERT INTO audit_events`).
+		WithArgs(sqlmock.AnyArg(), "CreateAPIKey", "api_key", key.ID.String(), sqlmock.AnyArg(), nil, nil,
+			withoutSecret{"prk_abcdefgh_secret"}, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err = NewAPIKeyStorage(db).CreateAPIKey(context.Background(), key, "hash")
+	require.NoError(t, err)
+	require.NotNil(t, key.CreatedAt)
+	assert.Equal(t, createdAt, *key.CreatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyStorage_UseAPIKey(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	id := uuid.New()
+	now := time.Date(2025, 10, 24, 10, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows(apiKeyRowColumns).
+		AddRow(id, "ci-bot", "prk_abcdefgh", "{pr:write,team:admin}", "admin", nil, now, now, nil)
+	mock.ExpectQuery(`WHERE key_hash = \$1\s+AND revoked_at IS NULL(.|\n)+UPDATE api_keys SET last_used_at = NOW\(\)(.|\n)+last_used_at < NOW\(\) - make_interval\(secs => \$2\)`).
+		WithArgs("hash", float64(60)).
+		WillReturnRows(rows)
+
+	key, err := NewAPIKeyStorage(db).UseAPIKey(context.Background(), "hash")
+	require.NoError(t, err)
+	assert.Equal(t, id, key.ID)
+	assert.Equal(t, []domain.APIKeyScope{domain.ScopePullRequestWrite, domain.ScopeTeamAdmin}, key.Scopes)
+	assert.Nil(t, key.ExpiresAt)
+	require.NotNil(t, key.LastUsedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyStorage_UseAPIKeyNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(`UPDATE api_keys SET last_used_at`).
+		WithArgs("hash", float64(60)).
+		WillReturnRows(sqlmock.NewRows(apiKeyRowColumns))
+
+	_, err = NewAPIKeyStorage(db).UseAPIKey(context.Background(), "hash")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyStorage_RevokeAPIKeyNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	id := uuid.New()
+	mock.ExpectBegin()
+	mock.ExpectQuery(`UPDATE api_keys SET revoked_at = COALESCE\(revoked_at, NOW\(\)\)`).
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows(apiKeyRowColumns))
+	mock.ExpectRollback()
+
+	_, err = NewAPIKeyStorage(db).RevokeAPIKey(context.Background(), id)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// withoutSecret совпадает со снимком аудита, в котором нет секрета
+type withoutSecret struct {
+	secret string
+}
+
+func (m withoutSecret) Match(v driver.Value) bool {
+	snapshot, ok := v.(string)
+	return ok && !strings.Contains(snapshot, m.secret)
+}
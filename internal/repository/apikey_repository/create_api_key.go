@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	audit_repository "AVITOSAMPISHU/internal/repository/audit_repository"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"time"
+)
+
+// CreateAPIKey сохраняет ключ по его хешу, проставляет ему время создания и пишет запись аудита.
+// Сам ключ в БД не попадает
+func (s *APIKeyStorage) CreateAPIKey(ctx context.Context, key *domain.APIKey, keyHash string) error {
+	operation := "CreateAPIKey"
+
+	logger.LogTransactionStart(operation)
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.LogTransactionRollback(operation, err)
+		return err
+	}
+	defer func() {
+		if err != nil {
+			logger.LogTransactionRollback(operation, err)
+			_ = tx.Rollback()
+		}
+	}()
+
+	query := `
+		INSERT INTO api_keys (id, name, key_hash, prefix, scopes, created_by, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7::timestamp)
+		RETURNING created_at`
+
+	var createdAt time.Time
+	if err = tx.QueryRowContext(ctx, query,
+		key.ID, key.Name, keyHash, key.Prefix, scopesArg(key.Scopes), key.CreatedBy, expiresAtArg(key.ExpiresAt),
+	).Scan(&createdAt); err != nil {
+		logger.LogQueryError(query, err)
+		return err
+	}
+
+	key.CreatedAt = &createdAt
+
+	after := *key
+	after.Key = ""
+	err = audit_repository.InsertEvent(ctx, tx, domain.AuditChange{
+		Operation:  operation,
+		EntityType: domain.AuditEntityAPIKey,
+		EntityID:   key.ID.String(),
+		After:      &after,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.LogTransactionRollback(operation, err)
+		return err
+	}
+
+	logger.LogTransactionCommit(operation)
+	return nil
+}
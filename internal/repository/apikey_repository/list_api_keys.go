@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+)
+
+// ListAPIKeys возвращает все ключи, включая отозванные и истёкшие
+func (s *APIKeyStorage) ListAPIKeys(ctx context.Context) ([]domain.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys ORDER BY created_at`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		logger.LogQueryError(query, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := make([]domain.APIKey, 0, 8)
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			logger.LogQueryError(query, err)
+			return nil, err
+		}
+		keys = append(keys, *key)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.LogQueryError(query, err)
+		return nil, err
+	}
+
+	return keys, nil
+}
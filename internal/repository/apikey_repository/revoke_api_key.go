@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	audit_repository "AVITOSAMPISHU/internal/repository/audit_repository"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// RevokeAPIKey отзывает ключ, пишет запись аудита и возвращает ключ. Повторный отзыв не меняет время первого
+func (s *APIKeyStorage) RevokeAPIKey(ctx context.Context, id uuid.UUID) (*domain.APIKey, error) {
+	operation := "RevokeAPIKey"
+
+	logger.LogTransactionStart(operation)
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.LogTransactionRollback(operation, err)
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			logger.LogTransactionRollback(operation, err)
+			_ = tx.Rollback()
+		}
+	}()
+
+	query := `
+		UPDATE api_keys SET revoked_at = COALESCE(revoked_at, NOW())
+		WHERE id = $1
+		RETURNING ` + apiKeyColumns
+
+	key, err := scanAPIKey(tx.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = domain.ErrNotFound
+			return nil, err
+		}
+		logger.LogQueryError(query, err)
+		return nil, err
+	}
+
+	err = audit_repository.InsertEvent(ctx, tx, domain.AuditChange{
+		Operation:  operation,
+		EntityType: domain.AuditEntityAPIKey,
+		EntityID:   key.ID.String(),
+		After:      key,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.LogTransactionRollback(operation, err)
+		return nil, err
+	}
+
+	logger.LogTransactionCommit(operation)
+	return key, nil
+}
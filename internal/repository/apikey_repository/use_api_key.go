@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// UseAPIKey находит действующий ключ по хешу и отмечает время его использования.
+// last_used_at обновляется не чаще раза в APIKeyTouchInterval: иначе каждый запрос сервиса
+// брал бы блокировку строки ключа. Неизвестный, отозванный и истёкший ключ возвращается как ErrNotFound
+func (s *APIKeyStorage) UseAPIKey(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	query := `
+		WITH active AS (
+			SELECT ` + apiKeyColumns + `
+			FROM api_keys
+			WHERE key_hash = $1
+				AND revoked_at IS NULL
+				AND (expires_at IS NULL OR expires_at > NOW())
+		), touched AS (
+			UPDATE api_keys SET last_used_at = NOW()
+			FROM active
+			WHERE api_keys.id = active.id
+				AND (api_keys.last_used_at IS NULL OR api_keys.last_used_at < NOW() - make_interval(secs => $2))
+			RETURNING api_keys.last_used_at
+		)
+		SELECT id, name, prefix, scopes, created_by, expires_at,
+			COALESCE((SELECT last_used_at FROM touched), active.last_used_at), created_at, revoked_at
+		FROM active`
+
+	key, err := scanAPIKey(s.db.QueryRowContext(ctx, query, keyHash, domain.APIKeyTouchInterval.Seconds()))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		logger.LogQueryError(query, err)
+		return nil, err
+	}
+
+	return key, nil
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+)
+
+type AuditStorage struct {
+	db *sql.DB
+}
+
+func NewAuditStorage(db *sql.DB) *AuditStorage {
+	return &AuditStorage{
+		db: db,
+	}
+}
+
+// snapshotArg передаёт снимок в JSONB: пустой снимок хранится как NULL.
+// Строка вместо []byte, потому что lib/pq кодирует []byte как bytea
+func snapshotArg(snapshot json.RawMessage) interface{} {
+	if len(snapshot) == 0 {
+		return nil
+	}
+	return string(snapshot)
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logger.InitLogger()
+}
+
+func TestInsertEvent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := domain.ContextWithActor(context.Background(), domain.Actor{ID: "admin"})
+	ctx = domain.ContextWithRequestID(ctx, "req-1")
+	ctx = domain.ContextWithAuditOperation(ctx, "MergePullRequest", &domain.MergePullRequestReq{PullRequestID: "pr-1", Force: true})
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO audit_events`).
+		WithArgs("admin", "MergePullRequest", "pull_request", "pr-1", pq.Array([]string{}),
+			`{"pull_request_id":"pr-1","force":true}`, `{"status":"OPEN"}`, `{"status":"MERGED"}`, "req-1").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	tx, err := db.Begin()
+	require.NoError(t, err)
+	require.NoError(t, InsertEvent(ctx, tx, domain.AuditChange{
+		Operation:  "Merge",
+		EntityType: domain.AuditEntityPullRequest,
+		EntityID:   "pr-1",
+		Before:     map[string]string{"status": "OPEN"},
+		After:      map[string]string{"status": "MERGED"},
+	}))
+	require.NoError(t, tx.Commit())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuditStorage_ListAuditEvents(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	from := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	cursorAt := time.Date(2025, 10, 24, 10, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{
+		"id", "actor", "operation", "entity_type", "entity_id", "target_ids", "params", "before", "after", "request_id", "created_at",
+	}).AddRow(int64(5), "admin", "ReassignReviewer", "pull_request", "pr-1", "{u1,u2}", `{"force":true}`, `{"a":1}`, nil, "", from)
+
+	mock.ExpectQuery(`WHERE entity_type = \$1 AND entity_id = \$2 AND actor = \$3 AND created_at >= \$4::timestamp AND \(created_at, id\) < \(\$5::timestamp, \$6::bigint\)`).
+		WithArgs("pull_request", "pr-1", "admin", "2025-10-01 00:00:00", "2025-10-24 10:00:00", "9", 11).
+		WillReturnRows(rows)
+
+	events, err := NewAuditStorage(db).ListAuditEvents(context.Background(), &domain.ListAuditEventsReq{
+		EntityType: domain.AuditEntityPullRequest,
+		EntityID:   "pr-1",
+		Actor:      "admin",
+		From:       &from,
+		Cursor:     &domain.PageCursor{CreatedAt: cursorAt, ID: "9"},
+	}, 11)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, []string{"u1", "u2"}, events[0].TargetIDs)
+	assert.JSONEq(t, `{"force":true}`, string(events[0].Params))
+	assert.JSONEq(t, `{"a":1}`, string(events[0].Before))
+	assert.Nil(t, events[0].After)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
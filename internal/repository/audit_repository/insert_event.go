@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/lib/pq"
+)
+
+// InsertEvent записывает изменение в журнал аудита в транзакции вызывающего репозитория: запись сохраняется
+// тогда и только тогда, когда фиксируется само изменение. Инициатор, идентификатор запроса, имя операции API
+// и её параметры берутся из контекста, без операции в контексте используется change.Operation
+func InsertEvent(ctx context.Context, tx *sql.Tx, change domain.AuditChange) error {
+	event, err := newEvent(ctx, change)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO audit_events (actor, operation, entity_type, entity_id, target_ids, params, before, after, request_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''))`
+
+	_, err = tx.ExecContext(ctx, query,
+		event.Actor,
+		event.Operation,
+		string(event.EntityType),
+		event.EntityID,
+		pq.Array(event.TargetIDs),
+		snapshotArg(event.Params),
+		snapshotArg(event.Before),
+		snapshotArg(event.After),
+		event.RequestID,
+	)
+	if err != nil {
+		logger.LogQueryError(query, err)
+		return err
+	}
+
+	return nil
+}
+
+func newEvent(ctx context.Context, change domain.AuditChange) (*domain.AuditEvent, error) {
+	event := &domain.AuditEvent{
+		Actor:      domain.ActorFromContext(ctx).ID,
+		Operation:  change.Operation,
+		EntityType: change.EntityType,
+		EntityID:   change.EntityID,
+		TargetIDs:  change.TargetIDs,
+		RequestID:  domain.RequestIDFromContext(ctx),
+	}
+	if event.TargetIDs == nil {
+		event.TargetIDs = []string{}
+	}
+
+	var err error
+	if operation, params, ok := domain.AuditOperationFromContext(ctx); ok {
+		event.Operation = operation
+		if event.Params, err = snapshot(params); err != nil {
+			return nil, err
+		}
+	}
+	if event.Before, err = snapshot(change.Before); err != nil {
+		return nil, err
+	}
+	if event.After, err = snapshot(change.After); err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// snapshot сериализует состояние сущности. Отсутствующее состояние (nil) хранится как NULL
+func snapshot(value interface{}) (json.RawMessage, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if string(raw) == "null" {
+		return nil, nil
+	}
+	return raw, nil
+}
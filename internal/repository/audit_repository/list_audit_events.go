@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/helpers"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ListAuditEvents возвращает не более limit записей журнала по фильтрам, от новых к старым.
+// Курсор из фильтра указывает на последнюю запись предыдущей страницы.
+func (s *AuditStorage) ListAuditEvents(
+	ctx context.Context,
+	filter *domain.ListAuditEventsReq,
+	limit int,
+) ([]domain.AuditEvent, error) {
+	conditions := make([]string, 0, 6)
+	args := make(helpers.QueryArgs, 0, 8)
+
+	if filter.EntityType != "" {
+		conditions = append(conditions, "entity_type = "+args.Add(string(filter.EntityType)))
+	}
+	if filter.EntityID != "" {
+		conditions = append(conditions, "entity_id = "+args.Add(filter.EntityID))
+	}
+	if filter.Actor != "" {
+		conditions = append(conditions, "actor = "+args.Add(filter.Actor))
+	}
+	if filter.From != nil {
+		conditions = append(conditions, "created_at >= "+args.Add(helpers.TimestampArg(*filter.From))+"::timestamp")
+	}
+	if filter.To != nil {
+		conditions = append(conditions, "created_at < "+args.Add(helpers.TimestampArg(*filter.To))+"::timestamp")
+	}
+	if filter.Cursor != nil {
+		createdAt := args.Add(helpers.TimestampArg(filter.Cursor.CreatedAt))
+		id := args.Add(filter.Cursor.ID)
+		conditions = append(conditions, fmt.Sprintf("(created_at, id) < (%s::timestamp, %s::bigint)", createdAt, id))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, actor, operation, entity_type, entity_id, target_ids, params, before, after, COALESCE(request_id, ''), created_at
+		FROM audit_events
+		%s
+		ORDER BY created_at DESC, id DESC
+		LIMIT %s`, where, args.Add(limit))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.LogQueryError(query, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]domain.AuditEvent, 0, limit)
+	for rows.Next() {
+		var event domain.AuditEvent
+		var entityType string
+		var params, before, after sql.NullString
+		var createdAt time.Time
+
+		if err = rows.Scan(
+			&event.ID, &event.Actor, &event.Operation, &entityType, &event.EntityID,
+			pq.Array(&event.TargetIDs), &params, &before, &after, &event.RequestID, &createdAt,
+		); err != nil {
+			logger.LogQueryError(query, err)
+			return nil, err
+		}
+
+		event.EntityType = domain.AuditEntityType(entityType)
+		if params.Valid {
+			event.Params = []byte(params.String)
+		}
+		if before.Valid {
+			event.Before = []byte(before.String)
+		}
+		if after.Valid {
+			event.After = []byte(after.String)
+		}
+		event.CreatedAt = &createdAt
+
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.LogQueryError(query, err)
+		return nil, err
+	}
+
+	return events, nil
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// ClaimKey занимает ключ под новый запрос. Истёкшая запись перезаписывается, как будто ключа не было.
+// Возвращает false, если ключ уже занят действующей записью
+func (s *IdempotencyStorage) ClaimKey(ctx context.Context, record *domain.IdempotencyRecord, ttl time.Duration) (bool, error) {
+	query := `
+		INSERT INTO idempotency_keys (principal, idempotency_key, request_hash, expires_at)
+		VALUES ($1, $2, $3, NOW() + make_interval(secs => $4))
+		ON CONFLICT (principal, idempotency_key) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash,
+			status_code = NULL,
+			response_body = NULL,
+			created_at = NOW(),
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= NOW()
+		RETURNING created_at, expires_at`
+
+	var createdAt, expiresAt time.Time
+	err := s.db.QueryRowContext(ctx, query, record.Principal, record.Key, record.RequestHash, ttl.Seconds()).
+		Scan(&createdAt, &expiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		logger.LogQueryError(query, err)
+		return false, err
+	}
+
+	record.CreatedAt = &createdAt
+	record.ExpiresAt = &expiresAt
+	return true, nil
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+)
+
+// CompleteKey сохраняет ответ на запрос, занявший ключ
+func (s *IdempotencyStorage) CompleteKey(ctx context.Context, principal, key string, statusCode int, body []byte) error {
+	query := `
+		UPDATE idempotency_keys SET status_code = $3, response_body = $4
+		WHERE principal = $1 AND idempotency_key = $2`
+
+	if _, err := s.db.ExecContext(ctx, query, principal, key, statusCode, body); err != nil {
+		logger.LogQueryError(query, err)
+		return err
+	}
+	return nil
+}
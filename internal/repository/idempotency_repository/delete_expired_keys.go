@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+)
+
+// DeleteExpiredKeys удаляет истёкшие записи и возвращает их количество
+func (s *IdempotencyStorage) DeleteExpiredKeys(ctx context.Context) (int64, error) {
+	query := `DELETE FROM idempotency_keys WHERE expires_at <= NOW()`
+
+	res, err := s.db.ExecContext(ctx, query)
+	if err != nil {
+		logger.LogQueryError(query, err)
+		return 0, err
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		logger.LogQueryError(query, err)
+		return 0, err
+	}
+	return deleted, nil
+}
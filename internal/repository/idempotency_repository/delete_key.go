@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+)
+
+// DeleteKey освобождает ключ, например после ответа 5xx, чтобы запрос можно было повторить
+func (s *IdempotencyStorage) DeleteKey(ctx context.Context, principal, key string) error {
+	query := `DELETE FROM idempotency_keys WHERE principal = $1 AND idempotency_key = $2`
+
+	if _, err := s.db.ExecContext(ctx, query, principal, key); err != nil {
+		logger.LogQueryError(query, err)
+		return err
+	}
+	return nil
+}
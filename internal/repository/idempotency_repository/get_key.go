@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+func (s *IdempotencyStorage) GetKey(ctx context.Context, principal, key string) (*domain.IdempotencyRecord, error) {
+	query := `
+		SELECT request_hash, status_code, response_body, created_at, expires_at
+		FROM idempotency_keys
+		WHERE principal = $1 AND idempotency_key = $2`
+
+	record := &domain.IdempotencyRecord{Principal: principal, Key: key}
+	var statusCode sql.NullInt64
+	var createdAt, expiresAt time.Time
+	err := s.db.QueryRowContext(ctx, query, principal, key).
+		Scan(&record.RequestHash, &statusCode, &record.Body, &createdAt, &expiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		logger.LogQueryError(query, err)
+		return nil, err
+	}
+
+	record.StatusCode = int(statusCode.Int64)
+	record.CreatedAt = &createdAt
+	record.ExpiresAt = &expiresAt
+	return record, nil
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"database/sql"
+)
+
+type IdempotencyStorage struct {
+	db *sql.DB
+}
+
+func NewIdempotencyStorage(db *sql.DB) *IdempotencyStorage {
+	return &IdempotencyStorage{
+		db: db,
+	}
+}
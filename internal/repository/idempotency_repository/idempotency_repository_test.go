@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logger.InitLogger()
+}
+
+func TestIdempotencyStorage_ClaimKey(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	now := time.Date(2025, 10, 24, 10, 0, 0, 0, time.UTC)
+	record := &domain.IdempotencyRecord{Principal: "alice", Key: "k1", RequestHash: "hash"}
+
+	mock.ExpectQuery(`INSERT INTO idempotency_keys .+ON CONFLICT \(principal, idempotency_key\) DO UPDATE.+WHERE idempotency_keys.expires_at <= NOW\(\)`).
+		WithArgs("alice", "k1", "hash", float64(3600)).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "expires_at"}).AddRow(now, now.Add(time.Hour)))
+
+	claimed, err := NewIdempotencyStorage(db).ClaimKey(context.Background(), record, time.Hour)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+	require.NotNil(t, record.ExpiresAt)
+	assert.Equal(t, now.Add(time.Hour), *record.ExpiresAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIdempotencyStorage_ClaimKeyTaken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(`INSERT INTO idempotency_keys`).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "expires_at"}))
+
+	claimed, err := NewIdempotencyStorage(db).ClaimKey(context.Background(), &domain.IdempotencyRecord{Principal: "alice", Key: "k1"}, time.Hour)
+	require.NoError(t, err)
+	assert.False(t, claimed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIdempotencyStorage_GetKeyPending(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	now := time.Date(2025, 10, 24, 10, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`FROM idempotency_keys`).
+		WithArgs("alice", "k1").
+		WillReturnRows(sqlmock.NewRows([]string{"request_hash", "status_code", "response_body", "created_at", "expires_at"}).
+			AddRow("hash", nil, nil, now, now.Add(time.Hour)))
+
+	record, err := NewIdempotencyStorage(db).GetKey(context.Background(), "alice", "k1")
+	require.NoError(t, err)
+	assert.False(t, record.Completed())
+	assert.Equal(t, "hash", record.RequestHash)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -3,24 +3,31 @@ package repository
 import (
 	"AVITOSAMPISHU/internal/domain"
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type TeamRepositoryInterface interface {
 	GetTeamByName(ctx context.Context, teamName string) (*domain.Team, error)
-	CreateTeamWithMembers(ctx context.Context, teamName string, members []domain.TeamMember) (uuid.UUID, error)
+	CreateTeamWithMembers(ctx context.Context, teamName string, members []domain.TeamMember, settings domain.TeamSettings) (uuid.UUID, error)
 	DeactivateTeamMembers(ctx context.Context, teamName string, userIDs []string, reassignments []domain.ReviewerReassignment) ([]string, error)
+	GetTeamSettings(ctx context.Context, teamName string) (*domain.TeamSettings, error)
+	UpdateTeamSettings(ctx context.Context, teamName string, settings *domain.TeamSettings) error
 }
 
 type UserRepositoryInterface interface {
 	GetUserByID(ctx context.Context, userID string) (*domain.User, error)
 	SetUserIsActive(ctx context.Context, userID string, isActive bool) error
+	GetActiveUsersOutsideTeam(ctx context.Context, teamName string) ([]domain.TeamMember, error)
 }
 
 type PullRequestRepositoryInterface interface {
 	GetPullRequestByID(ctx context.Context, prID string) (*domain.PullRequest, error)
-	MergePullRequest(ctx context.Context, prID string) error
+	MergePullRequest(ctx context.Context, pr *domain.PullRequest, check func(locked *domain.PullRequest) error) error
+	ClosePullRequest(ctx context.Context, prID string) ([]string, error)
+	OpenPullRequest(ctx context.Context, prID string, from domain.PRStatus, reviewerIDs []string, needMoreReviewers bool) error
+	ListPullRequests(ctx context.Context, filter *domain.ListPullRequestsReq, limit int) ([]domain.PullRequest, error)
 	SetNeedMoreReviewers(ctx context.Context, prID string, needMore bool) error
 	CreatePullRequestWithReviewers(ctx context.Context, pr *domain.PullRequest, reviewerIDs []string, needMoreReviewers bool) error
 }
@@ -28,5 +35,57 @@ type PullRequestRepositoryInterface interface {
 type PrReviewersRepositoryInterface interface {
 	GetAssignedReviewers(ctx context.Context, prID string) ([]string, error)
 	GetPRsByReviewer(ctx context.Context, userID string) ([]domain.PullRequestShort, error)
+	ListPRsByReviewer(ctx context.Context, req *domain.GetUserReviewsReq, limit int) ([]domain.PullRequestShort, error)
 	ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string) error
+	GetOpenReviewsCount(ctx context.Context, userIDs []string) (map[string]int, error)
+	GetReviews(ctx context.Context, prID string) ([]domain.ReviewerState, error)
+	SubmitReview(ctx context.Context, prID, reviewerID string, verdict domain.ReviewVerdict, comment string) error
+	GetReviewerHistory(ctx context.Context, prID string) ([]domain.ReviewerAssignment, error)
+}
+
+type StatsRepositoryInterface interface {
+	GetLoadSnapshot(ctx context.Context) ([]domain.TeamLoad, error)
+	GetTeamStats(ctx context.Context, teamName string, from, to time.Time) (*domain.TeamStats, error)
+	GetUserStats(ctx context.Context, userID string, from, to time.Time) (*domain.UserStats, error)
+	ExportReviews(ctx context.Context, filter *domain.ExportReviewsReq, handle func(row *domain.ReviewExportRow) error) error
+}
+
+type WebhookRepositoryInterface interface {
+	CreateSubscription(ctx context.Context, sub *domain.WebhookSubscription) error
+	GetSubscription(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context, eventType domain.EventType) ([]domain.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+	CreateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+	CreateDeliveries(ctx context.Context, deliveries []*domain.WebhookDelivery) ([]*domain.WebhookDelivery, error)
+	ClaimDueDeliveries(ctx context.Context, overdue time.Duration, limit int) ([]domain.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+	GetDelivery(ctx context.Context, id uuid.UUID) (*domain.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]domain.WebhookDelivery, error)
+}
+
+type OutboxRepositoryInterface interface {
+	ClaimEvents(ctx context.Context, limit int, claimTimeout time.Duration) ([]domain.OutboxEvent, error)
+	MarkEventProcessed(ctx context.Context, seq int64) error
+	RecordEventFailure(ctx context.Context, seq int64, lastError string, maxAttempts int, retryAfter time.Duration) (bool, error)
+	ReleaseEvents(ctx context.Context, seqs []int64) error
+	DeleteProcessedEvents(ctx context.Context, retention time.Duration) (int64, error)
+}
+
+type AuditRepositoryInterface interface {
+	ListAuditEvents(ctx context.Context, filter *domain.ListAuditEventsReq, limit int) ([]domain.AuditEvent, error)
+}
+
+type APIKeyRepositoryInterface interface {
+	CreateAPIKey(ctx context.Context, key *domain.APIKey, keyHash string) error
+	ListAPIKeys(ctx context.Context) ([]domain.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id uuid.UUID) (*domain.APIKey, error)
+	UseAPIKey(ctx context.Context, keyHash string) (*domain.APIKey, error)
+}
+
+type IdempotencyRepositoryInterface interface {
+	ClaimKey(ctx context.Context, record *domain.IdempotencyRecord, ttl time.Duration) (bool, error)
+	GetKey(ctx context.Context, principal, key string) (*domain.IdempotencyRecord, error)
+	CompleteKey(ctx context.Context, principal, key string, statusCode int, body []byte) error
+	DeleteKey(ctx context.Context, principal, key string) error
+	DeleteExpiredKeys(ctx context.Context) (int64, error)
 }
@@ -0,0 +1,46 @@
+package mocks
+
+import (
+	"context"
+
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type MockAPIKeyRepository struct {
+	repository.APIKeyRepositoryInterface
+	CreateAPIKeyFunc func(ctx context.Context, key *domain.APIKey, keyHash string) error
+	ListAPIKeysFunc  func(ctx context.Context) ([]domain.APIKey, error)
+	RevokeAPIKeyFunc func(ctx context.Context, id uuid.UUID) (*domain.APIKey, error)
+	UseAPIKeyFunc    func(ctx context.Context, keyHash string) (*domain.APIKey, error)
+}
+
+func (m *MockAPIKeyRepository) CreateAPIKey(ctx context.Context, key *domain.APIKey, keyHash string) error {
+	if m.CreateAPIKeyFunc != nil {
+		return m.CreateAPIKeyFunc(ctx, key, keyHash)
+	}
+	return nil
+}
+
+func (m *MockAPIKeyRepository) ListAPIKeys(ctx context.Context) ([]domain.APIKey, error) {
+	if m.ListAPIKeysFunc != nil {
+		return m.ListAPIKeysFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockAPIKeyRepository) RevokeAPIKey(ctx context.Context, id uuid.UUID) (*domain.APIKey, error) {
+	if m.RevokeAPIKeyFunc != nil {
+		return m.RevokeAPIKeyFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockAPIKeyRepository) UseAPIKey(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	if m.UseAPIKeyFunc != nil {
+		return m.UseAPIKeyFunc(ctx, keyHash)
+	}
+	return nil, nil
+}
@@ -0,0 +1,20 @@
+package mocks
+
+import (
+	"context"
+
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/repository"
+)
+
+type MockAuditRepository struct {
+	repository.AuditRepositoryInterface
+	ListAuditEventsFunc func(ctx context.Context, filter *domain.ListAuditEventsReq, limit int) ([]domain.AuditEvent, error)
+}
+
+func (m *MockAuditRepository) ListAuditEvents(ctx context.Context, filter *domain.ListAuditEventsReq, limit int) ([]domain.AuditEvent, error) {
+	if m.ListAuditEventsFunc != nil {
+		return m.ListAuditEventsFunc(ctx, filter, limit)
+	}
+	return nil, nil
+}
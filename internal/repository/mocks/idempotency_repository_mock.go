@@ -0,0 +1,53 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/repository"
+)
+
+type MockIdempotencyRepository struct {
+	repository.IdempotencyRepositoryInterface
+	ClaimKeyFunc          func(ctx context.Context, record *domain.IdempotencyRecord, ttl time.Duration) (bool, error)
+	GetKeyFunc            func(ctx context.Context, principal, key string) (*domain.IdempotencyRecord, error)
+	CompleteKeyFunc       func(ctx context.Context, principal, key string, statusCode int, body []byte) error
+	DeleteKeyFunc         func(ctx context.Context, principal, key string) error
+	DeleteExpiredKeysFunc func(ctx context.Context) (int64, error)
+}
+
+func (m *MockIdempotencyRepository) ClaimKey(ctx context.Context, record *domain.IdempotencyRecord, ttl time.Duration) (bool, error) {
+	if m.ClaimKeyFunc != nil {
+		return m.ClaimKeyFunc(ctx, record, ttl)
+	}
+	return true, nil
+}
+
+func (m *MockIdempotencyRepository) GetKey(ctx context.Context, principal, key string) (*domain.IdempotencyRecord, error) {
+	if m.GetKeyFunc != nil {
+		return m.GetKeyFunc(ctx, principal, key)
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (m *MockIdempotencyRepository) CompleteKey(ctx context.Context, principal, key string, statusCode int, body []byte) error {
+	if m.CompleteKeyFunc != nil {
+		return m.CompleteKeyFunc(ctx, principal, key, statusCode, body)
+	}
+	return nil
+}
+
+func (m *MockIdempotencyRepository) DeleteKey(ctx context.Context, principal, key string) error {
+	if m.DeleteKeyFunc != nil {
+		return m.DeleteKeyFunc(ctx, principal, key)
+	}
+	return nil
+}
+
+func (m *MockIdempotencyRepository) DeleteExpiredKeys(ctx context.Context) (int64, error) {
+	if m.DeleteExpiredKeysFunc != nil {
+		return m.DeleteExpiredKeysFunc(ctx)
+	}
+	return 0, nil
+}
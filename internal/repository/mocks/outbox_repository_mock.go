@@ -0,0 +1,59 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/repository"
+)
+
+type MockOutboxRepository struct {
+	repository.OutboxRepositoryInterface
+	ClaimEventsFunc           func(ctx context.Context, limit int, claimTimeout time.Duration) ([]domain.OutboxEvent, error)
+	MarkEventProcessedFunc    func(ctx context.Context, seq int64) error
+	RecordEventFailureFunc    func(ctx context.Context, seq int64, lastError string, maxAttempts int, retryAfter time.Duration) (bool, error)
+	ReleaseEventsFunc         func(ctx context.Context, seqs []int64) error
+	DeleteProcessedEventsFunc func(ctx context.Context, retention time.Duration) (int64, error)
+}
+
+func (m *MockOutboxRepository) ClaimEvents(ctx context.Context, limit int, claimTimeout time.Duration) ([]domain.OutboxEvent, error) {
+	if m.ClaimEventsFunc != nil {
+		return m.ClaimEventsFunc(ctx, limit, claimTimeout)
+	}
+	return nil, nil
+}
+
+func (m *MockOutboxRepository) MarkEventProcessed(ctx context.Context, seq int64) error {
+	if m.MarkEventProcessedFunc != nil {
+		return m.MarkEventProcessedFunc(ctx, seq)
+	}
+	return nil
+}
+
+func (m *MockOutboxRepository) RecordEventFailure(
+	ctx context.Context,
+	seq int64,
+	lastError string,
+	maxAttempts int,
+	retryAfter time.Duration,
+) (bool, error) {
+	if m.RecordEventFailureFunc != nil {
+		return m.RecordEventFailureFunc(ctx, seq, lastError, maxAttempts, retryAfter)
+	}
+	return false, nil
+}
+
+func (m *MockOutboxRepository) ReleaseEvents(ctx context.Context, seqs []int64) error {
+	if m.ReleaseEventsFunc != nil {
+		return m.ReleaseEventsFunc(ctx, seqs)
+	}
+	return nil
+}
+
+func (m *MockOutboxRepository) DeleteProcessedEvents(ctx context.Context, retention time.Duration) (int64, error) {
+	if m.DeleteProcessedEventsFunc != nil {
+		return m.DeleteProcessedEventsFunc(ctx, retention)
+	}
+	return 0, nil
+}
@@ -11,8 +11,11 @@ type MockPullRequestRepository struct {
 	repository.PullRequestRepositoryInterface
 	GetPullRequestByIDFunc             func(ctx context.Context, prID string) (*domain.PullRequest, error)
 	CreatePullRequestWithReviewersFunc func(ctx context.Context, pr *domain.PullRequest, reviewerIDs []string, needMoreReviewers bool) error
-	MergePullRequestFunc               func(ctx context.Context, prID string) error
+	MergePullRequestFunc               func(ctx context.Context, pr *domain.PullRequest, check func(locked *domain.PullRequest) error) error
 	SetNeedMoreReviewersFunc           func(ctx context.Context, prID string, needMore bool) error
+	ClosePullRequestFunc               func(ctx context.Context, prID string) ([]string, error)
+	OpenPullRequestFunc                func(ctx context.Context, prID string, from domain.PRStatus, reviewerIDs []string, needMoreReviewers bool) error
+	ListPullRequestsFunc               func(ctx context.Context, filter *domain.ListPullRequestsReq, limit int) ([]domain.PullRequest, error)
 }
 
 func (m *MockPullRequestRepository) GetPullRequestByID(ctx context.Context, prID string) (*domain.PullRequest, error) {
@@ -29,9 +32,13 @@ func (m *MockPullRequestRepository) CreatePullRequestWithReviewers(ctx context.C
 	return nil
 }
 
-func (m *MockPullRequestRepository) MergePullRequest(ctx context.Context, prID string) error {
+func (m *MockPullRequestRepository) MergePullRequest(
+	ctx context.Context,
+	pr *domain.PullRequest,
+	check func(locked *domain.PullRequest) error,
+) error {
 	if m.MergePullRequestFunc != nil {
-		return m.MergePullRequestFunc(ctx, prID)
+		return m.MergePullRequestFunc(ctx, pr, check)
 	}
 	return nil
 }
@@ -42,3 +49,24 @@ func (m *MockPullRequestRepository) SetNeedMoreReviewers(ctx context.Context, pr
 	}
 	return nil
 }
+
+func (m *MockPullRequestRepository) ClosePullRequest(ctx context.Context, prID string) ([]string, error) {
+	if m.ClosePullRequestFunc != nil {
+		return m.ClosePullRequestFunc(ctx, prID)
+	}
+	return nil, nil
+}
+
+func (m *MockPullRequestRepository) OpenPullRequest(ctx context.Context, prID string, from domain.PRStatus, reviewerIDs []string, needMoreReviewers bool) error {
+	if m.OpenPullRequestFunc != nil {
+		return m.OpenPullRequestFunc(ctx, prID, from, reviewerIDs, needMoreReviewers)
+	}
+	return nil
+}
+
+func (m *MockPullRequestRepository) ListPullRequests(ctx context.Context, filter *domain.ListPullRequestsReq, limit int) ([]domain.PullRequest, error) {
+	if m.ListPullRequestsFunc != nil {
+		return m.ListPullRequestsFunc(ctx, filter, limit)
+	}
+	return nil, nil
+}
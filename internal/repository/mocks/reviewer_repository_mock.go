@@ -11,7 +11,12 @@ type MockPrReviewersRepository struct {
 	repository.PrReviewersRepositoryInterface
 	GetAssignedReviewersFunc func(ctx context.Context, prID string) ([]string, error)
 	GetPRsByReviewerFunc     func(ctx context.Context, userID string) ([]domain.PullRequestShort, error)
+	ListPRsByReviewerFunc    func(ctx context.Context, req *domain.GetUserReviewsReq, limit int) ([]domain.PullRequestShort, error)
 	ReassignReviewerFunc     func(ctx context.Context, prID, oldReviewerID, newReviewerID string) error
+	GetOpenReviewsCountFunc  func(ctx context.Context, userIDs []string) (map[string]int, error)
+	GetReviewsFunc           func(ctx context.Context, prID string) ([]domain.ReviewerState, error)
+	SubmitReviewFunc         func(ctx context.Context, prID, reviewerID string, verdict domain.ReviewVerdict, comment string) error
+	GetReviewerHistoryFunc   func(ctx context.Context, prID string) ([]domain.ReviewerAssignment, error)
 }
 
 func (m *MockPrReviewersRepository) GetAssignedReviewers(ctx context.Context, prID string) ([]string, error) {
@@ -28,9 +33,44 @@ func (m *MockPrReviewersRepository) GetPRsByReviewer(ctx context.Context, userID
 	return nil, nil
 }
 
+func (m *MockPrReviewersRepository) ListPRsByReviewer(ctx context.Context, req *domain.GetUserReviewsReq, limit int) ([]domain.PullRequestShort, error) {
+	if m.ListPRsByReviewerFunc != nil {
+		return m.ListPRsByReviewerFunc(ctx, req, limit)
+	}
+	return nil, nil
+}
+
 func (m *MockPrReviewersRepository) ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string) error {
 	if m.ReassignReviewerFunc != nil {
 		return m.ReassignReviewerFunc(ctx, prID, oldReviewerID, newReviewerID)
 	}
 	return nil
 }
+
+func (m *MockPrReviewersRepository) GetOpenReviewsCount(ctx context.Context, userIDs []string) (map[string]int, error) {
+	if m.GetOpenReviewsCountFunc != nil {
+		return m.GetOpenReviewsCountFunc(ctx, userIDs)
+	}
+	return nil, nil
+}
+
+func (m *MockPrReviewersRepository) GetReviews(ctx context.Context, prID string) ([]domain.ReviewerState, error) {
+	if m.GetReviewsFunc != nil {
+		return m.GetReviewsFunc(ctx, prID)
+	}
+	return nil, nil
+}
+
+func (m *MockPrReviewersRepository) SubmitReview(ctx context.Context, prID, reviewerID string, verdict domain.ReviewVerdict, comment string) error {
+	if m.SubmitReviewFunc != nil {
+		return m.SubmitReviewFunc(ctx, prID, reviewerID, verdict, comment)
+	}
+	return nil
+}
+
+func (m *MockPrReviewersRepository) GetReviewerHistory(ctx context.Context, prID string) ([]domain.ReviewerAssignment, error) {
+	if m.GetReviewerHistoryFunc != nil {
+		return m.GetReviewerHistoryFunc(ctx, prID)
+	}
+	return nil, nil
+}
@@ -0,0 +1,49 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/repository"
+)
+
+type MockStatsRepository struct {
+	repository.StatsRepositoryInterface
+	GetLoadSnapshotFunc func(ctx context.Context) ([]domain.TeamLoad, error)
+	GetTeamStatsFunc    func(ctx context.Context, teamName string, from, to time.Time) (*domain.TeamStats, error)
+	GetUserStatsFunc    func(ctx context.Context, userID string, from, to time.Time) (*domain.UserStats, error)
+	ExportReviewsFunc   func(ctx context.Context, filter *domain.ExportReviewsReq, handle func(row *domain.ReviewExportRow) error) error
+}
+
+func (m *MockStatsRepository) GetLoadSnapshot(ctx context.Context) ([]domain.TeamLoad, error) {
+	if m.GetLoadSnapshotFunc != nil {
+		return m.GetLoadSnapshotFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockStatsRepository) GetTeamStats(ctx context.Context, teamName string, from, to time.Time) (*domain.TeamStats, error) {
+	if m.GetTeamStatsFunc != nil {
+		return m.GetTeamStatsFunc(ctx, teamName, from, to)
+	}
+	return nil, nil
+}
+
+func (m *MockStatsRepository) GetUserStats(ctx context.Context, userID string, from, to time.Time) (*domain.UserStats, error) {
+	if m.GetUserStatsFunc != nil {
+		return m.GetUserStatsFunc(ctx, userID, from, to)
+	}
+	return nil, nil
+}
+
+func (m *MockStatsRepository) ExportReviews(
+	ctx context.Context,
+	filter *domain.ExportReviewsReq,
+	handle func(row *domain.ReviewExportRow) error,
+) error {
+	if m.ExportReviewsFunc != nil {
+		return m.ExportReviewsFunc(ctx, filter, handle)
+	}
+	return nil
+}
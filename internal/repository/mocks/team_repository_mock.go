@@ -12,8 +12,10 @@ import (
 type MockTeamRepository struct {
 	repository.TeamRepositoryInterface
 	GetTeamByNameFunc         func(ctx context.Context, teamName string) (*domain.Team, error)
-	CreateTeamWithMembersFunc func(ctx context.Context, teamName string, members []domain.TeamMember) (uuid.UUID, error)
+	CreateTeamWithMembersFunc func(ctx context.Context, teamName string, members []domain.TeamMember, settings domain.TeamSettings) (uuid.UUID, error)
 	DeactivateTeamMembersFunc func(ctx context.Context, teamName string, userIDs []string, reassignments []domain.ReviewerReassignment) ([]string, error)
+	GetTeamSettingsFunc       func(ctx context.Context, teamName string) (*domain.TeamSettings, error)
+	UpdateTeamSettingsFunc    func(ctx context.Context, teamName string, settings *domain.TeamSettings) error
 }
 
 func (m *MockTeamRepository) GetTeamByName(ctx context.Context, teamName string) (*domain.Team, error) {
@@ -23,9 +25,9 @@ func (m *MockTeamRepository) GetTeamByName(ctx context.Context, teamName string)
 	return nil, nil
 }
 
-func (m *MockTeamRepository) CreateTeamWithMembers(ctx context.Context, teamName string, members []domain.TeamMember) (uuid.UUID, error) {
+func (m *MockTeamRepository) CreateTeamWithMembers(ctx context.Context, teamName string, members []domain.TeamMember, settings domain.TeamSettings) (uuid.UUID, error) {
 	if m.CreateTeamWithMembersFunc != nil {
-		return m.CreateTeamWithMembersFunc(ctx, teamName, members)
+		return m.CreateTeamWithMembersFunc(ctx, teamName, members, settings)
 	}
 	return uuid.Nil, nil
 }
@@ -36,3 +38,17 @@ func (m *MockTeamRepository) DeactivateTeamMembers(ctx context.Context, teamName
 	}
 	return nil, nil
 }
+
+func (m *MockTeamRepository) GetTeamSettings(ctx context.Context, teamName string) (*domain.TeamSettings, error) {
+	if m.GetTeamSettingsFunc != nil {
+		return m.GetTeamSettingsFunc(ctx, teamName)
+	}
+	return nil, nil
+}
+
+func (m *MockTeamRepository) UpdateTeamSettings(ctx context.Context, teamName string, settings *domain.TeamSettings) error {
+	if m.UpdateTeamSettingsFunc != nil {
+		return m.UpdateTeamSettingsFunc(ctx, teamName, settings)
+	}
+	return nil
+}
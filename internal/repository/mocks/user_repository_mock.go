@@ -9,8 +9,9 @@ import (
 
 type MockUserRepository struct {
 	repository.UserRepositoryInterface
-	GetUserByIDFunc     func(ctx context.Context, userID string) (*domain.User, error)
-	SetUserIsActiveFunc func(ctx context.Context, userID string, isActive bool) error
+	GetUserByIDFunc               func(ctx context.Context, userID string) (*domain.User, error)
+	SetUserIsActiveFunc           func(ctx context.Context, userID string, isActive bool) error
+	GetActiveUsersOutsideTeamFunc func(ctx context.Context, teamName string) ([]domain.TeamMember, error)
 }
 
 func (m *MockUserRepository) GetUserByID(ctx context.Context, userID string) (*domain.User, error) {
@@ -26,3 +27,10 @@ func (m *MockUserRepository) SetUserIsActive(ctx context.Context, userID string,
 	}
 	return nil
 }
+
+func (m *MockUserRepository) GetActiveUsersOutsideTeam(ctx context.Context, teamName string) ([]domain.TeamMember, error) {
+	if m.GetActiveUsersOutsideTeamFunc != nil {
+		return m.GetActiveUsersOutsideTeamFunc(ctx, teamName)
+	}
+	return nil, nil
+}
@@ -0,0 +1,98 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type MockWebhookRepository struct {
+	repository.WebhookRepositoryInterface
+	CreateSubscriptionFunc func(ctx context.Context, sub *domain.WebhookSubscription) error
+	GetSubscriptionFunc    func(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error)
+	ListSubscriptionsFunc  func(ctx context.Context, eventType domain.EventType) ([]domain.WebhookSubscription, error)
+	DeleteSubscriptionFunc func(ctx context.Context, id uuid.UUID) error
+	CreateDeliveryFunc     func(ctx context.Context, delivery *domain.WebhookDelivery) error
+	CreateDeliveriesFunc   func(ctx context.Context, deliveries []*domain.WebhookDelivery) ([]*domain.WebhookDelivery, error)
+	ClaimDueDeliveriesFunc func(ctx context.Context, overdue time.Duration, limit int) ([]domain.WebhookDelivery, error)
+	UpdateDeliveryFunc     func(ctx context.Context, delivery *domain.WebhookDelivery) error
+	GetDeliveryFunc        func(ctx context.Context, id uuid.UUID) (*domain.WebhookDelivery, error)
+	ListDeliveriesFunc     func(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]domain.WebhookDelivery, error)
+}
+
+func (m *MockWebhookRepository) CreateSubscription(ctx context.Context, sub *domain.WebhookSubscription) error {
+	if m.CreateSubscriptionFunc != nil {
+		return m.CreateSubscriptionFunc(ctx, sub)
+	}
+	return nil
+}
+
+func (m *MockWebhookRepository) GetSubscription(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	if m.GetSubscriptionFunc != nil {
+		return m.GetSubscriptionFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockWebhookRepository) ListSubscriptions(ctx context.Context, eventType domain.EventType) ([]domain.WebhookSubscription, error) {
+	if m.ListSubscriptionsFunc != nil {
+		return m.ListSubscriptionsFunc(ctx, eventType)
+	}
+	return nil, nil
+}
+
+func (m *MockWebhookRepository) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	if m.DeleteSubscriptionFunc != nil {
+		return m.DeleteSubscriptionFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockWebhookRepository) CreateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	if m.CreateDeliveryFunc != nil {
+		return m.CreateDeliveryFunc(ctx, delivery)
+	}
+	return nil
+}
+
+func (m *MockWebhookRepository) UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	if m.UpdateDeliveryFunc != nil {
+		return m.UpdateDeliveryFunc(ctx, delivery)
+	}
+	return nil
+}
+
+func (m *MockWebhookRepository) GetDelivery(ctx context.Context, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	if m.GetDeliveryFunc != nil {
+		return m.GetDeliveryFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockWebhookRepository) ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]domain.WebhookDelivery, error) {
+	if m.ListDeliveriesFunc != nil {
+		return m.ListDeliveriesFunc(ctx, subscriptionID, limit)
+	}
+	return nil, nil
+}
+
+func (m *MockWebhookRepository) CreateDeliveries(
+	ctx context.Context,
+	deliveries []*domain.WebhookDelivery,
+) ([]*domain.WebhookDelivery, error) {
+	if m.CreateDeliveriesFunc != nil {
+		return m.CreateDeliveriesFunc(ctx, deliveries)
+	}
+	return deliveries, nil
+}
+
+func (m *MockWebhookRepository) ClaimDueDeliveries(ctx context.Context, overdue time.Duration, limit int) ([]domain.WebhookDelivery, error) {
+	if m.ClaimDueDeliveriesFunc != nil {
+		return m.ClaimDueDeliveriesFunc(ctx, overdue, limit)
+	}
+	return nil, nil
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"sort"
+	"time"
+)
+
+// outboxLockKey ключ advisory-блокировки: события забирает не больше одного экземпляра сервиса одновременно,
+// иначе два экземпляра могли бы закрепить за собой одну и ту же очередь
+const outboxLockKey = 7_236_001
+
+// ClaimEvents закрепляет за вызывающим до limit необработанных событий в порядке записи на claimTimeout и
+// возвращает их. Пока за кем-то закреплено хоть одно необработанное событие, новые не выдаются: события
+// обрабатываются строго по очереди, даже если их разбирают несколько экземпляров сервиса.
+// Транзакция с блокировкой держится только на время выборки, обработка идёт уже без неё
+func (s *OutboxStorage) ClaimEvents(ctx context.Context, limit int, claimTimeout time.Duration) ([]domain.OutboxEvent, error) {
+	operation := "ClaimOutboxEvents"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.LogTransactionRollback(operation, err)
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			logger.LogTransactionRollback(operation, err)
+			_ = tx.Rollback()
+		}
+	}()
+
+	lockQuery := `SELECT pg_try_advisory_xact_lock($1)`
+	var locked bool
+	if err = tx.QueryRowContext(ctx, lockQuery, outboxLockKey).Scan(&locked); err != nil {
+		logger.LogQueryError(lockQuery, err)
+		return nil, err
+	}
+	if !locked {
+		// События сейчас забирает другой экземпляр
+		_ = tx.Rollback()
+		return nil, nil
+	}
+
+	claimQuery := `
+		WITH batch AS (
+			SELECT id
+			FROM outbox
+			WHERE processed_at IS NULL AND failed_at IS NULL
+				AND NOT EXISTS (
+					SELECT 1 FROM outbox
+					WHERE processed_at IS NULL AND failed_at IS NULL AND claimed_until > NOW()
+				)
+			ORDER BY id
+			LIMIT $1
+		)
+		UPDATE outbox o
+		SET claimed_until = NOW() + make_interval(secs => $2)
+		FROM batch
+		WHERE o.id = batch.id
+		RETURNING o.id, o.attempts, o.event_id, o.event_type, o.payload, o.occurred_at`
+
+	rows, err := tx.QueryContext(ctx, claimQuery, limit, claimTimeout.Seconds())
+	if err != nil {
+		logger.LogQueryError(claimQuery, err)
+		return nil, err
+	}
+
+	events := make([]domain.OutboxEvent, 0, limit)
+	for rows.Next() {
+		var event domain.OutboxEvent
+		var eventType string
+		var payload []byte
+		var occurredAt time.Time
+
+		if err = rows.Scan(&event.Seq, &event.Attempts, &event.Event.ID, &eventType, &payload, &occurredAt); err != nil {
+			rows.Close()
+			logger.LogQueryError(claimQuery, err)
+			return nil, err
+		}
+
+		event.Event.Type = domain.EventType(eventType)
+		event.Event.Data = payload
+		event.Event.OccurredAt = occurredAt.UTC()
+		events = append(events, event)
+	}
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		logger.LogQueryError(claimQuery, err)
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	// RETURNING порядок строк не гарантирует
+	sort.Slice(events, func(i, j int) bool { return events[i].Seq < events[j].Seq })
+	return events, nil
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logger.InitLogger()
+}
+
+func TestOutboxStorage_ClaimEvents(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	occurredAt := time.Date(2025, 10, 24, 10, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT pg_try_advisory_xact_lock`).
+		WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+	// RETURNING отдаёт строки в произвольном порядке
+	mock.ExpectQuery(`UPDATE outbox o\s+SET claimed_until`).
+		WithArgs(10, time.Minute.Seconds()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "attempts", "event_id", "event_type", "payload", "occurred_at"}).
+			AddRow(int64(2), 0, uuid.New(), "pull_request_merged", []byte(`{"pull_request_id":"pr1"}`), occurredAt).
+			AddRow(int64(1), 3, uuid.New(), "pull_request_created", []byte(`{"pull_request_id":"pr1"}`), occurredAt))
+	mock.ExpectCommit()
+
+	events, err := NewOutboxStorage(db).ClaimEvents(context.Background(), 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, int64(1), events[0].Seq)
+	assert.Equal(t, 3, events[0].Attempts)
+	assert.Equal(t, domain.EventPullRequestCreated, events[0].Event.Type)
+	assert.Equal(t, int64(2), events[1].Seq)
+	assert.Equal(t, domain.EventPullRequestMerged, events[1].Event.Type)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxStorage_ClaimEventsLockedByAnotherInstance(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT pg_try_advisory_xact_lock`).
+		WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(false))
+	mock.ExpectRollback()
+
+	events, err := NewOutboxStorage(db).ClaimEvents(context.Background(), 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"time"
+)
+
+// DeleteProcessedEvents удаляет события, обработанные раньше чем retention назад, и возвращает их количество.
+// Необработанные события не удаляются никогда
+func (s *OutboxStorage) DeleteProcessedEvents(ctx context.Context, retention time.Duration) (int64, error) {
+	query := `DELETE FROM outbox WHERE processed_at IS NOT NULL AND processed_at < NOW() - $1 * INTERVAL '1 second'`
+
+	res, err := s.db.ExecContext(ctx, query, retention.Seconds())
+	if err != nil {
+		logger.LogQueryError(query, err)
+		return 0, err
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		logger.LogQueryError(query, err)
+		return 0, err
+	}
+	return deleted, nil
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"database/sql"
+)
+
+// InsertEvent записывает событие в outbox в транзакции вызывающего репозитория:
+// событие сохраняется тогда и только тогда, когда фиксируется изменение состояния
+func InsertEvent(ctx context.Context, tx *sql.Tx, eventType domain.EventType, data interface{}) error {
+	event, err := domain.NewEvent(eventType, data)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO outbox (event_id, event_type, payload, occurred_at) VALUES ($1, $2, $3, $4)`
+	if _, err = tx.ExecContext(ctx, query, event.ID, string(event.Type), string(event.Data), event.OccurredAt); err != nil {
+		logger.LogQueryError(query, err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+)
+
+// MarkEventProcessed помечает событие обработанным и снимает с него закрепление
+func (s *OutboxStorage) MarkEventProcessed(ctx context.Context, seq int64) error {
+	query := `UPDATE outbox SET processed_at = NOW(), claimed_until = NULL WHERE id = $1`
+	if _, err := s.db.ExecContext(ctx, query, seq); err != nil {
+		logger.LogQueryError(query, err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"database/sql"
+)
+
+type OutboxStorage struct {
+	db *sql.DB
+}
+
+func NewOutboxStorage(db *sql.DB) *OutboxStorage {
+	return &OutboxStorage{
+		db: db,
+	}
+}
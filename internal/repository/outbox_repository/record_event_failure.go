@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"time"
+)
+
+// RecordEventFailure записывает неудачную попытку обработки события и её ошибку. Если попыток набралось
+// maxAttempts, событие помечается отброшенным и больше не выдаётся, иначе остаётся закреплённым ещё на
+// retryAfter: до этого времени очередь стоит, затем событие будет выдано снова первым.
+// Возвращает true, если событие отброшено
+func (s *OutboxStorage) RecordEventFailure(
+	ctx context.Context,
+	seq int64,
+	lastError string,
+	maxAttempts int,
+	retryAfter time.Duration,
+) (bool, error) {
+	query := `
+		UPDATE outbox
+		SET attempts = attempts + 1,
+			last_error = $2,
+			failed_at = CASE WHEN attempts + 1 >= $3 THEN NOW() END,
+			claimed_until = CASE WHEN attempts + 1 >= $3 THEN NULL ELSE NOW() + make_interval(secs => $4) END
+		WHERE id = $1
+		RETURNING failed_at IS NOT NULL`
+
+	var failed bool
+	if err := s.db.QueryRowContext(ctx, query, seq, lastError, maxAttempts, retryAfter.Seconds()).Scan(&failed); err != nil {
+		logger.LogQueryError(query, err)
+		return false, err
+	}
+
+	return failed, nil
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxStorage_RecordEventFailure(t *testing.T) {
+	tests := []struct {
+		name   string
+		failed bool
+	}{
+		{name: "retry later", failed: false},
+		{name: "max attempts reached", failed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			mock.ExpectQuery(`UPDATE outbox\s+SET attempts = attempts \+ 1`).
+				WithArgs(int64(7), "handler failed", 8, (4 * time.Second).Seconds()).
+				WillReturnRows(sqlmock.NewRows([]string{"failed"}).AddRow(tt.failed))
+
+			failed, err := NewOutboxStorage(db).RecordEventFailure(context.Background(), 7, "handler failed", 8, 4*time.Second)
+			require.NoError(t, err)
+			assert.Equal(t, tt.failed, failed)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
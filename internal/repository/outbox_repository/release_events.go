@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+
+	"github.com/lib/pq"
+)
+
+// ReleaseEvents снимает закрепление с необработанных событий, чтобы их можно было забрать снова, не дожидаясь
+// окончания срока закрепления
+func (s *OutboxStorage) ReleaseEvents(ctx context.Context, seqs []int64) error {
+	if len(seqs) == 0 {
+		return nil
+	}
+
+	query := `UPDATE outbox SET claimed_until = NULL WHERE id = ANY($1) AND processed_at IS NULL AND failed_at IS NULL`
+	if _, err := s.db.ExecContext(ctx, query, pq.Array(seqs)); err != nil {
+		logger.LogQueryError(query, err)
+		return err
+	}
+
+	return nil
+}
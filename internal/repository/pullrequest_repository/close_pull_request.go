@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+
+	"github.com/lib/pq"
+)
+
+// ClosePullRequest переводит PR из DRAFT или OPEN в CLOSED и снимает всех ревьюверов, назначения остаются в истории.
+// Пишет запись аудита и возвращает идентификаторы освобождённых ревьюверов.
+func (s *PullRequestStorage) ClosePullRequest(ctx context.Context, prID string) ([]string, error) {
+	operation := "ClosePullRequest"
+
+	logger.LogTransactionStart(operation)
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.LogTransactionRollback(operation, err)
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			logger.LogTransactionRollback(operation, err)
+			_ = tx.Rollback()
+		}
+	}()
+
+	before, err := LockPullRequest(ctx, tx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	updateQuery := `
+		UPDATE pull_requests
+		SET status = $1, closed_at = NOW(), need_more_reviewers = FALSE
+		WHERE id = $2 AND status = ANY($3::pr_status[])`
+	result, err := tx.ExecContext(ctx, updateQuery,
+		string(domain.PRStatusClosed),
+		prID,
+		pq.Array([]string{string(domain.PRStatusDraft), string(domain.PRStatusOpen)}),
+	)
+	if err != nil {
+		logger.LogQueryError(updateQuery, err)
+		return nil, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.LogQueryError(updateQuery, err)
+		return nil, err
+	}
+
+	if rowsAffected == 0 {
+		err = transitionError(ctx, tx, prID, domain.PRStatusClosed)
+		return nil, err
+	}
+
+	unassignQuery := `
+		UPDATE reviewers
+		SET unassigned_at = NOW(), unassign_reason = $1
+		WHERE pull_request_id = $2 AND unassigned_at IS NULL
+		RETURNING reviewer_id`
+	rows, err := tx.QueryContext(ctx, unassignQuery, string(domain.UnassignReasonClosed), prID)
+	if err != nil {
+		logger.LogQueryError(unassignQuery, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	released := make([]string, 0, domain.DefaultRequiredReviewers)
+	for rows.Next() {
+		var reviewerID string
+		if err = rows.Scan(&reviewerID); err != nil {
+			logger.LogQueryError(unassignQuery, err)
+			return nil, err
+		}
+		released = append(released, reviewerID)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.LogQueryError(unassignQuery, err)
+		return nil, err
+	}
+	rows.Close()
+
+	if err = RecordChange(ctx, tx, "ClosePullRequest", prID, released, before); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.LogTransactionRollback(operation, err)
+		return nil, err
+	}
+
+	logger.LogTransactionCommit(operation)
+	return released, nil
+}
@@ -2,12 +2,14 @@ package repository
 
 import (
 	"AVITOSAMPISHU/internal/domain"
+	outbox_repository "AVITOSAMPISHU/internal/repository/outbox_repository"
 	"AVITOSAMPISHU/pkg/logger"
 	"context"
 
 	"github.com/lib/pq"
 )
 
+// CreatePullRequestWithReviewers создаёт PR с назначенными ревьюверами, пишет событие pull_request_created и запись аудита
 func (s *PullRequestStorage) CreatePullRequestWithReviewers(
 	ctx context.Context,
 	pr *domain.PullRequest,
@@ -50,6 +52,14 @@ func (s *PullRequestStorage) CreatePullRequestWithReviewers(
 		}
 	}
 
+	if err = outbox_repository.InsertEvent(ctx, tx, domain.EventPullRequestCreated, pr); err != nil {
+		return err
+	}
+
+	if err = RecordChange(ctx, tx, "CreatePullRequest", pr.PullRequestID, reviewerIDs, nil); err != nil {
+		return err
+	}
+
 	if err = tx.Commit(); err != nil {
 		logger.LogTransactionRollback(operation, err)
 		return err
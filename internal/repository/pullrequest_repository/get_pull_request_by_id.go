@@ -2,6 +2,7 @@ package repository
 
 import (
 	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/helpers"
 	"AVITOSAMPISHU/pkg/logger"
 	"context"
 	"database/sql"
@@ -10,19 +11,47 @@ import (
 )
 
 func (s *PullRequestStorage) GetPullRequestByID(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	return LoadPullRequest(ctx, s.db, prID)
+}
+
+// LockPullRequest блокирует PR до конца транзакции и возвращает его карточку: снимок состояния до изменения
+// для журнала аудита
+func LockPullRequest(ctx context.Context, tx *sql.Tx, prID string) (*domain.PullRequest, error) {
+	lockQuery := `SELECT id FROM pull_requests WHERE id = $1 FOR UPDATE`
+	var id string
+	if err := tx.QueryRowContext(ctx, lockQuery, prID).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		logger.LogQueryError(lockQuery, err)
+		return nil, err
+	}
+
+	return LoadPullRequest(ctx, tx, prID)
+}
+
+// LoadPullRequest читает карточку PR с текущими ревьюверами и их решениями через q: БД или транзакцию.
+// Текущие ревьюверы — действующие назначения, а у слитого PR — назначения, завершённые слиянием
+func LoadPullRequest(ctx context.Context, q helpers.Querier, prID string) (*domain.PullRequest, error) {
 	query := `
-		SELECT pull_requests_name, author_id, status, need_more_reviewers, created_at, merged_at
-		FROM pull_requests
-		WHERE id = $1`
+		SELECT pr.pull_requests_name, pr.author_id, COALESCE(u.username, ''), COALESCE(t.team_name, ''),
+			pr.status, pr.need_more_reviewers, pr.created_at, pr.merged_at, pr.closed_at
+		FROM pull_requests pr
+		LEFT JOIN users u ON u.id = pr.author_id
+		LEFT JOIN teams t ON t.id = u.team_id
+		WHERE pr.id = $1`
 
 	var name string
 	var authorID string
+	var authorUsername string
+	var teamName string
 	var status string
 	var needMoreReviewers bool
 	var createdAt time.Time
 	var mergedAt sql.NullTime
+	var closedAt sql.NullTime
 
-	err := s.db.QueryRowContext(ctx, query, prID).Scan(&name, &authorID, &status, &needMoreReviewers, &createdAt, &mergedAt)
+	err := q.QueryRowContext(ctx, query, prID).Scan(&name, &authorID, &authorUsername, &teamName, &status, &needMoreReviewers, &createdAt, &mergedAt, &closedAt)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.ErrNotFound
@@ -31,22 +60,40 @@ func (s *PullRequestStorage) GetPullRequestByID(ctx context.Context, prID string
 		return nil, err
 	}
 
-	reviewersQuery := `SELECT reviewer_id FROM reviewers WHERE pull_request_id = $1`
-	rows, err := s.db.QueryContext(ctx, reviewersQuery, prID)
+	reviewersQuery := `
+		SELECT reviewer_id, verdict, COALESCE(verdict_comment, ''), assigned_at, reviewed_at
+		FROM reviewers
+		WHERE pull_request_id = $1 AND (unassigned_at IS NULL OR unassign_reason = 'merged')
+		ORDER BY assigned_at`
+	rows, err := q.QueryContext(ctx, reviewersQuery, prID)
 	if err != nil {
 		logger.LogQueryError(reviewersQuery, err)
 		return nil, err
 	}
 	defer rows.Close()
 
-	reviewers := make([]string, 0, domain.MaxReviewersCount)
+	reviewers := make([]string, 0, domain.DefaultRequiredReviewers)
+	reviews := make([]domain.ReviewerState, 0, domain.DefaultRequiredReviewers)
 	for rows.Next() {
-		var reviewerID string
-		if err = rows.Scan(&reviewerID); err != nil {
+		var review domain.ReviewerState
+		var verdict string
+		var assignedAt sql.NullTime
+		var reviewedAt sql.NullTime
+
+		if err = rows.Scan(&review.ReviewerID, &verdict, &review.Comment, &assignedAt, &reviewedAt); err != nil {
 			logger.LogQueryError(reviewersQuery, err)
 			return nil, err
 		}
-		reviewers = append(reviewers, reviewerID)
+
+		review.Verdict = domain.ReviewVerdict(verdict)
+		if assignedAt.Valid {
+			review.AssignedAt = &assignedAt.Time
+		}
+		if reviewedAt.Valid {
+			review.ReviewedAt = &reviewedAt.Time
+		}
+		reviewers = append(reviewers, review.ReviewerID)
+		reviews = append(reviews, review)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -59,14 +106,23 @@ func (s *PullRequestStorage) GetPullRequestByID(ctx context.Context, prID string
 		mergedAtPtr = &mergedAt.Time
 	}
 
+	var closedAtPtr *time.Time
+	if closedAt.Valid {
+		closedAtPtr = &closedAt.Time
+	}
+
 	return &domain.PullRequest{
 		PullRequestID:     prID,
 		PullRequestName:   name,
 		AuthorID:          authorID,
+		AuthorUsername:    authorUsername,
+		TeamName:          teamName,
 		Status:            domain.PRStatus(status),
 		AssignedReviewers: reviewers,
+		Reviews:           reviews,
 		NeedMoreReviewers: &needMoreReviewers,
 		CreatedAt:         &createdAt,
 		MergedAt:          mergedAtPtr,
+		ClosedAt:          closedAtPtr,
 	}, nil
 }
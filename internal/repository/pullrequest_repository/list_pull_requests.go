@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/helpers"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ListPullRequests возвращает не более limit PR, подходящих под фильтры, в порядке (created_at, id).
+// Курсор из фильтра указывает на последний элемент предыдущей страницы.
+func (s *PullRequestStorage) ListPullRequests(
+	ctx context.Context,
+	filter *domain.ListPullRequestsReq,
+	limit int,
+) ([]domain.PullRequest, error) {
+	conditions := make([]string, 0, 10)
+	args := make(helpers.QueryArgs, 0, 12)
+
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, 0, len(filter.Statuses))
+		for _, status := range filter.Statuses {
+			statuses = append(statuses, string(status))
+		}
+		conditions = append(conditions, "pr.status = ANY("+args.Add(pq.Array(statuses))+"::pr_status[])")
+	}
+	if filter.AuthorID != "" {
+		conditions = append(conditions, "pr.author_id = "+args.Add(filter.AuthorID))
+	}
+	if filter.TeamName != "" {
+		conditions = append(conditions, "t.team_name = "+args.Add(filter.TeamName))
+	}
+	if filter.ReviewerID != "" {
+		conditions = append(conditions,
+			"EXISTS (SELECT 1 FROM reviewers rf WHERE rf.pull_request_id = pr.id AND (rf.unassigned_at IS NULL OR rf.unassign_reason = 'merged') AND rf.reviewer_id = "+args.Add(filter.ReviewerID)+")")
+	}
+	if filter.NeedMoreReviewers != nil {
+		conditions = append(conditions, "pr.need_more_reviewers = "+args.Add(*filter.NeedMoreReviewers))
+	}
+	if filter.CreatedFrom != nil {
+		conditions = append(conditions, "pr.created_at >= "+args.Add(helpers.TimestampArg(*filter.CreatedFrom))+"::timestamp")
+	}
+	if filter.CreatedTo != nil {
+		conditions = append(conditions, "pr.created_at < "+args.Add(helpers.TimestampArg(*filter.CreatedTo))+"::timestamp")
+	}
+	if filter.MergedFrom != nil {
+		conditions = append(conditions, "pr.merged_at >= "+args.Add(helpers.TimestampArg(*filter.MergedFrom))+"::timestamp")
+	}
+	if filter.MergedTo != nil {
+		conditions = append(conditions, "pr.merged_at < "+args.Add(helpers.TimestampArg(*filter.MergedTo))+"::timestamp")
+	}
+
+	order := "DESC"
+	comparison := "<"
+	if filter.Order == domain.SortOrderAsc {
+		order = "ASC"
+		comparison = ">"
+	}
+
+	if filter.Cursor != nil {
+		createdAt := args.Add(helpers.TimestampArg(filter.Cursor.CreatedAt))
+		id := args.Add(filter.Cursor.ID)
+		conditions = append(conditions, fmt.Sprintf("(pr.created_at, pr.id) %s (%s::timestamp, %s)", comparison, createdAt, id))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query := fmt.Sprintf(`
+		SELECT pr.id, pr.pull_requests_name, pr.author_id, COALESCE(u.username, ''), COALESCE(t.team_name, ''),
+			pr.status, pr.need_more_reviewers, pr.created_at, pr.merged_at, pr.closed_at,
+			ARRAY(SELECT r.reviewer_id FROM reviewers r WHERE r.pull_request_id = pr.id AND (r.unassigned_at IS NULL OR r.unassign_reason = 'merged') ORDER BY r.assigned_at)
+		FROM pull_requests pr
+		LEFT JOIN users u ON u.id = pr.author_id
+		LEFT JOIN teams t ON t.id = u.team_id
+		%s
+		ORDER BY pr.created_at %s, pr.id %s
+		LIMIT %s`, where, order, order, args.Add(limit))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.LogQueryError(query, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	prs := make([]domain.PullRequest, 0, limit)
+	for rows.Next() {
+		var pr domain.PullRequest
+		var status string
+		var needMoreReviewers bool
+		var createdAt time.Time
+		var mergedAt sql.NullTime
+		var closedAt sql.NullTime
+		reviewers := make([]string, 0, domain.DefaultRequiredReviewers)
+
+		if err = rows.Scan(
+			&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.AuthorUsername, &pr.TeamName,
+			&status, &needMoreReviewers, &createdAt, &mergedAt, &closedAt,
+			pq.Array(&reviewers),
+		); err != nil {
+			logger.LogQueryError(query, err)
+			return nil, err
+		}
+
+		pr.Status = domain.PRStatus(status)
+		pr.NeedMoreReviewers = &needMoreReviewers
+		pr.CreatedAt = &createdAt
+		pr.AssignedReviewers = reviewers
+		if mergedAt.Valid {
+			pr.MergedAt = &mergedAt.Time
+		}
+		if closedAt.Valid {
+			pr.ClosedAt = &closedAt.Time
+		}
+
+		prs = append(prs, pr)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.LogQueryError(query, err)
+		return nil, err
+	}
+
+	return prs, nil
+}
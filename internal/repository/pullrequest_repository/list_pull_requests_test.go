@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logger.InitLogger()
+}
+
+func TestPullRequestStorage_ListPullRequests(t *testing.T) {
+	createdAt := time.Date(2025, 10, 24, 10, 0, 0, 0, time.UTC)
+	mergedAt := createdAt.Add(2 * time.Hour)
+	needMore := true
+
+	columns := []string{
+		"id", "pull_requests_name", "author_id", "username", "team_name",
+		"status", "need_more_reviewers", "created_at", "merged_at", "closed_at", "reviewers",
+	}
+
+	tests := []struct {
+		name    string
+		filter  *domain.ListPullRequestsReq
+		limit   int
+		setup   func(mock sqlmock.Sqlmock)
+		wantLen int
+	}{
+		{
+			name:   "no filters",
+			filter: &domain.ListPullRequestsReq{Order: domain.SortOrderDesc},
+			limit:  3,
+			setup: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow("pr2", "Second", "u1", "Alice", "backend", "MERGED", false, createdAt, mergedAt, nil, "{u2,u3}").
+					AddRow("pr1", "First", "u1", "Alice", "backend", "OPEN", true, createdAt, nil, nil, "{}")
+				mock.ExpectQuery(`ORDER BY pr.created_at DESC, pr.id DESC\s+LIMIT \$1`).
+					WithArgs(3).
+					WillReturnRows(rows)
+			},
+			wantLen: 2,
+		},
+		{
+			name: "all filters with cursor",
+			filter: &domain.ListPullRequestsReq{
+				Statuses:          []domain.PRStatus{domain.PRStatusOpen},
+				AuthorID:          "u1",
+				TeamName:          "backend",
+				ReviewerID:        "u2",
+				NeedMoreReviewers: &needMore,
+				CreatedFrom:       &createdAt,
+				Order:             domain.SortOrderAsc,
+				Cursor:            &domain.PageCursor{CreatedAt: createdAt, ID: "pr1"},
+			},
+			limit: 2,
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`WHERE pr.status = ANY\(\$1::pr_status\[\]\) AND pr.author_id = \$2 AND t.team_name = \$3 AND EXISTS .+ AND pr.need_more_reviewers = \$5 AND pr.created_at >= \$6::timestamp AND \(pr.created_at, pr.id\) > \(\$7::timestamp, \$8\)\s+ORDER BY pr.created_at ASC, pr.id ASC\s+LIMIT \$9`).
+					WithArgs(sqlmock.AnyArg(), "u1", "backend", "u2", true, "2025-10-24 10:00:00", "2025-10-24 10:00:00", "pr1", 2).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+			wantLen: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			tt.setup(mock)
+
+			storage := NewPullRequestStorage(db)
+			prs, err := storage.ListPullRequests(context.Background(), tt.filter, tt.limit)
+			require.NoError(t, err)
+			assert.Len(t, prs, tt.wantLen)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPullRequestStorage_ListPullRequests_ScansRows(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	createdAt := time.Date(2025, 10, 24, 10, 0, 0, 0, time.UTC)
+	mergedAt := createdAt.Add(time.Hour)
+	rows := sqlmock.NewRows([]string{
+		"id", "pull_requests_name", "author_id", "username", "team_name",
+		"status", "need_more_reviewers", "created_at", "merged_at", "closed_at", "reviewers",
+	}).AddRow("pr1", "First", "u1", "Alice", "backend", "MERGED", false, createdAt, mergedAt, nil, "{u2,u3}")
+	mock.ExpectQuery(`SELECT pr.id`).WillReturnRows(rows)
+
+	prs, err := NewPullRequestStorage(db).ListPullRequests(context.Background(), &domain.ListPullRequestsReq{}, 10)
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+
+	pr := prs[0]
+	assert.Equal(t, "pr1", pr.PullRequestID)
+	assert.Equal(t, "Alice", pr.AuthorUsername)
+	assert.Equal(t, "backend", pr.TeamName)
+	assert.Equal(t, domain.PRStatusMerged, pr.Status)
+	assert.Equal(t, []string{"u2", "u3"}, pr.AssignedReviewers)
+	require.NotNil(t, pr.MergedAt)
+	assert.Equal(t, mergedAt, *pr.MergedAt)
+	assert.Nil(t, pr.ClosedAt)
+}
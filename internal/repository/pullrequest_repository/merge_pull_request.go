@@ -2,6 +2,7 @@ package repository
 
 import (
 	"AVITOSAMPISHU/internal/domain"
+	outbox_repository "AVITOSAMPISHU/internal/repository/outbox_repository"
 	"AVITOSAMPISHU/pkg/logger"
 	"context"
 	"database/sql"
@@ -9,43 +10,163 @@ import (
 	"time"
 )
 
-func (s *PullRequestStorage) MergePullRequest(ctx context.Context, prID string) error {
+// MergePullRequest сливает OPEN PR, проставляет pr статус и время слияния, завершает назначения ревьюверов
+// с причиной merged и пишет событие pull_request_merged.
+// PR блокируется до конца транзакции, решения ревьюверов перечитываются в pr и передаются check: ошибка check
+// отменяет слияние, а решение, отправленное параллельно, либо попадёт в проверку, либо будет отклонено как для слитого PR.
+// Слияние записывается в журнал аудита в той же транзакции. Повторное слияние уже слитого PR ничего не меняет
+// и не порождает ни события, ни записи аудита
+func (s *PullRequestStorage) MergePullRequest(
+	ctx context.Context,
+	pr *domain.PullRequest,
+	check func(locked *domain.PullRequest) error,
+) error {
+	operation := "MergePullRequest"
+
+	logger.LogTransactionStart(operation)
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.LogTransactionRollback(operation, err)
+		return err
+	}
+	defer func() {
+		if err != nil {
+			logger.LogTransactionRollback(operation, err)
+			_ = tx.Rollback()
+		}
+	}()
+
+	statusQuery := `SELECT status, merged_at FROM pull_requests WHERE id = $1 FOR UPDATE`
+	var currentStatus string
+	var currentMergedAt sql.NullTime
+	err = tx.QueryRowContext(ctx, statusQuery, pr.PullRequestID).Scan(&currentStatus, &currentMergedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = domain.ErrNotFound
+			return err
+		}
+		logger.LogQueryError(statusQuery, err)
+		return err
+	}
+
+	if currentStatus == string(domain.PRStatusMerged) {
+		// PR уже слит параллельным запросом
+		pr.Status = domain.PRStatusMerged
+		if currentMergedAt.Valid {
+			pr.MergedAt = &currentMergedAt.Time
+		}
+		_ = tx.Rollback()
+		return nil
+	}
+
+	if currentStatus != string(domain.PRStatusOpen) {
+		err = domain.ValidateStatusTransition(domain.PRStatus(currentStatus), domain.PRStatusMerged)
+		return err
+	}
+
+	before, err := LoadPullRequest(ctx, tx, pr.PullRequestID)
+	if err != nil {
+		return err
+	}
+
+	reviews, err := lockedReviews(ctx, tx, pr.PullRequestID)
+	if err != nil {
+		return err
+	}
+	pr.SetReviews(reviews)
+
+	if check != nil {
+		if err = check(pr); err != nil {
+			return err
+		}
+	}
+
 	query := `
 		UPDATE pull_requests
 		SET status = $1, merged_at = COALESCE(merged_at, $2)
-		WHERE id = $3 AND status != $1`
+		WHERE id = $3
+		RETURNING merged_at`
 
-	now := time.Now()
-	result, err := s.db.ExecContext(ctx, query, string(domain.PRStatusMerged), now, prID)
+	var mergedAt time.Time
+	err = tx.QueryRowContext(ctx, query, string(domain.PRStatusMerged), time.Now(), pr.PullRequestID).Scan(&mergedAt)
 	if err != nil {
 		logger.LogQueryError(query, err)
 		return err
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	pr.Status = domain.PRStatusMerged
+	pr.MergedAt = &mergedAt
+
+	// Назначения слитого PR завершаются временем слияния, но остаются его ревьюверами
+	unassignQuery := `
+		UPDATE reviewers
+		SET unassigned_at = $1, unassign_reason = $2
+		WHERE pull_request_id = $3 AND unassigned_at IS NULL`
+	_, err = tx.ExecContext(ctx, unassignQuery, mergedAt, string(domain.UnassignReasonMerged), pr.PullRequestID)
 	if err != nil {
-		logger.LogQueryError(query, err)
+		logger.LogQueryError(unassignQuery, err)
 		return err
 	}
 
-	if rowsAffected == 0 {
-		statusQuery := `SELECT status FROM pull_requests WHERE id = $1`
-		var currentStatus string
-		err = s.db.QueryRowContext(ctx, statusQuery, prID).Scan(&currentStatus)
-		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				return domain.ErrNotFound
-			}
-			logger.LogQueryError(statusQuery, err)
-			return err
+	if err = outbox_repository.InsertEvent(ctx, tx, domain.EventPullRequestMerged, pr); err != nil {
+		return err
+	}
+
+	if err = RecordChange(ctx, tx, "MergePullRequest", pr.PullRequestID, nil, before); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.LogTransactionRollback(operation, err)
+		return err
+	}
+
+	logger.LogTransactionCommit(operation)
+	return nil
+}
+
+// lockedReviews читает текущие решения ревьюверов PR и блокирует их строки до конца транзакции
+func lockedReviews(ctx context.Context, tx *sql.Tx, prID string) ([]domain.ReviewerState, error) {
+	query := `
+		SELECT reviewer_id, verdict, COALESCE(verdict_comment, ''), assigned_at, reviewed_at
+		FROM reviewers
+		WHERE pull_request_id = $1 AND unassigned_at IS NULL
+		ORDER BY assigned_at
+		FOR UPDATE`
+
+	rows, err := tx.QueryContext(ctx, query, prID)
+	if err != nil {
+		logger.LogQueryError(query, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := make([]domain.ReviewerState, 0, domain.DefaultRequiredReviewers)
+	for rows.Next() {
+		var review domain.ReviewerState
+		var verdict string
+		var assignedAt sql.NullTime
+		var reviewedAt sql.NullTime
+
+		if err = rows.Scan(&review.ReviewerID, &verdict, &review.Comment, &assignedAt, &reviewedAt); err != nil {
+			logger.LogQueryError(query, err)
+			return nil, err
 		}
 
-		if currentStatus == string(domain.PRStatusMerged) {
-			return nil
+		review.Verdict = domain.ReviewVerdict(verdict)
+		if assignedAt.Valid {
+			review.AssignedAt = &assignedAt.Time
+		}
+		if reviewedAt.Valid {
+			review.ReviewedAt = &reviewedAt.Time
 		}
+		reviews = append(reviews, review)
+	}
 
-		return domain.ErrNotFound
+	if err = rows.Err(); err != nil {
+		logger.LogQueryError(query, err)
+		return nil, err
 	}
 
-	return nil
+	return reviews, nil
 }
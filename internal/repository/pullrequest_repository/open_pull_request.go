@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+)
+
+// OpenPullRequest переводит PR из статуса from (DRAFT или CLOSED) в OPEN, назначает ревьюверов и пишет запись аудита
+func (s *PullRequestStorage) OpenPullRequest(
+	ctx context.Context,
+	prID string,
+	from domain.PRStatus,
+	reviewerIDs []string,
+	needMoreReviewers bool,
+) error {
+	operation := "OpenPullRequest"
+
+	logger.LogTransactionStart(operation)
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.LogTransactionRollback(operation, err)
+		return err
+	}
+	defer func() {
+		if err != nil {
+			logger.LogTransactionRollback(operation, err)
+			_ = tx.Rollback()
+		}
+	}()
+
+	before, err := LockPullRequest(ctx, tx, prID)
+	if err != nil {
+		return err
+	}
+
+	updateQuery := `
+		UPDATE pull_requests
+		SET status = $1, closed_at = NULL, need_more_reviewers = $2
+		WHERE id = $3 AND status = $4`
+	result, err := tx.ExecContext(ctx, updateQuery, string(domain.PRStatusOpen), needMoreReviewers, prID, string(from))
+	if err != nil {
+		logger.LogQueryError(updateQuery, err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.LogQueryError(updateQuery, err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		err = transitionError(ctx, tx, prID, domain.PRStatusOpen)
+		return err
+	}
+
+	if len(reviewerIDs) > 0 {
+		reviewerQuery := `INSERT INTO reviewers (pull_request_id, reviewer_id, assigned_at) VALUES ($1, $2, NOW())`
+		for _, reviewerID := range reviewerIDs {
+			_, err = tx.ExecContext(ctx, reviewerQuery, prID, reviewerID)
+			if err != nil {
+				logger.LogQueryError(reviewerQuery, err)
+				return err
+			}
+		}
+	}
+
+	if err = RecordChange(ctx, tx, "OpenPullRequest", prID, reviewerIDs, before); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.LogTransactionRollback(operation, err)
+		return err
+	}
+
+	logger.LogTransactionCommit(operation)
+	return nil
+}
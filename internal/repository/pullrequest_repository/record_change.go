@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	audit_repository "AVITOSAMPISHU/internal/repository/audit_repository"
+	"context"
+	"database/sql"
+)
+
+// RecordChange пишет изменение PR в журнал аудита в транзакции tx. Состояние после изменения читается
+// в той же транзакции, before — карточка, прочитанная в ней до изменения (nil для нового PR)
+func RecordChange(
+	ctx context.Context,
+	tx *sql.Tx,
+	operation string,
+	prID string,
+	targetIDs []string,
+	before *domain.PullRequest,
+) error {
+	after, err := LoadPullRequest(ctx, tx, prID)
+	if err != nil {
+		return err
+	}
+
+	change := domain.AuditChange{
+		Operation:  operation,
+		EntityType: domain.AuditEntityPullRequest,
+		EntityID:   prID,
+		TargetIDs:  targetIDs,
+		After:      after,
+	}
+	if before != nil {
+		change.Before = before
+	}
+
+	return audit_repository.InsertEvent(ctx, tx, change)
+}
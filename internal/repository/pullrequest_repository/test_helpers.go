@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"AVITOSAMPISHU/internal/config"
 	"AVITOSAMPISHU/internal/domain"
 	"AVITOSAMPISHU/internal/infrastructure/database"
 	team_repository "AVITOSAMPISHU/internal/repository/team_repository"
@@ -19,7 +20,10 @@ func setupPRTestDB(t *testing.T) *sql.DB {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	db, err := database.NewDB(ctx)
+	cfg, _, err := config.Load("test", nil)
+	require.NoError(t, err)
+
+	db, err := database.NewDBWithConfig(ctx, cfg.DatabaseOptions())
 	require.NoError(t, err)
 
 	cleanupPRTestDB(t, db)
@@ -46,6 +50,6 @@ func createTestTeamAndUser(t *testing.T, db *sql.DB, teamName, userID, username
 	ctx := context.Background()
 	_, err := teamStorage.CreateTeamWithMembers(ctx, teamName, []domain.TeamMember{
 		{UserID: userID, Username: username, IsActive: true},
-	})
+	}, domain.DefaultTeamSettings())
 	require.NoError(t, err)
 }
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// transitionError объясняет, почему условный UPDATE статуса не затронул ни одной строки:
+// PR не существует или находится не в том статусе, из которого разрешён переход в target
+func transitionError(ctx context.Context, tx *sql.Tx, prID string, target domain.PRStatus) error {
+	statusQuery := `SELECT status FROM pull_requests WHERE id = $1`
+	var currentStatus string
+	err := tx.QueryRowContext(ctx, statusQuery, prID).Scan(&currentStatus)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrNotFound
+		}
+		logger.LogQueryError(statusQuery, err)
+		return err
+	}
+
+	return fmt.Errorf("%w: %s -> %s", domain.ErrInvalidTransition, currentStatus, target)
+}
@@ -7,7 +7,7 @@ import (
 )
 
 func (s *PrReviewersStorage) GetAssignedReviewers(ctx context.Context, prID string) ([]string, error) {
-	query := `SELECT reviewer_id FROM reviewers WHERE pull_request_id = $1 ORDER BY assigned_at`
+	query := `SELECT reviewer_id FROM reviewers WHERE pull_request_id = $1 AND (unassigned_at IS NULL OR unassign_reason = 'merged') ORDER BY assigned_at`
 
 	rows, err := s.db.QueryContext(ctx, query, prID)
 	if err != nil {
@@ -16,7 +16,7 @@ func (s *PrReviewersStorage) GetAssignedReviewers(ctx context.Context, prID stri
 	}
 	defer rows.Close()
 
-	reviewers := make([]string, 0, domain.MaxReviewersCount)
+	reviewers := make([]string, 0, domain.DefaultRequiredReviewers)
 	for rows.Next() {
 		var reviewerID string
 		if err = rows.Scan(&reviewerID); err != nil {
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+
+	"github.com/lib/pq"
+)
+
+// GetOpenReviewsCount возвращает количество открытых PR, назначенных каждому из пользователей.
+// Пользователи без открытых ревью в результат не попадают.
+func (s *PrReviewersStorage) GetOpenReviewsCount(ctx context.Context, userIDs []string) (map[string]int, error) {
+	query := `
+		SELECT r.reviewer_id, COUNT(*)
+		FROM reviewers r
+		JOIN pull_requests pr ON pr.id = r.pull_request_id
+		WHERE pr.status = $1 AND r.reviewer_id = ANY($2) AND r.unassigned_at IS NULL
+		GROUP BY r.reviewer_id`
+
+	rows, err := s.db.QueryContext(ctx, query, string(domain.PRStatusOpen), pq.Array(userIDs))
+	if err != nil {
+		logger.LogQueryError(query, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int, len(userIDs))
+	for rows.Next() {
+		var reviewerID string
+		var count int
+		if err = rows.Scan(&reviewerID, &count); err != nil {
+			logger.LogQueryError(query, err)
+			return nil, err
+		}
+		counts[reviewerID] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.LogQueryError(query, err)
+		return nil, err
+	}
+
+	return counts, nil
+}
@@ -11,7 +11,7 @@ func (s *PrReviewersStorage) GetPRsByReviewer(ctx context.Context, userID string
 		SELECT pr.id, pr.pull_requests_name, pr.author_id, pr.status
 		FROM pull_requests pr
 		JOIN reviewers r ON pr.id = r.pull_request_id
-		WHERE r.reviewer_id = $1
+		WHERE r.reviewer_id = $1 AND (r.unassigned_at IS NULL OR r.unassign_reason = 'merged')
 		ORDER BY pr.created_at DESC`
 
 	rows, err := s.db.QueryContext(ctx, query, userID)
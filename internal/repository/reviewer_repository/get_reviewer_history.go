@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"database/sql"
+)
+
+// GetReviewerHistory возвращает все назначения ревьюверов PR, включая снятые, в порядке назначения
+func (s *PrReviewersStorage) GetReviewerHistory(ctx context.Context, prID string) ([]domain.ReviewerAssignment, error) {
+	query := `
+		SELECT reviewer_id, verdict, COALESCE(verdict_comment, ''), assigned_at, reviewed_at,
+			unassigned_at, COALESCE(unassign_reason, '')
+		FROM reviewers
+		WHERE pull_request_id = $1
+		ORDER BY assigned_at, id`
+
+	rows, err := s.db.QueryContext(ctx, query, prID)
+	if err != nil {
+		logger.LogQueryError(query, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := make([]domain.ReviewerAssignment, 0, domain.DefaultRequiredReviewers)
+	for rows.Next() {
+		var assignment domain.ReviewerAssignment
+		var verdict string
+		var reason string
+		var assignedAt sql.NullTime
+		var reviewedAt sql.NullTime
+		var unassignedAt sql.NullTime
+
+		if err = rows.Scan(&assignment.ReviewerID, &verdict, &assignment.Comment, &assignedAt, &reviewedAt, &unassignedAt, &reason); err != nil {
+			logger.LogQueryError(query, err)
+			return nil, err
+		}
+
+		assignment.Verdict = domain.ReviewVerdict(verdict)
+		assignment.Reason = domain.UnassignReason(reason)
+		if assignedAt.Valid {
+			assignment.AssignedAt = &assignedAt.Time
+		}
+		if reviewedAt.Valid {
+			assignment.ReviewedAt = &reviewedAt.Time
+		}
+		if unassignedAt.Valid {
+			assignment.UnassignedAt = &unassignedAt.Time
+		}
+		history = append(history, assignment)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.LogQueryError(query, err)
+		return nil, err
+	}
+
+	return history, nil
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"database/sql"
+)
+
+// GetReviews возвращает назначенных ревьюверов PR вместе с их решениями
+func (s *PrReviewersStorage) GetReviews(ctx context.Context, prID string) ([]domain.ReviewerState, error) {
+	query := `
+		SELECT reviewer_id, verdict, COALESCE(verdict_comment, ''), assigned_at, reviewed_at
+		FROM reviewers
+		WHERE pull_request_id = $1 AND (unassigned_at IS NULL OR unassign_reason = 'merged')
+		ORDER BY assigned_at`
+
+	rows, err := s.db.QueryContext(ctx, query, prID)
+	if err != nil {
+		logger.LogQueryError(query, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := make([]domain.ReviewerState, 0, domain.DefaultRequiredReviewers)
+	for rows.Next() {
+		var review domain.ReviewerState
+		var verdict string
+		var assignedAt sql.NullTime
+		var reviewedAt sql.NullTime
+
+		if err = rows.Scan(&review.ReviewerID, &verdict, &review.Comment, &assignedAt, &reviewedAt); err != nil {
+			logger.LogQueryError(query, err)
+			return nil, err
+		}
+
+		review.Verdict = domain.ReviewVerdict(verdict)
+		if assignedAt.Valid {
+			review.AssignedAt = &assignedAt.Time
+		}
+		if reviewedAt.Valid {
+			review.ReviewedAt = &reviewedAt.Time
+		}
+		reviews = append(reviews, review)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.LogQueryError(query, err)
+		return nil, err
+	}
+
+	return reviews, nil
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/helpers"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ListPRsByReviewer возвращает не более limit PR ревьювера от новых к старым.
+// Курсор из запроса указывает на последний PR предыдущей страницы.
+func (s *PrReviewersStorage) ListPRsByReviewer(
+	ctx context.Context,
+	req *domain.GetUserReviewsReq,
+	limit int,
+) ([]domain.PullRequestShort, error) {
+	args := make(helpers.QueryArgs, 0, 5)
+	where := "WHERE r.reviewer_id = " + args.Add(req.UserID) + " AND (r.unassigned_at IS NULL OR r.unassign_reason = 'merged')"
+
+	if len(req.Statuses) > 0 {
+		statuses := make([]string, 0, len(req.Statuses))
+		for _, status := range req.Statuses {
+			statuses = append(statuses, string(status))
+		}
+		where += " AND pr.status = ANY(" + args.Add(pq.Array(statuses)) + "::pr_status[])"
+	}
+
+	if req.Cursor != nil {
+		createdAt := args.Add(helpers.TimestampArg(req.Cursor.CreatedAt))
+		id := args.Add(req.Cursor.ID)
+		where += fmt.Sprintf(" AND (pr.created_at, pr.id) < (%s::timestamp, %s)", createdAt, id)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT pr.id, pr.pull_requests_name, pr.author_id, pr.status, pr.created_at
+		FROM pull_requests pr
+		JOIN reviewers r ON pr.id = r.pull_request_id
+		%s
+		ORDER BY pr.created_at DESC, pr.id DESC
+		LIMIT %s`, where, args.Add(limit))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.LogQueryError(query, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	prs := make([]domain.PullRequestShort, 0, limit)
+	for rows.Next() {
+		var pr domain.PullRequestShort
+		var status string
+		var createdAt time.Time
+
+		if err = rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &status, &createdAt); err != nil {
+			logger.LogQueryError(query, err)
+			return nil, err
+		}
+
+		pr.Status = domain.PRStatus(status)
+		pr.CreatedAt = &createdAt
+		prs = append(prs, pr)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.LogQueryError(query, err)
+		return nil, err
+	}
+
+	return prs, nil
+}
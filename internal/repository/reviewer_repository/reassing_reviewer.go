@@ -2,12 +2,17 @@ package repository
 
 import (
 	"AVITOSAMPISHU/internal/domain"
+	outbox_repository "AVITOSAMPISHU/internal/repository/outbox_repository"
+	pullrequest_repository "AVITOSAMPISHU/internal/repository/pullrequest_repository"
+	stats_repository "AVITOSAMPISHU/internal/repository/stats_repository"
 	"AVITOSAMPISHU/pkg/logger"
 	"context"
 	"database/sql"
 	"errors"
 )
 
+// ReassignReviewer снимает ревьювера с открытого PR, назначает вместо него newReviewerID (если задан),
+// пишет событие reviewer_reassigned и запись аудита
 func (s *PrReviewersStorage) ReassignReviewer(
 	ctx context.Context,
 	prID,
@@ -29,7 +34,8 @@ func (s *PrReviewersStorage) ReassignReviewer(
 		}
 	}()
 
-	statusQuery := `SELECT status FROM pull_requests WHERE id = $1`
+	// Блокируем PR, чтобы переназначение не прошло параллельно со слиянием или закрытием
+	statusQuery := `SELECT status FROM pull_requests WHERE id = $1 FOR UPDATE`
 	var prStatus string
 	err = tx.QueryRowContext(ctx, statusQuery, prID).Scan(&prStatus)
 	if err != nil {
@@ -41,24 +47,40 @@ func (s *PrReviewersStorage) ReassignReviewer(
 	}
 
 	if prStatus == string(domain.PRStatusMerged) {
-		return domain.ErrPRMerged
+		err = domain.ErrPRMerged
+		return err
+	}
+
+	if prStatus != string(domain.PRStatusOpen) {
+		err = domain.ErrPRNotOpen
+		return err
 	}
 
-	deleteQuery := `DELETE FROM reviewers WHERE pull_request_id = $1 AND reviewer_id = $2`
-	result, err := tx.ExecContext(ctx, deleteQuery, prID, oldReviewerID)
+	before, err := pullrequest_repository.LoadPullRequest(ctx, tx, prID)
 	if err != nil {
-		logger.LogQueryError(deleteQuery, err)
+		return err
+	}
+
+	// Старое назначение остаётся в истории
+	unassignQuery := `
+		UPDATE reviewers
+		SET unassigned_at = NOW(), unassign_reason = $1
+		WHERE pull_request_id = $2 AND reviewer_id = $3 AND unassigned_at IS NULL`
+	result, err := tx.ExecContext(ctx, unassignQuery, string(domain.UnassignReasonManualReassign), prID, oldReviewerID)
+	if err != nil {
+		logger.LogQueryError(unassignQuery, err)
 		return err
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		logger.LogQueryError(deleteQuery, err)
+		logger.LogQueryError(unassignQuery, err)
 		return err
 	}
 
 	if rowsAffected == 0 {
-		return domain.ErrNotAssigned
+		err = domain.ErrNotAssigned
+		return err
 	}
 
 	if newReviewerID != "" {
@@ -70,6 +92,27 @@ func (s *PrReviewersStorage) ReassignReviewer(
 		}
 	}
 
+	if err = stats_repository.InsertReassignment(ctx, tx, prID, oldReviewerID, newReviewerID); err != nil {
+		return err
+	}
+
+	err = outbox_repository.InsertEvent(ctx, tx, domain.EventReviewerReassigned, domain.ReviewerReassignedEvent{
+		PullRequestID: prID,
+		OldReviewerID: oldReviewerID,
+		NewReviewerID: newReviewerID,
+	})
+	if err != nil {
+		return err
+	}
+
+	targetIDs := []string{oldReviewerID}
+	if newReviewerID != "" {
+		targetIDs = append(targetIDs, newReviewerID)
+	}
+	if err = pullrequest_repository.RecordChange(ctx, tx, "ReassignReviewer", prID, targetIDs, before); err != nil {
+		return err
+	}
+
 	if err = tx.Commit(); err != nil {
 		logger.LogTransactionRollback(operation, err)
 		return err
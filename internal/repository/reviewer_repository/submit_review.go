@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	pullrequest_repository "AVITOSAMPISHU/internal/repository/pullrequest_repository"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// SubmitReview сохраняет решение назначенного ревьювера по открытому PR и пишет запись аудита
+func (s *PrReviewersStorage) SubmitReview(
+	ctx context.Context,
+	prID,
+	reviewerID string,
+	verdict domain.ReviewVerdict,
+	comment string,
+) error {
+	operation := "SubmitReview"
+
+	logger.LogTransactionStart(operation)
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.LogTransactionRollback(operation, err)
+		return err
+	}
+	defer func() {
+		if err != nil {
+			logger.LogTransactionRollback(operation, err)
+			_ = tx.Rollback()
+		}
+	}()
+
+	// Блокируем PR, чтобы решение не записалось параллельно со слиянием
+	statusQuery := `SELECT status FROM pull_requests WHERE id = $1 FOR UPDATE`
+	var prStatus string
+	err = tx.QueryRowContext(ctx, statusQuery, prID).Scan(&prStatus)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrNotFound
+		}
+		logger.LogQueryError(statusQuery, err)
+		return err
+	}
+
+	if prStatus == string(domain.PRStatusMerged) {
+		err = domain.ErrPRMerged
+		return err
+	}
+
+	if prStatus != string(domain.PRStatusOpen) {
+		err = domain.ErrPRNotOpen
+		return err
+	}
+
+	before, err := pullrequest_repository.LoadPullRequest(ctx, tx, prID)
+	if err != nil {
+		return err
+	}
+
+	updateQuery := `
+		UPDATE reviewers
+		SET verdict = $1, verdict_comment = NULLIF($2, ''), reviewed_at = NOW()
+		WHERE pull_request_id = $3 AND reviewer_id = $4 AND unassigned_at IS NULL`
+	result, err := tx.ExecContext(ctx, updateQuery, string(verdict), comment, prID, reviewerID)
+	if err != nil {
+		logger.LogQueryError(updateQuery, err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.LogQueryError(updateQuery, err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		err = domain.ErrNotAssigned
+		return err
+	}
+
+	if err = pullrequest_repository.RecordChange(ctx, tx, "SubmitReview", prID, []string{reviewerID}, before); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.LogTransactionRollback(operation, err)
+		return err
+	}
+
+	logger.LogTransactionCommit(operation)
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"AVITOSAMPISHU/internal/config"
 	"AVITOSAMPISHU/internal/domain"
 	"AVITOSAMPISHU/internal/infrastructure/database"
 	pullrequest_repository "AVITOSAMPISHU/internal/repository/pullrequest_repository"
@@ -19,7 +20,10 @@ func setupReviewerTestDB(t *testing.T) *sql.DB {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	db, err := database.NewDB(ctx)
+	cfg, _, err := config.Load("test", nil)
+	require.NoError(t, err)
+
+	db, err := database.NewDBWithConfig(ctx, cfg.DatabaseOptions())
 	require.NoError(t, err)
 
 	cleanupReviewerTestDB(t, db)
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/helpers"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ExportReviews передаёт handle назначения ревьюверов по фильтрам в порядке назначения.
+// Строки читаются из курсора по одной и в памяти не накапливаются; соединение из пула занято,
+// пока handle не обработает последнюю строку. Ошибка handle прерывает выгрузку и возвращается как есть
+func (s *StatsStorage) ExportReviews(
+	ctx context.Context,
+	filter *domain.ExportReviewsReq,
+	handle func(row *domain.ReviewExportRow) error,
+) error {
+	conditions := make([]string, 0, 3)
+	args := make(helpers.QueryArgs, 0, 4)
+
+	if filter.TeamName != "" {
+		conditions = append(conditions, "t.team_name = "+args.Add(filter.TeamName))
+	}
+	if filter.From != nil {
+		conditions = append(conditions, "r.assigned_at >= "+args.Add(helpers.TimestampArg(*filter.From))+"::timestamp")
+	}
+	if filter.To != nil {
+		conditions = append(conditions, "r.assigned_at < "+args.Add(helpers.TimestampArg(*filter.To))+"::timestamp")
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query := fmt.Sprintf(`
+		SELECT r.pull_request_id, pr.author_id, r.reviewer_id, COALESCE(t.team_name, ''),
+			r.assigned_at, r.unassigned_at, COALESCE(r.unassign_reason, ''), pr.merged_at
+		FROM reviewers r
+		JOIN pull_requests pr ON pr.id = r.pull_request_id
+		JOIN users a ON a.id = pr.author_id
+		LEFT JOIN teams t ON t.id = a.team_id
+		%s
+		ORDER BY r.assigned_at, r.pull_request_id, r.reviewer_id, r.id`, where)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.LogQueryError(query, err)
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row domain.ReviewExportRow
+		var assignedAt, unassignedAt, mergedAt sql.NullTime
+		var reason string
+		if err = rows.Scan(&row.PullRequestID, &row.AuthorID, &row.ReviewerID, &row.TeamName,
+			&assignedAt, &unassignedAt, &reason, &mergedAt); err != nil {
+			logger.LogQueryError(query, err)
+			return err
+		}
+
+		row.AssignedAt = utcTime(assignedAt)
+		row.RemovedAt = utcTime(unassignedAt)
+		row.Reason = domain.UnassignReason(reason)
+		row.MergedAt = utcTime(mergedAt)
+
+		if err = handle(&row); err != nil {
+			return err
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.LogQueryError(query, err)
+		return err
+	}
+
+	return nil
+}
+
+// utcTime переводит время из колонки TIMESTAMP без пояса в UTC; NULL — nil
+func utcTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	utc := t.Time.UTC()
+	return &utc
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"database/sql"
+)
+
+// GetLoadSnapshot одним запросом считает нагрузку всех команд и их участников.
+// Команды возвращаются по имени, участники внутри команды — по идентификатору; команда без участников тоже попадает в результат
+func (s *StatsStorage) GetLoadSnapshot(ctx context.Context) ([]domain.TeamLoad, error) {
+	query := `
+		WITH open_reviews AS (
+			SELECT r.reviewer_id, COUNT(*) AS open_reviews
+			FROM reviewers r
+			JOIN pull_requests pr ON pr.id = r.pull_request_id
+			WHERE pr.status = $1 AND r.unassigned_at IS NULL
+			GROUP BY r.reviewer_id
+		), team_prs AS (
+			SELECT a.team_id,
+				COUNT(*) AS open_prs,
+				COUNT(*) FILTER (WHERE pr.need_more_reviewers) AS need_more_reviewers
+			FROM pull_requests pr
+			JOIN users a ON a.id = pr.author_id
+			WHERE pr.status = $1
+			GROUP BY a.team_id
+		)
+		SELECT t.team_name,
+			COALESCE(tp.open_prs, 0),
+			COALESCE(tp.need_more_reviewers, 0),
+			u.id,
+			COALESCE(u.is_active, FALSE),
+			COALESCE(o.open_reviews, 0)
+		FROM teams t
+		LEFT JOIN team_prs tp ON tp.team_id = t.id
+		LEFT JOIN users u ON u.team_id = t.id
+		LEFT JOIN open_reviews o ON o.reviewer_id = u.id
+		ORDER BY t.team_name, u.id`
+
+	rows, err := s.db.QueryContext(ctx, query, string(domain.PRStatusOpen))
+	if err != nil {
+		logger.LogQueryError(query, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	teams := make([]domain.TeamLoad, 0)
+	for rows.Next() {
+		var team domain.TeamLoad
+		var userID sql.NullString
+		var reviewer domain.ReviewerLoad
+		if err = rows.Scan(
+			&team.TeamName,
+			&team.OpenPullRequests,
+			&team.NeedMoreReviewers,
+			&userID,
+			&reviewer.IsActive,
+			&reviewer.OpenReviews,
+		); err != nil {
+			logger.LogQueryError(query, err)
+			return nil, err
+		}
+
+		// Строки одной команды идут подряд, командные значения в них повторяются
+		if len(teams) == 0 || teams[len(teams)-1].TeamName != team.TeamName {
+			teams = append(teams, team)
+		}
+		current := &teams[len(teams)-1]
+
+		if !userID.Valid {
+			continue
+		}
+		reviewer.UserID = userID.String
+		reviewer.TeamName = current.TeamName
+		if reviewer.IsActive {
+			current.ActiveMembers++
+		} else {
+			current.InactiveMembers++
+		}
+		current.Reviewers = append(current.Reviewers, reviewer)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.LogQueryError(query, err)
+		return nil, err
+	}
+
+	return teams, nil
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/helpers"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// GetTeamStats считает аналитику команды за период [from, to) одним запросом.
+// Несуществующая команда — ErrNotFound
+func (s *StatsStorage) GetTeamStats(ctx context.Context, teamName string, from, to time.Time) (*domain.TeamStats, error) {
+	query := `
+		WITH team AS (
+			SELECT id FROM teams WHERE team_name = $1
+		), team_prs AS (
+			SELECT pr.id, pr.status, pr.need_more_reviewers, pr.created_at, pr.merged_at, pr.closed_at
+			FROM pull_requests pr
+			JOIN users a ON a.id = pr.author_id
+			JOIN team ON team.id = a.team_id
+		), first_reviews AS (
+			SELECT p.created_at, MIN(r.reviewed_at) AS reviewed_at
+			FROM team_prs p
+			JOIN reviewers r ON r.pull_request_id = p.id
+			WHERE r.reviewed_at IS NOT NULL
+			GROUP BY p.id, p.created_at
+		), ` + reassignmentsCTE + `
+		SELECT
+			prs.created, prs.merged, prs.closed, prs.open_prs, prs.need_more_reviewers,
+			ttfr.count, ttfr.median, ttfr.p90,
+			ttm.count, ttm.median, ttm.p90,
+			reviews.approved, reviews.changes_requested, reviews.open_reviews,
+			(SELECT COUNT(*) FROM reassignments ra JOIN team_prs p ON p.id = ra.pull_request_id)
+		FROM team
+		CROSS JOIN LATERAL (
+			SELECT
+				COUNT(*) FILTER (WHERE created_at >= $2::timestamp AND created_at < $3::timestamp) AS created,
+				COUNT(*) FILTER (WHERE merged_at >= $2::timestamp AND merged_at < $3::timestamp) AS merged,
+				COUNT(*) FILTER (WHERE closed_at >= $2::timestamp AND closed_at < $3::timestamp) AS closed,
+				COUNT(*) FILTER (WHERE status = $4) AS open_prs,
+				COUNT(*) FILTER (WHERE status = $4 AND need_more_reviewers) AS need_more_reviewers
+			FROM team_prs
+		) prs
+		CROSS JOIN LATERAL (
+			SELECT COUNT(*) AS count,
+				percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM reviewed_at - created_at)) AS median,
+				percentile_cont(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM reviewed_at - created_at)) AS p90
+			FROM first_reviews
+			WHERE reviewed_at >= $2::timestamp AND reviewed_at < $3::timestamp
+		) ttfr
+		CROSS JOIN LATERAL (
+			SELECT COUNT(*) AS count,
+				percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM merged_at - created_at)) AS median,
+				percentile_cont(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM merged_at - created_at)) AS p90
+			FROM team_prs
+			WHERE merged_at >= $2::timestamp AND merged_at < $3::timestamp
+		) ttm
+		CROSS JOIN LATERAL (
+			SELECT
+				COUNT(*) FILTER (WHERE r.verdict = $5 AND r.reviewed_at >= $2::timestamp AND r.reviewed_at < $3::timestamp) AS approved,
+				COUNT(*) FILTER (WHERE r.verdict = $6 AND r.reviewed_at >= $2::timestamp AND r.reviewed_at < $3::timestamp) AS changes_requested,
+				COUNT(*) FILTER (WHERE pr.status = $4 AND r.unassigned_at IS NULL) AS open_reviews
+			FROM reviewers r
+			JOIN users u ON u.id = r.reviewer_id
+			JOIN pull_requests pr ON pr.id = r.pull_request_id
+			WHERE u.team_id = team.id
+		) reviews`
+
+	stats := &domain.TeamStats{TeamName: teamName, From: from, To: to}
+	var (
+		ttfrCount, ttmCount int
+		ttfrMedian, ttfrP90 sql.NullFloat64
+		ttmMedian, ttmP90   sql.NullFloat64
+	)
+	err := s.db.QueryRowContext(ctx, query,
+		teamName,
+		helpers.TimestampArg(from),
+		helpers.TimestampArg(to),
+		string(domain.PRStatusOpen),
+		string(domain.ReviewVerdictApproved),
+		string(domain.ReviewVerdictChangesRequested),
+	).Scan(
+		&stats.PullRequests.Created,
+		&stats.PullRequests.Merged,
+		&stats.PullRequests.Closed,
+		&stats.OpenLoad.OpenPullRequests,
+		&stats.OpenLoad.NeedMoreReviewers,
+		&ttfrCount, &ttfrMedian, &ttfrP90,
+		&ttmCount, &ttmMedian, &ttmP90,
+		&stats.Reviews.Approved,
+		&stats.Reviews.ChangesRequested,
+		&stats.OpenLoad.OpenReviews,
+		&stats.Reassignments,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		logger.LogQueryError(query, err)
+		return nil, err
+	}
+
+	stats.TimeToFirstReview = durationStats(ttfrCount, ttfrMedian, ttfrP90)
+	stats.TimeToMerge = durationStats(ttmCount, ttmMedian, ttmP90)
+
+	return stats, nil
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/helpers"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// GetUserStats считает аналитику пользователя за период [from, to) одним запросом.
+// Несуществующий пользователь — ErrNotFound
+func (s *StatsStorage) GetUserStats(ctx context.Context, userID string, from, to time.Time) (*domain.UserStats, error) {
+	query := `
+		WITH own_prs AS (
+			SELECT pr.id, pr.created_at, pr.merged_at, pr.closed_at
+			FROM pull_requests pr
+			WHERE pr.author_id = $1
+		), first_reviews AS (
+			SELECT p.created_at, MIN(r.reviewed_at) AS reviewed_at
+			FROM own_prs p
+			JOIN reviewers r ON r.pull_request_id = p.id
+			WHERE r.reviewed_at IS NOT NULL
+			GROUP BY p.id, p.created_at
+		), ` + reassignmentsCTE + `
+		SELECT
+			COALESCE(t.team_name, ''),
+			prs.created, prs.merged, prs.closed,
+			ttfr.count, ttfr.median, ttfr.p90,
+			ttm.count, ttm.median, ttm.p90,
+			reviews.approved, reviews.changes_requested, reviews.open_reviews,
+			turnaround.count, turnaround.median, turnaround.p90,
+			(SELECT COUNT(*) FROM reassignments WHERE old_reviewer_id = u.id),
+			(SELECT COUNT(*) FROM reassignments WHERE new_reviewer_id = u.id)
+		FROM users u
+		LEFT JOIN teams t ON t.id = u.team_id
+		CROSS JOIN LATERAL (
+			SELECT
+				COUNT(*) FILTER (WHERE created_at >= $2::timestamp AND created_at < $3::timestamp) AS created,
+				COUNT(*) FILTER (WHERE merged_at >= $2::timestamp AND merged_at < $3::timestamp) AS merged,
+				COUNT(*) FILTER (WHERE closed_at >= $2::timestamp AND closed_at < $3::timestamp) AS closed
+			FROM own_prs
+		) prs
+		CROSS JOIN LATERAL (
+			SELECT COUNT(*) AS count,
+				percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM reviewed_at - created_at)) AS median,
+				percentile_cont(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM reviewed_at - created_at)) AS p90
+			FROM first_reviews
+			WHERE reviewed_at >= $2::timestamp AND reviewed_at < $3::timestamp
+		) ttfr
+		CROSS JOIN LATERAL (
+			SELECT COUNT(*) AS count,
+				percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM merged_at - created_at)) AS median,
+				percentile_cont(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM merged_at - created_at)) AS p90
+			FROM own_prs
+			WHERE merged_at >= $2::timestamp AND merged_at < $3::timestamp
+		) ttm
+		CROSS JOIN LATERAL (
+			SELECT
+				COUNT(*) FILTER (WHERE r.verdict = $5 AND r.reviewed_at >= $2::timestamp AND r.reviewed_at < $3::timestamp) AS approved,
+				COUNT(*) FILTER (WHERE r.verdict = $6 AND r.reviewed_at >= $2::timestamp AND r.reviewed_at < $3::timestamp) AS changes_requested,
+				COUNT(*) FILTER (WHERE pr.status = $4 AND r.unassigned_at IS NULL) AS open_reviews
+			FROM reviewers r
+			JOIN pull_requests pr ON pr.id = r.pull_request_id
+			WHERE r.reviewer_id = u.id
+		) reviews
+		CROSS JOIN LATERAL (
+			SELECT COUNT(*) AS count,
+				percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM r.reviewed_at - r.assigned_at)) AS median,
+				percentile_cont(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM r.reviewed_at - r.assigned_at)) AS p90
+			FROM reviewers r
+			WHERE r.reviewer_id = u.id AND r.reviewed_at >= $2::timestamp AND r.reviewed_at < $3::timestamp
+		) turnaround
+		WHERE u.id = $1`
+
+	stats := &domain.UserStats{UserID: userID, From: from, To: to}
+	var (
+		ttfrCount, ttmCount, turnaroundCount int
+		ttfrMedian, ttfrP90                  sql.NullFloat64
+		ttmMedian, ttmP90                    sql.NullFloat64
+		turnaroundMedian, turnaroundP90      sql.NullFloat64
+	)
+	err := s.db.QueryRowContext(ctx, query,
+		userID,
+		helpers.TimestampArg(from),
+		helpers.TimestampArg(to),
+		string(domain.PRStatusOpen),
+		string(domain.ReviewVerdictApproved),
+		string(domain.ReviewVerdictChangesRequested),
+	).Scan(
+		&stats.TeamName,
+		&stats.PullRequests.Created,
+		&stats.PullRequests.Merged,
+		&stats.PullRequests.Closed,
+		&ttfrCount, &ttfrMedian, &ttfrP90,
+		&ttmCount, &ttmMedian, &ttmP90,
+		&stats.Reviews.Approved,
+		&stats.Reviews.ChangesRequested,
+		&stats.OpenReviews,
+		&turnaroundCount, &turnaroundMedian, &turnaroundP90,
+		&stats.Reassignments.ReassignedAway,
+		&stats.Reassignments.ReassignedIn,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		logger.LogQueryError(query, err)
+		return nil, err
+	}
+
+	stats.TimeToFirstReview = durationStats(ttfrCount, ttfrMedian, ttfrP90)
+	stats.TimeToMerge = durationStats(ttmCount, ttmMedian, ttmP90)
+	stats.ReviewTurnaround = durationStats(turnaroundCount, turnaroundMedian, turnaroundP90)
+
+	return stats, nil
+}
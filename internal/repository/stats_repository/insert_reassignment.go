@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"database/sql"
+)
+
+// InsertReassignment записывает переназначение в журнал для аналитики в транзакции вызывающего репозитория.
+// Пустой newReviewerID — ревьювер снят без замены
+func InsertReassignment(ctx context.Context, tx *sql.Tx, prID, oldReviewerID, newReviewerID string) error {
+	query := `
+		INSERT INTO reviewer_reassignments (pull_request_id, old_reviewer_id, new_reviewer_id, reassigned_at)
+		VALUES ($1, $2, NULLIF($3, ''), NOW())`
+	if _, err := tx.ExecContext(ctx, query, prID, oldReviewerID, newReviewerID); err != nil {
+		logger.LogQueryError(query, err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"database/sql"
+)
+
+// StatsStorage агрегирующие запросы для метрик и аналитики, только чтение
+type StatsStorage struct {
+	db *sql.DB
+}
+
+func NewStatsStorage(db *sql.DB) *StatsStorage {
+	return &StatsStorage{
+		db: db,
+	}
+}
+
+// reassignmentsCTE переназначения ревьюверов за период [$2, $3) из журнала переназначений:
+// ручные и при деактивации участников, new_reviewer_id пуст, если замена не нашлась
+const reassignmentsCTE = `
+	reassignments AS (
+		SELECT pull_request_id, old_reviewer_id, new_reviewer_id
+		FROM reviewer_reassignments
+		WHERE reassigned_at >= $2::timestamp AND reassigned_at < $3::timestamp
+	)`
+
+// durationStats собирает распределение из результата percentile_cont, который без измерений равен NULL
+func durationStats(count int, median, p90 sql.NullFloat64) domain.DurationStats {
+	stats := domain.DurationStats{Count: count}
+	if median.Valid {
+		stats.MedianSeconds = &median.Float64
+	}
+	if p90.Valid {
+		stats.P90Seconds = &p90.Float64
+	}
+	return stats
+}
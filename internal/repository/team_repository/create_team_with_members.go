@@ -2,6 +2,7 @@ package repository
 
 import (
 	"AVITOSAMPISHU/internal/domain"
+	audit_repository "AVITOSAMPISHU/internal/repository/audit_repository"
 	"AVITOSAMPISHU/pkg/logger"
 	"context"
 
@@ -9,10 +10,12 @@ import (
 	"github.com/lib/pq"
 )
 
+// CreateTeamWithMembers создаёт команду с участниками и настройками и пишет запись аудита
 func (s *TeamStorage) CreateTeamWithMembers(
 	ctx context.Context,
 	teamName string,
 	members []domain.TeamMember,
+	settings domain.TeamSettings,
 ) (uuid.UUID, error) {
 	operation := "CreateTeamWithMembers"
 
@@ -49,6 +52,37 @@ func (s *TeamStorage) CreateTeamWithMembers(
 		}
 	}
 
+	settingsQuery := `
+		INSERT INTO team_settings (team_id, required_reviewers, min_reviewers, selection_strategy, allow_cross_team_fallback, required_approvals)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err = tx.ExecContext(ctx, settingsQuery,
+		teamID,
+		settings.RequiredReviewers,
+		settings.MinReviewers,
+		string(settings.SelectionStrategy),
+		settings.AllowCrossTeamFallback,
+		settings.RequiredApprovals,
+	)
+	if err != nil {
+		logger.LogQueryError(settingsQuery, err)
+		return uuid.Nil, err
+	}
+
+	memberIDs := make([]string, 0, len(members))
+	for _, member := range members {
+		memberIDs = append(memberIDs, member.UserID)
+	}
+	err = audit_repository.InsertEvent(ctx, tx, domain.AuditChange{
+		Operation:  "CreateTeam",
+		EntityType: domain.AuditEntityTeam,
+		EntityID:   teamName,
+		TargetIDs:  memberIDs,
+		After:      &domain.Team{TeamName: teamName, Members: members, Settings: &settings},
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
 	if err = tx.Commit(); err != nil {
 		logger.LogTransactionRollback(operation, err)
 		return uuid.Nil, err
@@ -46,6 +46,12 @@ func TestTeamStorage_CreateTeamWithMembers(t *testing.T) {
 				mock.ExpectExec(`INSERT INTO users`).
 					WithArgs("user2", "User2", teamID, true).
 					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`INSERT INTO team_settings`).
+					WithArgs(teamID, 2, 1, "random", false, 0).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`INSERT INTO audit_events`).
+					WithArgs(sqlmock.AnyArg(), "CreateTeam", "team", "team1", sqlmock.AnyArg(), nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectCommit()
 			},
 			wantErr:   nil,
@@ -99,7 +105,7 @@ func TestTeamStorage_CreateTeamWithMembers(t *testing.T) {
 			tt.setup(mock)
 
 			repo := NewTeamStorage(db)
-			got, err := repo.CreateTeamWithMembers(context.Background(), tt.teamName, tt.members)
+			got, err := repo.CreateTeamWithMembers(context.Background(), tt.teamName, tt.members, domain.DefaultTeamSettings())
 
 			if tt.wantErr != nil {
 				assert.Error(t, err)
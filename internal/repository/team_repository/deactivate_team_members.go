@@ -2,12 +2,18 @@ package repository
 
 import (
 	"AVITOSAMPISHU/internal/domain"
+	audit_repository "AVITOSAMPISHU/internal/repository/audit_repository"
+	outbox_repository "AVITOSAMPISHU/internal/repository/outbox_repository"
+	stats_repository "AVITOSAMPISHU/internal/repository/stats_repository"
 	"AVITOSAMPISHU/pkg/logger"
 	"context"
+	"database/sql"
 
 	"github.com/lib/pq"
 )
 
+// DeactivateTeamMembers деактивирует участников команды (всех, если userIDs пуст), применяет переназначения
+// ревьюверов, пишет событие team_members_deactivated и запись аудита с командой до изменения
 func (s *TeamStorage) DeactivateTeamMembers(
 	ctx context.Context,
 	teamName string,
@@ -29,6 +35,11 @@ func (s *TeamStorage) DeactivateTeamMembers(
 		}
 	}()
 
+	before, err := getTeamByName(ctx, tx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
 		UPDATE users u
 		SET is_active = false
@@ -71,11 +82,32 @@ func (s *TeamStorage) DeactivateTeamMembers(
 		return nil, err
 	}
 
+	if err = lockReassignedPullRequests(ctx, tx, reassignments); err != nil {
+		return nil, err
+	}
+
 	for _, reassignment := range reassignments {
-		deleteQuery := `DELETE FROM reviewers WHERE pull_request_id = $1 AND reviewer_id = $2`
-		_, err = tx.ExecContext(ctx, deleteQuery, reassignment.PrID, reassignment.OldReviewerID)
+		unassignQuery := `
+			UPDATE reviewers
+			SET unassigned_at = NOW(), unassign_reason = $1
+			WHERE pull_request_id = $2 AND reviewer_id = $3 AND unassigned_at IS NULL`
+		var result sql.Result
+		result, err = tx.ExecContext(ctx, unassignQuery, string(domain.UnassignReasonDeactivation), reassignment.PrID, reassignment.OldReviewerID)
 		if err != nil {
-			logger.LogQueryError(deleteQuery, err)
+			logger.LogQueryError(unassignQuery, err)
+			return nil, err
+		}
+
+		var rowsAffected int64
+		rowsAffected, err = result.RowsAffected()
+		if err != nil {
+			logger.LogQueryError(unassignQuery, err)
+			return nil, err
+		}
+
+		// План строился вне транзакции: ревьювера могли уже снять или переназначить
+		if rowsAffected != 1 {
+			err = domain.ErrNotAssigned
 			return nil, err
 		}
 
@@ -87,6 +119,32 @@ func (s *TeamStorage) DeactivateTeamMembers(
 				return nil, err
 			}
 		}
+
+		err = stats_repository.InsertReassignment(ctx, tx, reassignment.PrID, reassignment.OldReviewerID, reassignment.NewReviewerID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	deactivated := domain.TeamMembersDeactivatedEvent{
+		TeamName:           teamName,
+		DeactivatedUserIDs: deactivatedIDs,
+		Reassignments:      reassignments,
+	}
+	if err = outbox_repository.InsertEvent(ctx, tx, domain.EventTeamMembersDeactivated, deactivated); err != nil {
+		return nil, err
+	}
+
+	err = audit_repository.InsertEvent(ctx, tx, domain.AuditChange{
+		Operation:  "DeactivateTeamMembers",
+		EntityType: domain.AuditEntityTeam,
+		EntityID:   teamName,
+		TargetIDs:  deactivatedIDs,
+		Before:     before,
+		After:      deactivated,
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	if err = tx.Commit(); err != nil {
@@ -97,3 +155,54 @@ func (s *TeamStorage) DeactivateTeamMembers(
 	logger.LogTransactionCommit(operation)
 	return deactivatedIDs, nil
 }
+
+// lockReassignedPullRequests блокирует PR из плана переназначений, чтобы оно не прошло параллельно со слиянием
+// или закрытием, и проверяет, что они всё ещё открыты или в черновике. PR блокируются в порядке id,
+// поэтому параллельные деактивации не взаимоблокируются
+func lockReassignedPullRequests(ctx context.Context, tx *sql.Tx, reassignments []domain.ReviewerReassignment) error {
+	if len(reassignments) == 0 {
+		return nil
+	}
+
+	prIDs := make([]string, 0, len(reassignments))
+	for _, reassignment := range reassignments {
+		prIDs = append(prIDs, reassignment.PrID)
+	}
+
+	query := `SELECT id, status FROM pull_requests WHERE id = ANY($1) ORDER BY id FOR UPDATE`
+	rows, err := tx.QueryContext(ctx, query, pq.Array(prIDs))
+	if err != nil {
+		logger.LogQueryError(query, err)
+		return err
+	}
+	defer rows.Close()
+
+	statuses := make(map[string]domain.PRStatus, len(prIDs))
+	for rows.Next() {
+		var prID, status string
+		if err = rows.Scan(&prID, &status); err != nil {
+			logger.LogQueryError(query, err)
+			return err
+		}
+		statuses[prID] = domain.PRStatus(status)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.LogQueryError(query, err)
+		return err
+	}
+
+	for _, prID := range prIDs {
+		status, ok := statuses[prID]
+		switch {
+		case !ok:
+			return domain.ErrNotFound
+		case status == domain.PRStatusMerged:
+			return domain.ErrPRMerged
+		case status != domain.PRStatusOpen && status != domain.PRStatusDraft:
+			return domain.ErrPRNotOpen
+		}
+	}
+
+	return nil
+}
@@ -34,12 +34,15 @@ func TestTeamStorage_DeactivateTeamMembers(t *testing.T) {
 			reassignments: []domain.ReviewerReassignment{},
 			setup: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
+				expectTeamMembers(mock, "team1")
 				rows := sqlmock.NewRows([]string{"id"}).
 					AddRow("user1").
 					AddRow("user2")
 				mock.ExpectQuery(`UPDATE users u`).
 					WithArgs("team1", pq.Array([]string{"user1", "user2"})).
 					WillReturnRows(rows)
+				mock.ExpectExec("INSERT INTO outbox").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO audit_events").WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectCommit()
 			},
 			want:    []string{"user1", "user2"},
@@ -54,16 +57,23 @@ func TestTeamStorage_DeactivateTeamMembers(t *testing.T) {
 			},
 			setup: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
+				expectTeamMembers(mock, "team1")
 				rows := sqlmock.NewRows([]string{"id"}).AddRow("user1")
 				mock.ExpectQuery(`UPDATE users u`).
 					WithArgs("team1", pq.Array([]string{"user1"})).
 					WillReturnRows(rows)
-				mock.ExpectExec(`DELETE FROM reviewers`).
-					WithArgs("pr1", "user1").
+				expectLockedPullRequest(mock, "pr1", domain.PRStatusOpen)
+				mock.ExpectExec(`UPDATE reviewers`).
+					WithArgs(string(domain.UnassignReasonDeactivation), "pr1", "user1").
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectExec(`INSERT INTO reviewers`).
 					WithArgs("pr1", "user3").
 					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO reviewer_reassignments").
+					WithArgs("pr1", "user1", "user3").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO outbox").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO audit_events").WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectCommit()
 			},
 			want:    []string{"user1"},
@@ -76,6 +86,7 @@ func TestTeamStorage_DeactivateTeamMembers(t *testing.T) {
 			reassignments: []domain.ReviewerReassignment{},
 			setup: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
+				expectTeamMembers(mock, "team1")
 				mock.ExpectQuery(`UPDATE users u`).
 					WithArgs("team1", pq.Array([]string{"user1"})).
 					WillReturnError(sql.ErrConnDone)
@@ -93,12 +104,14 @@ func TestTeamStorage_DeactivateTeamMembers(t *testing.T) {
 			},
 			setup: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
+				expectTeamMembers(mock, "team1")
 				rows := sqlmock.NewRows([]string{"id"}).AddRow("user1")
 				mock.ExpectQuery(`UPDATE users u`).
 					WithArgs("team1", pq.Array([]string{"user1"})).
 					WillReturnRows(rows)
-				mock.ExpectExec(`DELETE FROM reviewers`).
-					WithArgs("pr1", "user1").
+				expectLockedPullRequest(mock, "pr1", domain.PRStatusOpen)
+				mock.ExpectExec(`UPDATE reviewers`).
+					WithArgs(string(domain.UnassignReasonDeactivation), "pr1", "user1").
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				pqErr := &pq.Error{Code: "23503"}
 				mock.ExpectExec(`INSERT INTO reviewers`).
@@ -109,6 +122,47 @@ func TestTeamStorage_DeactivateTeamMembers(t *testing.T) {
 			want:    nil,
 			wantErr: &pq.Error{Code: "23503"},
 		},
+		{
+			name:     "pull request merged after planning",
+			teamName: "team1",
+			userIDs:  []string{"user1"},
+			reassignments: []domain.ReviewerReassignment{
+				{PrID: "pr1", OldReviewerID: "user1", NewReviewerID: "user3"},
+			},
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectTeamMembers(mock, "team1")
+				mock.ExpectQuery(`UPDATE users u`).
+					WithArgs("team1", pq.Array([]string{"user1"})).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("user1"))
+				expectLockedPullRequest(mock, "pr1", domain.PRStatusMerged)
+				mock.ExpectRollback()
+			},
+			want:    nil,
+			wantErr: domain.ErrPRMerged,
+		},
+		{
+			name:     "reviewer already unassigned",
+			teamName: "team1",
+			userIDs:  []string{"user1"},
+			reassignments: []domain.ReviewerReassignment{
+				{PrID: "pr1", OldReviewerID: "user1", NewReviewerID: "user3"},
+			},
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectTeamMembers(mock, "team1")
+				mock.ExpectQuery(`UPDATE users u`).
+					WithArgs("team1", pq.Array([]string{"user1"})).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("user1"))
+				expectLockedPullRequest(mock, "pr1", domain.PRStatusOpen)
+				mock.ExpectExec(`UPDATE reviewers`).
+					WithArgs(string(domain.UnassignReasonDeactivation), "pr1", "user1").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			want:    nil,
+			wantErr: domain.ErrNotAssigned,
+		},
 	}
 
 	for _, tt := range tests {
@@ -143,3 +197,20 @@ func TestTeamStorage_DeactivateTeamMembers(t *testing.T) {
 		})
 	}
 }
+
+// expectTeamMembers ожидает чтение состояния команды до деактивации для аудита
+func expectTeamMembers(mock sqlmock.Sqlmock, teamName string) {
+	rows := sqlmock.NewRows([]string{"id", "username", "is_active", "required_reviewers", "min_reviewers", "selection_strategy", "allow_cross_team_fallback", "required_approvals"}).
+		AddRow("user1", "User1", true, 2, 1, "random", false, 0).
+		AddRow("user2", "User2", true, 2, 1, "random", false, 0)
+	mock.ExpectQuery(`SELECT u.id, u.username, u.is_active`).
+		WithArgs(teamName, 2, 1, "random").
+		WillReturnRows(rows)
+}
+
+// expectLockedPullRequest ожидает блокировку PR из плана переназначений
+func expectLockedPullRequest(mock sqlmock.Sqlmock, prID string, status domain.PRStatus) {
+	mock.ExpectQuery(`SELECT id, status FROM pull_requests`).
+		WithArgs(pq.Array([]string{prID})).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(prID, string(status)))
+}
@@ -2,20 +2,38 @@ package repository
 
 import (
 	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/helpers"
 	"AVITOSAMPISHU/pkg/logger"
 	"context"
 	"database/sql"
 )
 
 func (s *TeamStorage) GetTeamByName(ctx context.Context, teamName string) (*domain.Team, error) {
+	return getTeamByName(ctx, s.db, teamName)
+}
+
+// getTeamByName читает команду с участниками и настройками через q: БД или транзакцию
+func getTeamByName(ctx context.Context, q helpers.Querier, teamName string) (*domain.Team, error) {
+	defaults := domain.DefaultTeamSettings()
 	query := `
-		SELECT u.id, u.username, u.is_active
+		SELECT u.id, u.username, u.is_active,
+			COALESCE(ts.required_reviewers, $2),
+			COALESCE(ts.min_reviewers, $3),
+			COALESCE(ts.selection_strategy, $4),
+			COALESCE(ts.allow_cross_team_fallback, false),
+			COALESCE(ts.required_approvals, 0)
 		FROM teams t
 		LEFT JOIN users u ON t.id = u.team_id
+		LEFT JOIN team_settings ts ON t.id = ts.team_id
 		WHERE t.team_name = $1
 		ORDER BY u.username`
 
-	rows, err := s.db.QueryContext(ctx, query, teamName)
+	rows, err := q.QueryContext(ctx, query,
+		teamName,
+		defaults.RequiredReviewers,
+		defaults.MinReviewers,
+		string(defaults.SelectionStrategy),
+	)
 	if err != nil {
 		logger.LogQueryError(query, err)
 		return nil, err
@@ -24,13 +42,18 @@ func (s *TeamStorage) GetTeamByName(ctx context.Context, teamName string) (*doma
 
 	members := make([]domain.TeamMember, 0, 10)
 	var teamExists bool
+	var settings domain.TeamSettings
+	var strategy string
 
 	for rows.Next() {
 		var userID sql.NullString
 		var username sql.NullString
 		var isActive sql.NullBool
 
-		if err = rows.Scan(&userID, &username, &isActive); err != nil {
+		if err = rows.Scan(
+			&userID, &username, &isActive,
+			&settings.RequiredReviewers, &settings.MinReviewers, &strategy, &settings.AllowCrossTeamFallback, &settings.RequiredApprovals,
+		); err != nil {
 			logger.LogQueryError(query, err)
 			return nil, err
 		}
@@ -55,8 +78,11 @@ func (s *TeamStorage) GetTeamByName(ctx context.Context, teamName string) (*doma
 		return nil, domain.ErrNotFound
 	}
 
+	settings.SelectionStrategy = domain.SelectionStrategy(strategy)
+
 	return &domain.Team{
 		TeamName: teamName,
 		Members:  members,
+		Settings: &settings,
 	}, nil
 }
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/helpers"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// GetTeamSettings возвращает настройки команды. Для команд без строки в team_settings
+// возвращаются значения по умолчанию.
+func (s *TeamStorage) GetTeamSettings(ctx context.Context, teamName string) (*domain.TeamSettings, error) {
+	return getTeamSettings(ctx, s.db, teamName, "")
+}
+
+// getTeamSettings читает настройки команды через q: БД или транзакцию. lock дописывается к запросу,
+// например FOR UPDATE OF t, чтобы заблокировать команду до конца транзакции
+func getTeamSettings(ctx context.Context, q helpers.Querier, teamName, lock string) (*domain.TeamSettings, error) {
+	defaults := domain.DefaultTeamSettings()
+	query := `
+		SELECT
+			COALESCE(ts.required_reviewers, $2),
+			COALESCE(ts.min_reviewers, $3),
+			COALESCE(ts.selection_strategy, $4),
+			COALESCE(ts.allow_cross_team_fallback, false),
+			COALESCE(ts.required_approvals, 0),
+			ts.updated_at
+		FROM teams t
+		LEFT JOIN team_settings ts ON t.id = ts.team_id
+		WHERE t.team_name = $1 ` + lock
+
+	var settings domain.TeamSettings
+	var strategy string
+	var updatedAt sql.NullTime
+
+	err := q.QueryRowContext(ctx, query,
+		teamName,
+		defaults.RequiredReviewers,
+		defaults.MinReviewers,
+		string(defaults.SelectionStrategy),
+	).Scan(&settings.RequiredReviewers, &settings.MinReviewers, &strategy, &settings.AllowCrossTeamFallback, &settings.RequiredApprovals, &updatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		logger.LogQueryError(query, err)
+		return nil, err
+	}
+
+	settings.SelectionStrategy = domain.SelectionStrategy(strategy)
+	if updatedAt.Valid {
+		settings.UpdatedAt = &updatedAt.Time
+	}
+
+	return &settings, nil
+}
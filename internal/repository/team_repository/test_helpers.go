@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"AVITOSAMPISHU/internal/config"
 	"AVITOSAMPISHU/internal/infrastructure/database"
 
 	"github.com/stretchr/testify/require"
@@ -17,7 +18,10 @@ func setupTeamTestDB(t *testing.T) *sql.DB {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	db, err := database.NewDB(ctx)
+	cfg, _, err := config.Load("test", nil)
+	require.NoError(t, err)
+
+	db, err := database.NewDBWithConfig(ctx, cfg.DatabaseOptions())
 	require.NoError(t, err)
 
 	cleanupTeamTestDB(t, db)
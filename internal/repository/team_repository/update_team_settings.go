@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	audit_repository "AVITOSAMPISHU/internal/repository/audit_repository"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// UpdateTeamSettings сохраняет настройки команды, создавая строку в team_settings при её отсутствии,
+// и пишет запись аудита с настройками до и после изменения
+func (s *TeamStorage) UpdateTeamSettings(ctx context.Context, teamName string, settings *domain.TeamSettings) error {
+	operation := "UpdateTeamSettings"
+
+	logger.LogTransactionStart(operation)
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.LogTransactionRollback(operation, err)
+		return err
+	}
+	defer func() {
+		if err != nil {
+			logger.LogTransactionRollback(operation, err)
+			_ = tx.Rollback()
+		}
+	}()
+
+	before, err := getTeamSettings(ctx, tx, teamName, "FOR UPDATE OF t")
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO team_settings (team_id, required_reviewers, min_reviewers, selection_strategy, allow_cross_team_fallback, required_approvals, updated_at)
+		SELECT t.id, $2, $3, $4, $5, $6, NOW()
+		FROM teams t
+		WHERE t.team_name = $1
+		ON CONFLICT (team_id) DO UPDATE SET
+			required_reviewers = EXCLUDED.required_reviewers,
+			min_reviewers = EXCLUDED.min_reviewers,
+			selection_strategy = EXCLUDED.selection_strategy,
+			allow_cross_team_fallback = EXCLUDED.allow_cross_team_fallback,
+			required_approvals = EXCLUDED.required_approvals,
+			updated_at = EXCLUDED.updated_at
+		RETURNING updated_at`
+
+	var updatedAt time.Time
+	err = tx.QueryRowContext(ctx, query,
+		teamName,
+		settings.RequiredReviewers,
+		settings.MinReviewers,
+		string(settings.SelectionStrategy),
+		settings.AllowCrossTeamFallback,
+		settings.RequiredApprovals,
+	).Scan(&updatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = domain.ErrNotFound
+			return err
+		}
+		logger.LogQueryError(query, err)
+		return err
+	}
+
+	settings.UpdatedAt = &updatedAt
+
+	err = audit_repository.InsertEvent(ctx, tx, domain.AuditChange{
+		Operation:  operation,
+		EntityType: domain.AuditEntityTeam,
+		EntityID:   teamName,
+		Before:     before,
+		After:      settings,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.LogTransactionRollback(operation, err)
+		return err
+	}
+
+	logger.LogTransactionCommit(operation)
+	return nil
+}
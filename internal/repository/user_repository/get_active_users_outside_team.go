@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+)
+
+// GetActiveUsersOutsideTeam возвращает активных пользователей всех команд, кроме указанной.
+// Используется для добора ревьюверов из других команд.
+func (r *UserRepository) GetActiveUsersOutsideTeam(ctx context.Context, teamName string) ([]domain.TeamMember, error) {
+	query := `
+		SELECT u.id, u.username
+		FROM users u
+		JOIN teams t ON u.team_id = t.id
+		WHERE u.is_active = true AND t.team_name != $1
+		ORDER BY u.id`
+
+	rows, err := r.db.QueryContext(ctx, query, teamName)
+	if err != nil {
+		logger.LogQueryError(query, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := make([]domain.TeamMember, 0, 20)
+	for rows.Next() {
+		var userID string
+		var username string
+		if err = rows.Scan(&userID, &username); err != nil {
+			logger.LogQueryError(query, err)
+			return nil, err
+		}
+		users = append(users, domain.TeamMember{
+			UserID:   userID,
+			Username: username,
+			IsActive: true,
+		})
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.LogQueryError(query, err)
+		return nil, err
+	}
+
+	return users, nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/helpers"
 	"AVITOSAMPISHU/pkg/logger"
 	"context"
 	"database/sql"
@@ -9,6 +10,12 @@ import (
 )
 
 func (r *UserRepository) GetUserByID(ctx context.Context, userID string) (*domain.User, error) {
+	return getUserByID(ctx, r.db, userID, "")
+}
+
+// getUserByID читает пользователя через q: БД или транзакцию. lock дописывается к запросу,
+// например FOR UPDATE OF u, чтобы заблокировать пользователя до конца транзакции
+func getUserByID(ctx context.Context, q helpers.Querier, userID, lock string) (*domain.User, error) {
 	var username string
 	var teamName string
 	var isActive bool
@@ -17,9 +24,9 @@ func (r *UserRepository) GetUserByID(ctx context.Context, userID string) (*domai
 		SELECT u.username, t.team_name, u.is_active
 		FROM users u
 		LEFT JOIN teams t ON u.team_id = t.id
-		WHERE u.id = $1`
+		WHERE u.id = $1 ` + lock
 
-	err := r.db.QueryRowContext(ctx, query, userID).Scan(&username, &teamName, &isActive)
+	err := q.QueryRowContext(ctx, query, userID).Scan(&username, &teamName, &isActive)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.ErrNotFound
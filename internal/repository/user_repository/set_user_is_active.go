@@ -2,31 +2,61 @@ package repository
 
 import (
 	"AVITOSAMPISHU/internal/domain"
+	audit_repository "AVITOSAMPISHU/internal/repository/audit_repository"
 	"AVITOSAMPISHU/pkg/logger"
 	"context"
 )
 
+// SetUserIsActive меняет флаг активности пользователя и пишет запись аудита с пользователем до и после изменения
 func (r *UserRepository) SetUserIsActive(ctx context.Context, userID string, isActive bool) error {
+	operation := "SetIsActive"
+
+	logger.LogTransactionStart(operation)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.LogTransactionRollback(operation, err)
+		return err
+	}
+	defer func() {
+		if err != nil {
+			logger.LogTransactionRollback(operation, err)
+			_ = tx.Rollback()
+		}
+	}()
+
+	before, err := getUserByID(ctx, tx, userID, "FOR UPDATE OF u")
+	if err != nil {
+		return err
+	}
+
 	query := `
 		UPDATE users 
 		SET is_active = $1 
 		WHERE id = $2`
 
-	result, err := r.db.ExecContext(ctx, query, isActive, userID)
-	if err != nil {
+	if _, err = tx.ExecContext(ctx, query, isActive, userID); err != nil {
 		logger.LogQueryError(query, err)
 		return err
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	after := *before
+	after.IsActive = isActive
+	err = audit_repository.InsertEvent(ctx, tx, domain.AuditChange{
+		Operation:  operation,
+		EntityType: domain.AuditEntityUser,
+		EntityID:   userID,
+		Before:     before,
+		After:      &after,
+	})
 	if err != nil {
-		logger.LogQueryError(query, err)
 		return err
 	}
 
-	if rowsAffected == 0 {
-		return domain.ErrNotFound
+	if err = tx.Commit(); err != nil {
+		logger.LogTransactionRollback(operation, err)
+		return err
 	}
 
+	logger.LogTransactionCommit(operation)
 	return nil
 }
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"time"
+)
+
+// ClaimDueDeliveries забирает до limit доставок в статусе PENDING, очередная попытка которых просрочена больше чем
+// на overdue: процесс, который ими занимался, остановился или упал. Забранным доставкам next_attempt_at сдвигается
+// на текущее время, поэтому другой экземпляр сервиса возьмёт их снова не раньше чем через overdue
+func (s *WebhookStorage) ClaimDueDeliveries(ctx context.Context, overdue time.Duration, limit int) ([]domain.WebhookDelivery, error) {
+	query := `
+		WITH due AS (
+			SELECT id
+			FROM webhook_deliveries
+			WHERE status = $1 AND COALESCE(next_attempt_at, created_at) <= NOW() - make_interval(secs => $2)
+			ORDER BY COALESCE(next_attempt_at, created_at)
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE webhook_deliveries
+		SET next_attempt_at = NOW()
+		WHERE id IN (SELECT id FROM due)
+		RETURNING ` + deliveryColumns
+
+	rows, err := s.db.QueryContext(ctx, query, string(domain.WebhookDeliveryPending), overdue.Seconds(), limit)
+	if err != nil {
+		logger.LogQueryError(query, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := make([]domain.WebhookDelivery, 0, limit)
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			logger.LogQueryError(query, err)
+			return nil, err
+		}
+		deliveries = append(deliveries, *delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.LogQueryError(query, err)
+		return nil, err
+	}
+
+	return deliveries, nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// CreateDeliveries записывает доставки одного события в одной транзакции: либо все, либо ни одной.
+// Доставка, которая для этой подписки и события уже есть в журнале, пропускается по уникальному индексу —
+// так повтор события из outbox не создаёт дубликатов, даже если два экземпляра сервиса обрабатывают его одновременно. Возвращает только записанные доставки с проставленным временем создания
+func (s *WebhookStorage) CreateDeliveries(ctx context.Context, deliveries []*domain.WebhookDelivery) ([]*domain.WebhookDelivery, error) {
+	operation := "CreateDeliveries"
+
+	logger.LogTransactionStart(operation)
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.LogTransactionRollback(operation, err)
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			logger.LogTransactionRollback(operation, err)
+			_ = tx.Rollback()
+		}
+	}()
+
+	// next_attempt_at = NOW(): доставка сразу считается запланированной, и если процесс упадёт до первой попытки,
+	// её подберёт ResumeDeliveries
+	query := `
+		INSERT INTO webhook_deliveries (id, subscription_id, event_id, event_type, payload, status, next_attempt_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		ON CONFLICT (subscription_id, event_id) WHERE replay_of IS NULL DO NOTHING
+		RETURNING created_at, next_attempt_at`
+
+	created := make([]*domain.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		var createdAt, nextAttemptAt time.Time
+		err = tx.QueryRowContext(ctx, query,
+			delivery.ID,
+			delivery.SubscriptionID,
+			delivery.EventID,
+			string(delivery.EventType),
+			string(delivery.Payload),
+			string(delivery.Status),
+		).Scan(&createdAt, &nextAttemptAt)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil
+			continue
+		}
+		if err != nil {
+			logger.LogQueryError(query, err)
+			return nil, err
+		}
+
+		delivery.CreatedAt = &createdAt
+		delivery.NextAttemptAt = &nextAttemptAt
+		created = append(created, delivery)
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.LogTransactionRollback(operation, err)
+		return nil, err
+	}
+
+	logger.LogTransactionCommit(operation)
+	return created, nil
+}
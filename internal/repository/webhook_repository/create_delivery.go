@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	audit_repository "AVITOSAMPISHU/internal/repository/audit_repository"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"time"
+)
+
+// CreateDelivery добавляет запись в журнал доставок, проставляет ей время создания и пишет запись аудита.
+// Используется для ручного повтора доставки, доставки событий записываются через CreateDeliveries
+func (s *WebhookStorage) CreateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	operation := "ReplayDelivery"
+
+	logger.LogTransactionStart(operation)
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.LogTransactionRollback(operation, err)
+		return err
+	}
+	defer func() {
+		if err != nil {
+			logger.LogTransactionRollback(operation, err)
+			_ = tx.Rollback()
+		}
+	}()
+
+	query := `
+		INSERT INTO webhook_deliveries (id, subscription_id, event_id, event_type, payload, status, next_attempt_at, replay_of)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW(), $7)
+		RETURNING created_at, next_attempt_at`
+
+	var createdAt, nextAttemptAt time.Time
+	err = tx.QueryRowContext(ctx, query,
+		delivery.ID,
+		delivery.SubscriptionID,
+		delivery.EventID,
+		string(delivery.EventType),
+		string(delivery.Payload),
+		string(delivery.Status),
+		delivery.ReplayOf,
+	).Scan(&createdAt, &nextAttemptAt)
+	if err != nil {
+		logger.LogQueryError(query, err)
+		return err
+	}
+
+	delivery.CreatedAt = &createdAt
+	delivery.NextAttemptAt = &nextAttemptAt
+
+	err = audit_repository.InsertEvent(ctx, tx, domain.AuditChange{
+		Operation:  operation,
+		EntityType: domain.AuditEntityWebhook,
+		EntityID:   delivery.SubscriptionID.String(),
+		TargetIDs:  []string{delivery.ID.String()},
+		After:      delivery,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.LogTransactionRollback(operation, err)
+		return err
+	}
+
+	logger.LogTransactionCommit(operation)
+	return nil
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	audit_repository "AVITOSAMPISHU/internal/repository/audit_repository"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"time"
+)
+
+// CreateSubscription сохраняет подписку, проставляет ей время создания и пишет запись аудита без секрета
+func (s *WebhookStorage) CreateSubscription(ctx context.Context, sub *domain.WebhookSubscription) error {
+	operation := "CreateWebhook"
+
+	logger.LogTransactionStart(operation)
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.LogTransactionRollback(operation, err)
+		return err
+	}
+	defer func() {
+		if err != nil {
+			logger.LogTransactionRollback(operation, err)
+			_ = tx.Rollback()
+		}
+	}()
+
+	query := `
+		INSERT INTO webhook_subscriptions (id, url, secret, events, is_active)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at`
+
+	var createdAt time.Time
+	if err = tx.QueryRowContext(ctx, query, sub.ID, sub.URL, sub.Secret, eventsArg(sub.Events), sub.IsActive).Scan(&createdAt); err != nil {
+		logger.LogQueryError(query, err)
+		return err
+	}
+
+	sub.CreatedAt = &createdAt
+
+	after := *sub
+	after.Secret = ""
+	err = audit_repository.InsertEvent(ctx, tx, domain.AuditChange{
+		Operation:  operation,
+		EntityType: domain.AuditEntityWebhook,
+		EntityID:   sub.ID.String(),
+		After:      &after,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.LogTransactionRollback(operation, err)
+		return err
+	}
+
+	logger.LogTransactionCommit(operation)
+	return nil
+}
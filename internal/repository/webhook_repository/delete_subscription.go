@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	audit_repository "AVITOSAMPISHU/internal/repository/audit_repository"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// DeleteSubscription удаляет подписку вместе с журналом её доставок и пишет запись аудита с удалённой подпиской без секрета
+func (s *WebhookStorage) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	operation := "DeleteWebhook"
+
+	logger.LogTransactionStart(operation)
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.LogTransactionRollback(operation, err)
+		return err
+	}
+	defer func() {
+		if err != nil {
+			logger.LogTransactionRollback(operation, err)
+			_ = tx.Rollback()
+		}
+	}()
+
+	query := `DELETE FROM webhook_subscriptions WHERE id = $1 RETURNING ` + subscriptionColumns
+
+	before, err := scanSubscription(tx.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = domain.ErrNotFound
+			return err
+		}
+		logger.LogQueryError(query, err)
+		return err
+	}
+
+	before.Secret = ""
+	err = audit_repository.InsertEvent(ctx, tx, domain.AuditChange{
+		Operation:  operation,
+		EntityType: domain.AuditEntityWebhook,
+		EntityID:   id.String(),
+		Before:     before,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.LogTransactionRollback(operation, err)
+		return err
+	}
+
+	logger.LogTransactionCommit(operation)
+	return nil
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// ListDeliveries возвращает последние limit доставок подписки, от новых к старым
+func (s *WebhookStorage) ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]domain.WebhookDelivery, error) {
+	query := `
+		SELECT ` + deliveryColumns + `
+		FROM webhook_deliveries
+		WHERE subscription_id = $1
+		ORDER BY created_at DESC, id
+		LIMIT $2`
+
+	rows, err := s.db.QueryContext(ctx, query, subscriptionID, limit)
+	if err != nil {
+		logger.LogQueryError(query, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := make([]domain.WebhookDelivery, 0, limit)
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			logger.LogQueryError(query, err)
+			return nil, err
+		}
+		deliveries = append(deliveries, *delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.LogQueryError(query, err)
+		return nil, err
+	}
+
+	return deliveries, nil
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+)
+
+// ListSubscriptions возвращает все подписки. Если eventType не пуст, только активные подписки на это событие
+func (s *WebhookStorage) ListSubscriptions(ctx context.Context, eventType domain.EventType) ([]domain.WebhookSubscription, error) {
+	query := `SELECT ` + subscriptionColumns + ` FROM webhook_subscriptions`
+	args := make([]interface{}, 0, 1)
+	if eventType != "" {
+		query += ` WHERE is_active AND $1 = ANY(events)`
+		args = append(args, string(eventType))
+	}
+	query += ` ORDER BY created_at`
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.LogQueryError(query, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	subs := make([]domain.WebhookSubscription, 0, 8)
+	for rows.Next() {
+		sub, err := scanSubscription(rows)
+		if err != nil {
+			logger.LogQueryError(query, err)
+			return nil, err
+		}
+		subs = append(subs, *sub)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.LogQueryError(query, err)
+		return nil, err
+	}
+
+	return subs, nil
+}
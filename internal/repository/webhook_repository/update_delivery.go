@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+)
+
+// UpdateDelivery сохраняет результат очередной попытки доставки
+func (s *WebhookStorage) UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, last_status_code = $4, last_error = NULLIF($5, ''),
+			next_attempt_at = $6, delivered_at = $7
+		WHERE id = $1`
+
+	res, err := s.db.ExecContext(ctx, query,
+		delivery.ID,
+		string(delivery.Status),
+		delivery.Attempts,
+		delivery.LastStatusCode,
+		delivery.LastError,
+		delivery.NextAttemptAt,
+		delivery.DeliveredAt,
+	)
+	if err != nil {
+		logger.LogQueryError(query, err)
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		logger.LogQueryError(query, err)
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
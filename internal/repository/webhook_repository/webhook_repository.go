@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type WebhookStorage struct {
+	db *sql.DB
+}
+
+func NewWebhookStorage(db *sql.DB) *WebhookStorage {
+	return &WebhookStorage{
+		db: db,
+	}
+}
+
+const subscriptionColumns = `id, url, secret, events, is_active, created_at`
+
+const deliveryColumns = `id, subscription_id, event_id, event_type, payload, status, attempts,
+	last_status_code, last_error, next_attempt_at, created_at, delivered_at, replay_of`
+
+// rowScanner общий интерфейс *sql.Row и *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSubscription(row rowScanner) (*domain.WebhookSubscription, error) {
+	var sub domain.WebhookSubscription
+	var events []string
+	var createdAt time.Time
+
+	if err := row.Scan(&sub.ID, &sub.URL, &sub.Secret, pq.Array(&events), &sub.IsActive, &createdAt); err != nil {
+		return nil, err
+	}
+
+	sub.Events = make([]domain.EventType, 0, len(events))
+	for _, e := range events {
+		sub.Events = append(sub.Events, domain.EventType(e))
+	}
+	sub.CreatedAt = &createdAt
+	return &sub, nil
+}
+
+func scanDelivery(row rowScanner) (*domain.WebhookDelivery, error) {
+	var d domain.WebhookDelivery
+	var eventType, status string
+	var payload []byte
+	var lastStatusCode sql.NullInt64
+	var lastError sql.NullString
+	var nextAttemptAt, deliveredAt sql.NullTime
+	var createdAt time.Time
+	var replayOf uuid.NullUUID
+
+	if err := row.Scan(
+		&d.ID, &d.SubscriptionID, &d.EventID, &eventType, &payload, &status, &d.Attempts,
+		&lastStatusCode, &lastError, &nextAttemptAt, &createdAt, &deliveredAt, &replayOf,
+	); err != nil {
+		return nil, err
+	}
+
+	d.EventType = domain.EventType(eventType)
+	d.Payload = payload
+	d.Status = domain.WebhookDeliveryStatus(status)
+	d.CreatedAt = &createdAt
+	if lastStatusCode.Valid {
+		code := int(lastStatusCode.Int64)
+		d.LastStatusCode = &code
+	}
+	d.LastError = lastError.String
+	if nextAttemptAt.Valid {
+		d.NextAttemptAt = &nextAttemptAt.Time
+	}
+	if deliveredAt.Valid {
+		d.DeliveredAt = &deliveredAt.Time
+	}
+	if replayOf.Valid {
+		d.ReplayOf = &replayOf.UUID
+	}
+	return &d, nil
+}
+
+func eventsArg(events []domain.EventType) interface{} {
+	values := make([]string, 0, len(events))
+	for _, e := range events {
+		values = append(values, string(e))
+	}
+	return pq.Array(values)
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logger.InitLogger()
+}
+
+func TestWebhookStorage_ListSubscriptions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	id := uuid.New()
+	createdAt := time.Date(2025, 10, 24, 10, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "url", "secret", "events", "is_active", "created_at"}).
+		AddRow(id, "https://example.com/hook", "s", "{pull_request_created,pull_request_merged}", true, createdAt)
+	mock.ExpectQuery(`FROM webhook_subscriptions WHERE is_active AND \$1 = ANY\(events\)`).
+		WithArgs("pull_request_merged").
+		WillReturnRows(rows)
+
+	subs, err := NewWebhookStorage(db).ListSubscriptions(context.Background(), domain.EventPullRequestMerged)
+	require.NoError(t, err)
+	require.Len(t, subs, 1)
+	assert.Equal(t, id, subs[0].ID)
+	assert.Equal(t, []domain.EventType{domain.EventPullRequestCreated, domain.EventPullRequestMerged}, subs[0].Events)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookStorage_DeleteSubscriptionNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	id := uuid.New()
+	mock.ExpectBegin()
+	mock.ExpectQuery(`DELETE FROM webhook_subscriptions WHERE id = \$1 RETURNING`).
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "url", "secret", "events", "is_active", "created_at"}))
+	mock.ExpectRollback()
+
+	err = NewWebhookStorage(db).DeleteSubscription(context.Background(), id)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookStorage_GetDelivery(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	id, subID, eventID := uuid.New(), uuid.New(), uuid.New()
+	createdAt := time.Date(2025, 10, 24, 10, 0, 0, 0, time.UTC)
+	nextAttemptAt := createdAt.Add(time.Second)
+	rows := sqlmock.NewRows([]string{
+		"id", "subscription_id", "event_id", "event_type", "payload", "status", "attempts",
+		"last_status_code", "last_error", "next_attempt_at", "created_at", "delivered_at", "replay_of",
+	}).AddRow(id, subID, eventID, "reviewer_reassigned", []byte(`{"type":"reviewer_reassigned"}`), "PENDING", 1,
+		503, "subscriber responded with status 503", nextAttemptAt, createdAt, nil, nil)
+	mock.ExpectQuery(`FROM webhook_deliveries WHERE id = \$1`).
+		WithArgs(id).
+		WillReturnRows(rows)
+
+	delivery, err := NewWebhookStorage(db).GetDelivery(context.Background(), id)
+	require.NoError(t, err)
+	assert.Equal(t, subID, delivery.SubscriptionID)
+	assert.Equal(t, eventID, delivery.EventID)
+	assert.Equal(t, domain.EventReviewerReassigned, delivery.EventType)
+	assert.Equal(t, domain.WebhookDeliveryPending, delivery.Status)
+	require.NotNil(t, delivery.LastStatusCode)
+	assert.Equal(t, 503, *delivery.LastStatusCode)
+	require.NotNil(t, delivery.NextAttemptAt)
+	assert.Nil(t, delivery.DeliveredAt)
+	assert.Nil(t, delivery.ReplayOf)
+	assert.JSONEq(t, `{"type":"reviewer_reassigned"}`, string(delivery.Payload))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookStorage_GetDeliveryNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	id := uuid.New()
+	mock.ExpectQuery(`FROM webhook_deliveries WHERE id = \$1`).
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err = NewWebhookStorage(db).GetDelivery(context.Background(), id)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookStorage_CreateDeliveriesSkipsRecorded(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	eventID := uuid.New()
+	recorded := &domain.WebhookDelivery{ID: uuid.New(), SubscriptionID: uuid.New(), EventID: eventID, Status: domain.WebhookDeliveryPending}
+	fresh := &domain.WebhookDelivery{ID: uuid.New(), SubscriptionID: uuid.New(), EventID: eventID, Status: domain.WebhookDeliveryPending}
+	createdAt := time.Date(2025, 10, 24, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`ON CONFLICT \(subscription_id, event_id\) WHERE replay_of IS NULL DO NOTHING`).
+		WithArgs(recorded.ID, recorded.SubscriptionID, eventID, sqlmock.AnyArg(), sqlmock.AnyArg(), "PENDING").
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "next_attempt_at"}))
+	mock.ExpectQuery(`ON CONFLICT \(subscription_id, event_id\) WHERE replay_of IS NULL DO NOTHING`).
+		WithArgs(fresh.ID, fresh.SubscriptionID, eventID, sqlmock.AnyArg(), sqlmock.AnyArg(), "PENDING").
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "next_attempt_at"}).AddRow(createdAt, createdAt))
+	mock.ExpectCommit()
+
+	created, err := NewWebhookStorage(db).CreateDeliveries(context.Background(), []*domain.WebhookDelivery{recorded, fresh})
+	require.NoError(t, err)
+	require.Len(t, created, 1)
+	assert.Same(t, fresh, created[0])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -4,36 +4,45 @@ import (
 	"context"
 	"errors"
 	"net/http"
-	"os"
+	"sync/atomic"
 	"time"
 )
 
-const (
-	readTimeout  = 15 * time.Second
-	writeTimeout = 15 * time.Second
-)
-
 // APIServer обёртка над http.Server для управления жизненным циклом сервера
 type APIServer struct {
 	httpServer *http.Server
+
+	// drainDelay сколько сервер продолжает принимать запросы после начала остановки,
+	// чтобы балансировщик успел увидеть неготовность по /readyz и перестал слать трафик
+	drainDelay   time.Duration
+	shuttingDown atomic.Bool
 }
 
-func NewAPIServer(handler http.Handler) *APIServer {
-	port := os.Getenv("API_PORT")
-	if port == "" {
-		port = "8080"
-	}
+// Config адрес и таймауты HTTP-сервера
+type Config struct {
+	Port         string
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	DrainDelay   time.Duration
+}
 
+func NewAPIServer(handler http.Handler, cfg Config) *APIServer {
 	return &APIServer{
 		httpServer: &http.Server{
-			Addr:         ":" + port,
+			Addr:         ":" + cfg.Port,
 			Handler:      handler,
-			ReadTimeout:  readTimeout,
-			WriteTimeout: writeTimeout,
+			ReadTimeout:  cfg.ReadTimeout,
+			WriteTimeout: cfg.WriteTimeout,
 		},
+		drainDelay: cfg.DrainDelay,
 	}
 }
 
+// ShuttingDown сообщает, началась ли остановка сервера
+func (s *APIServer) ShuttingDown() bool {
+	return s.shuttingDown.Load()
+}
+
 func (s *APIServer) Start() error {
 	if err := s.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		return err
@@ -41,7 +50,16 @@ func (s *APIServer) Start() error {
 	return nil
 }
 
+// Shutdown сразу помечает сервер останавливающимся, ждёт drainDelay, продолжая обслуживать запросы,
+// и только затем закрывает приём соединений и дожидается активных запросов
 func (s *APIServer) Shutdown(ctx context.Context) error {
+	s.shuttingDown.Store(true)
+
+	select {
+	case <-time.After(s.drainDelay):
+	case <-ctx.Done():
+	}
+
 	if err := s.httpServer.Shutdown(ctx); err != nil {
 		return err
 	}
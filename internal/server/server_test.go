@@ -0,0 +1,26 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIServer_ShutdownDrainsBeforeClosing(t *testing.T) {
+	srv := NewAPIServer(nil, Config{Port: "0", DrainDelay: 100 * time.Millisecond})
+	require.False(t, srv.ShuttingDown())
+
+	done := make(chan error, 1)
+	start := time.Now()
+	go func() {
+		done <- srv.Shutdown(context.Background())
+	}()
+
+	assert.Eventually(t, srv.ShuttingDown, time.Second, time.Millisecond)
+
+	require.NoError(t, <-done)
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+}
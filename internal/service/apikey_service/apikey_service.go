@@ -0,0 +1,24 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/repository"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+type APIKeyServiceImpl struct {
+	apiKeyRepo repository.APIKeyRepositoryInterface
+}
+
+func NewAPIKeyService(apiKeyRepo repository.APIKeyRepositoryInterface) *APIKeyServiceImpl {
+	return &APIKeyServiceImpl{
+		apiKeyRepo: apiKeyRepo,
+	}
+}
+
+// hashKey хеш, по которому ключ хранится и ищется в БД. Ключ случайный и длинный,
+// поэтому соль и медленный хеш не нужны, а поиск остаётся точным совпадением по индексу
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
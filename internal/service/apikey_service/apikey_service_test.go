@@ -0,0 +1,107 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/repository/mocks"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logger.InitLogger()
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	adminCtx := domain.ContextWithActor(context.Background(), domain.Actor{ID: domain.AdminActorID, Role: domain.RoleAdmin})
+	req := &domain.CreateAPIKeyReq{
+		Name:   "ci-bot",
+		Scopes: []domain.APIKeyScope{domain.ScopePullRequestWrite},
+	}
+
+	t.Run("stores only hash", func(t *testing.T) {
+		var storedHash string
+		var stored *domain.APIKey
+		svc := NewAPIKeyService(&mocks.MockAPIKeyRepository{
+			CreateAPIKeyFunc: func(_ context.Context, key *domain.APIKey, keyHash string) error {
+				assert.Empty(t, key.Key)
+				stored = key
+				storedHash = keyHash
+				return nil
+			},
+		})
+
+		key, err := svc.CreateAPIKey(adminCtx, req)
+		require.NoError(t, err)
+		assert.Same(t, stored, key)
+		assert.True(t, strings.HasPrefix(key.Key, domain.APIKeyPrefix))
+		assert.Equal(t, key.Key[:domain.APIKeyDisplayLength], key.Prefix)
+		assert.Equal(t, hashKey(key.Key), storedHash)
+		assert.NotContains(t, storedHash, key.Key)
+		assert.Equal(t, domain.AdminActorID, key.CreatedBy)
+	})
+}
+
+func TestAuthenticate(t *testing.T) {
+	const secret = domain.APIKeyPrefix + "secret"
+	id := uuid.New()
+
+	t.Run("returns service actor with scopes", func(t *testing.T) {
+		svc := NewAPIKeyService(&mocks.MockAPIKeyRepository{
+			UseAPIKeyFunc: func(_ context.Context, keyHash string) (*domain.APIKey, error) {
+				assert.Equal(t, hashKey(secret), keyHash)
+				return &domain.APIKey{ID: id, Scopes: []domain.APIKeyScope{domain.ScopePullRequestWrite}}, nil
+			},
+		})
+
+		actor, err := svc.Authenticate(context.Background(), secret)
+		require.NoError(t, err)
+		assert.Equal(t, "apikey:"+id.String(), actor.ID)
+		assert.Equal(t, domain.RoleService, actor.Role)
+		assert.True(t, actor.HasScope(domain.ScopePullRequestWrite))
+		assert.False(t, actor.HasScope(domain.ScopeTeamAdmin))
+		assert.False(t, actor.IsAdmin())
+	})
+
+	t.Run("unknown key is unauthorized", func(t *testing.T) {
+		svc := NewAPIKeyService(&mocks.MockAPIKeyRepository{
+			UseAPIKeyFunc: func(context.Context, string) (*domain.APIKey, error) {
+				return nil, domain.ErrNotFound
+			},
+		})
+
+		_, err := svc.Authenticate(context.Background(), secret)
+		assert.ErrorIs(t, err, domain.ErrUnauthorized)
+	})
+
+	t.Run("key without prefix is not looked up", func(t *testing.T) {
+		svc := NewAPIKeyService(&mocks.MockAPIKeyRepository{
+			UseAPIKeyFunc: func(context.Context, string) (*domain.APIKey, error) {
+				t.Fatal("unexpected lookup")
+				return nil, nil
+			},
+		})
+
+		_, err := svc.Authenticate(context.Background(), "secret")
+		assert.ErrorIs(t, err, domain.ErrUnauthorized)
+	})
+
+	t.Run("storage error is returned as is", func(t *testing.T) {
+		dbErr := errors.New("connection refused")
+		svc := NewAPIKeyService(&mocks.MockAPIKeyRepository{
+			UseAPIKeyFunc: func(context.Context, string) (*domain.APIKey, error) {
+				return nil, dbErr
+			},
+		})
+
+		_, err := svc.Authenticate(context.Background(), secret)
+		assert.ErrorIs(t, err, dbErr)
+		assert.NotErrorIs(t, err, domain.ErrUnauthorized)
+	})
+}
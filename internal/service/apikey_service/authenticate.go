@@ -0,0 +1,33 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Authenticate проверяет ключ из заголовка Authorization: ApiKey и возвращает инициатора запроса с разрешениями ключа.
+// Неизвестный, отозванный и истёкший ключ возвращается как ErrUnauthorized без уточнения причины
+func (s *APIKeyServiceImpl) Authenticate(ctx context.Context, secret string) (domain.Actor, error) {
+	invalid := fmt.Errorf("%w: invalid, revoked or expired API key", domain.ErrUnauthorized)
+
+	if !strings.HasPrefix(secret, domain.APIKeyPrefix) {
+		return domain.Actor{}, invalid
+	}
+
+	key, err := s.apiKeyRepo.UseAPIKey(ctx, hashKey(secret))
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.Actor{}, invalid
+	}
+	if err != nil {
+		return domain.Actor{}, err
+	}
+
+	return domain.Actor{
+		ID:     key.ActorID(),
+		Role:   domain.RoleService,
+		Scopes: key.Scopes,
+	}, nil
+}
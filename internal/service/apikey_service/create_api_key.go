@@ -0,0 +1,68 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/infrastructure/tracing"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const generatedKeyBytes = 32
+
+// CreateAPIKey выпускает ключ. Сам ключ возвращается только в ответе на создание, в БД хранится его хеш
+func (s *APIKeyServiceImpl) CreateAPIKey(ctx context.Context, req *domain.CreateAPIKeyReq) (*domain.APIKey, error) {
+	start := time.Now()
+	operation := "CreateAPIKey"
+
+	ctx, span := tracing.Start(ctx, "APIKeyService."+operation)
+	defer span.End()
+	actor := domain.ActorFromContext(ctx)
+
+	logger.LogBusinessTransactionStart(ctx, operation, map[string]interface{}{
+		"name":   req.Name,
+		"scopes": req.Scopes,
+		"actor":  actor.ID,
+	})
+
+	buf := make([]byte, generatedKeyBytes)
+	if _, err := rand.Read(buf); err != nil {
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
+			"error": err.Error(),
+		})
+		return nil, err
+	}
+	secret := domain.APIKeyPrefix + base64.RawURLEncoding.EncodeToString(buf)
+
+	key := &domain.APIKey{
+		ID:        uuid.New(),
+		Name:      req.Name,
+		Prefix:    secret[:domain.APIKeyDisplayLength],
+		Scopes:    req.Scopes,
+		CreatedBy: actor.ID,
+		ExpiresAt: req.ExpiresAt,
+	}
+	if err := s.apiKeyRepo.CreateAPIKey(ctx, key, hashKey(secret)); err != nil {
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
+			"error": err.Error(),
+		})
+		return nil, err
+	}
+	key.Key = secret
+
+	logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), true, map[string]interface{}{
+		"api_key_id": key.ID.String(),
+	})
+	logger.LogCriticalEvent("api_key_created", map[string]interface{}{
+		"api_key_id": key.ID.String(),
+		"name":       key.Name,
+		"scopes":     key.Scopes,
+		"actor":      actor.ID,
+	})
+
+	return key, nil
+}
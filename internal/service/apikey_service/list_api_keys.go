@@ -0,0 +1,11 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"context"
+)
+
+// ListAPIKeys возвращает все ключи без самих ключей: в БД их нет, отдаётся только начало ключа
+func (s *APIKeyServiceImpl) ListAPIKeys(ctx context.Context) ([]domain.APIKey, error) {
+	return s.apiKeyRepo.ListAPIKeys(ctx)
+}
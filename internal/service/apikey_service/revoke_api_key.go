@@ -0,0 +1,22 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+)
+
+// RevokeAPIKey отзывает ключ: следующий запрос с ним получит 401. Повторный отзыв не считается ошибкой
+func (s *APIKeyServiceImpl) RevokeAPIKey(ctx context.Context, req *domain.RevokeAPIKeyReq) (*domain.APIKey, error) {
+	key, err := s.apiKeyRepo.RevokeAPIKey(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.LogCriticalEvent("api_key_revoked", map[string]interface{}{
+		"api_key_id": key.ID.String(),
+		"name":       key.Name,
+		"actor":      domain.ActorFromContext(ctx).ID,
+	})
+	return key, nil
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/repository"
+	"context"
+)
+
+// AuditServiceImpl ведёт журнал аудита. Записи пишут сами репозитории в транзакции изменения, поэтому журнал
+// не расходится с данными. Обёртки Wrap*Service только передают репозиториям через контекст имя вызванной
+// операции API и её параметры, сами сервисы про аудит ничего не знают. Обёртки не встраивают сервис, а реализуют
+// каждый его метод явно: новый метод интерфейса не соберётся, пока для него не решено, пишется ли он в журнал
+type AuditServiceImpl struct {
+	auditRepo repository.AuditRepositoryInterface
+}
+
+func NewAuditService(auditRepo repository.AuditRepositoryInterface) *AuditServiceImpl {
+	return &AuditServiceImpl{
+		auditRepo: auditRepo,
+	}
+}
+
+// audited помечает контекст операцией API для записи аудита. Секреты в params не передаются
+func audited(ctx context.Context, operation string, params interface{}) context.Context {
+	return domain.ContextWithAuditOperation(ctx, operation, params)
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/repository/mocks"
+	"AVITOSAMPISHU/internal/service"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logger.InitLogger()
+}
+
+// stubPullRequestService запоминает контекст, с которым вызвано слияние
+type stubPullRequestService struct {
+	service.PullRequestService
+	ctx context.Context
+}
+
+func (s *stubPullRequestService) MergePullRequest(ctx context.Context, req *domain.MergePullRequestReq) (*domain.PullRequest, error) {
+	s.ctx = ctx
+	return &domain.PullRequest{PullRequestID: req.PullRequestID, Status: domain.PRStatusMerged}, nil
+}
+
+func TestAuditedPullRequestService_PassesOperationToRepository(t *testing.T) {
+	inner := &stubPullRequestService{}
+	prSvc := NewAuditService(&mocks.MockAuditRepository{}).WrapPullRequestService(inner)
+
+	_, err := prSvc.MergePullRequest(context.Background(), &domain.MergePullRequestReq{PullRequestID: "pr1", Force: true})
+	require.NoError(t, err)
+
+	operation, params, ok := domain.AuditOperationFromContext(inner.ctx)
+	require.True(t, ok)
+	assert.Equal(t, "MergePullRequest", operation)
+
+	raw, err := json.Marshal(params)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"pull_request_id":"pr1","force":true}`, string(raw))
+}
+
+func TestAuditServiceImpl_ListAuditEvents(t *testing.T) {
+	base := time.Date(2025, 10, 24, 10, 0, 0, 0, time.UTC)
+	auditRepo := &mocks.MockAuditRepository{
+		ListAuditEventsFunc: func(_ context.Context, _ *domain.ListAuditEventsReq, limit int) ([]domain.AuditEvent, error) {
+			events := make([]domain.AuditEvent, 0, limit)
+			for i := 0; i < limit; i++ {
+				createdAt := base.Add(-time.Duration(i) * time.Minute)
+				events = append(events, domain.AuditEvent{ID: int64(100 - i), CreatedAt: &createdAt})
+			}
+			return events, nil
+		},
+	}
+	svc := NewAuditService(auditRepo)
+
+	t.Run("admin gets page with cursor", func(t *testing.T) {
+		ctx := domain.ContextWithActor(context.Background(), domain.Actor{ID: domain.AdminActorID, Role: domain.RoleAdmin})
+		res, err := svc.ListAuditEvents(ctx, &domain.ListAuditEventsReq{Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, res.Events, 2)
+
+		cursor, err := domain.DecodePageCursor(res.NextCursor)
+		require.NoError(t, err)
+		assert.Equal(t, "99", cursor.ID)
+		assert.True(t, base.Add(-time.Minute).Equal(cursor.CreatedAt))
+	})
+}
+
+// stubWebhookService запоминает контекст, с которым создана подписка
+type stubWebhookService struct {
+	service.WebhookService
+	ctx context.Context
+}
+
+func (s *stubWebhookService) CreateWebhook(ctx context.Context, req *domain.CreateWebhookReq) (*domain.WebhookSubscription, error) {
+	s.ctx = ctx
+	return &domain.WebhookSubscription{URL: req.URL, Secret: req.Secret}, nil
+}
+
+func TestAuditedWebhookService_DoesNotPassSecret(t *testing.T) {
+	inner := &stubWebhookService{}
+	webhookSvc := NewAuditService(&mocks.MockAuditRepository{}).WrapWebhookService(inner)
+
+	req := &domain.CreateWebhookReq{URL: "https://example.com/hook", Secret: "whsec_secret"}
+	sub, err := webhookSvc.CreateWebhook(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "whsec_secret", sub.Secret)
+	assert.Equal(t, "whsec_secret", req.Secret)
+
+	_, params, ok := domain.AuditOperationFromContext(inner.ctx)
+	require.True(t, ok)
+	raw, err := json.Marshal(params)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "whsec_secret")
+	assert.Contains(t, string(raw), "https://example.com/hook")
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/service"
+	"context"
+)
+
+// auditedAPIKeyService помечает выпуск и отзыв ключей API для журнала аудита, чтение передаётся как есть.
+// Сам ключ в журнал не попадает: его нет ни в запросе, ни в записи репозитория
+type auditedAPIKeyService struct {
+	inner service.APIKeyService
+}
+
+func (s *AuditServiceImpl) WrapAPIKeyService(inner service.APIKeyService) service.APIKeyService {
+	return &auditedAPIKeyService{inner: inner}
+}
+
+func (s *auditedAPIKeyService) CreateAPIKey(ctx context.Context, req *domain.CreateAPIKeyReq) (*domain.APIKey, error) {
+	return s.inner.CreateAPIKey(audited(ctx, "CreateAPIKey", req), req)
+}
+
+func (s *auditedAPIKeyService) RevokeAPIKey(ctx context.Context, req *domain.RevokeAPIKeyReq) (*domain.APIKey, error) {
+	return s.inner.RevokeAPIKey(audited(ctx, "RevokeAPIKey", req), req)
+}
+
+func (s *auditedAPIKeyService) ListAPIKeys(ctx context.Context) ([]domain.APIKey, error) {
+	return s.inner.ListAPIKeys(ctx)
+}
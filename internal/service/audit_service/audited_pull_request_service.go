@@ -0,0 +1,81 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/service"
+	"context"
+)
+
+// auditedPullRequestService помечает изменяющие операции PullRequestService для журнала аудита, чтение передаётся как есть
+type auditedPullRequestService struct {
+	inner service.PullRequestService
+}
+
+func (s *AuditServiceImpl) WrapPullRequestService(inner service.PullRequestService) service.PullRequestService {
+	return &auditedPullRequestService{inner: inner}
+}
+
+func (s *auditedPullRequestService) CreatePullRequest(
+	ctx context.Context,
+	req *domain.CreatePullRequestReq,
+) (*domain.PullRequest, error) {
+	return s.inner.CreatePullRequest(audited(ctx, "CreatePullRequest", req), req)
+}
+
+// MergePullRequest параметры запроса попадают в журнал, поэтому слияние в обход одобрений (force) видно в аудите
+func (s *auditedPullRequestService) MergePullRequest(
+	ctx context.Context,
+	req *domain.MergePullRequestReq,
+) (*domain.PullRequest, error) {
+	return s.inner.MergePullRequest(audited(ctx, "MergePullRequest", req), req)
+}
+
+func (s *auditedPullRequestService) ReassignReviewer(
+	ctx context.Context,
+	req *domain.ReassignReviewerReq,
+) (*domain.PullRequest, string, error) {
+	return s.inner.ReassignReviewer(audited(ctx, "ReassignReviewer", req), req)
+}
+
+func (s *auditedPullRequestService) SubmitReview(
+	ctx context.Context,
+	req *domain.SubmitReviewReq,
+) (*domain.PullRequest, error) {
+	return s.inner.SubmitReview(audited(ctx, "SubmitReview", req), req)
+}
+
+func (s *auditedPullRequestService) ClosePullRequest(
+	ctx context.Context,
+	req *domain.ClosePullRequestReq,
+) (*domain.PullRequest, error) {
+	return s.inner.ClosePullRequest(audited(ctx, "ClosePullRequest", req), req)
+}
+
+func (s *auditedPullRequestService) ReopenPullRequest(
+	ctx context.Context,
+	req *domain.ReopenPullRequestReq,
+) (*domain.PullRequest, error) {
+	return s.inner.ReopenPullRequest(audited(ctx, "ReopenPullRequest", req), req)
+}
+
+func (s *auditedPullRequestService) MarkReady(ctx context.Context, req *domain.MarkReadyReq) (*domain.PullRequest, error) {
+	return s.inner.MarkReady(audited(ctx, "MarkReady", req), req)
+}
+
+func (s *auditedPullRequestService) GetPullRequest(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	return s.inner.GetPullRequest(ctx, prID)
+}
+
+func (s *auditedPullRequestService) GetPullRequestHistory(
+	ctx context.Context,
+	prID string,
+) (*domain.PullRequestHistoryResponse, error) {
+	return s.inner.GetPullRequestHistory(ctx, prID)
+}
+
+func (s *auditedPullRequestService) ListPullRequests(
+	ctx context.Context,
+	req *domain.ListPullRequestsReq,
+) (*domain.ListPullRequestsResponse, error) {
+	return s.inner.ListPullRequests(ctx, req)
+}
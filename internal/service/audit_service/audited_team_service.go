@@ -0,0 +1,35 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/service"
+	"context"
+)
+
+// auditedTeamService помечает изменяющие операции TeamService для журнала аудита, чтение передаётся как есть
+type auditedTeamService struct {
+	inner service.TeamService
+}
+
+func (s *AuditServiceImpl) WrapTeamService(inner service.TeamService) service.TeamService {
+	return &auditedTeamService{inner: inner}
+}
+
+func (s *auditedTeamService) CreateTeam(ctx context.Context, team *domain.Team) (*domain.Team, error) {
+	return s.inner.CreateTeam(audited(ctx, "CreateTeam", team), team)
+}
+
+func (s *auditedTeamService) UpdateTeamSettings(
+	ctx context.Context,
+	req *domain.UpdateTeamSettingsReq,
+) (*domain.TeamSettings, error) {
+	return s.inner.UpdateTeamSettings(audited(ctx, "UpdateTeamSettings", req), req)
+}
+
+func (s *auditedTeamService) GetTeam(ctx context.Context, teamName string) (*domain.Team, error) {
+	return s.inner.GetTeam(ctx, teamName)
+}
+
+func (s *auditedTeamService) GetTeamSettings(ctx context.Context, teamName string) (*domain.TeamSettings, error) {
+	return s.inner.GetTeamSettings(ctx, teamName)
+}
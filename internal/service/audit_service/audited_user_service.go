@@ -0,0 +1,34 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/service"
+	"context"
+)
+
+// auditedUserService помечает изменяющие операции UserService для журнала аудита, чтение передаётся как есть
+type auditedUserService struct {
+	inner service.UserService
+}
+
+func (s *AuditServiceImpl) WrapUserService(inner service.UserService) service.UserService {
+	return &auditedUserService{inner: inner}
+}
+
+func (s *auditedUserService) SetIsActive(ctx context.Context, req *domain.SetIsActiveRequest) (*domain.User, error) {
+	return s.inner.SetIsActive(audited(ctx, "SetIsActive", req), req)
+}
+
+func (s *auditedUserService) DeactivateTeamMembers(
+	ctx context.Context,
+	req *domain.DeactivateTeamMembersReq,
+) (*domain.DeactivateTeamMembersRes, error) {
+	return s.inner.DeactivateTeamMembers(audited(ctx, "DeactivateTeamMembers", req), req)
+}
+
+func (s *auditedUserService) GetUserReviews(
+	ctx context.Context,
+	req *domain.GetUserReviewsReq,
+) (*domain.GetUserReviewsResponse, error) {
+	return s.inner.GetUserReviews(ctx, req)
+}
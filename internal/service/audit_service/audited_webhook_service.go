@@ -0,0 +1,48 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/service"
+	"context"
+)
+
+// auditedWebhookService помечает изменяющие операции WebhookService для журнала аудита, чтение передаётся как есть.
+// Секрет подписки в журнал не попадает
+type auditedWebhookService struct {
+	inner service.WebhookService
+}
+
+func (s *AuditServiceImpl) WrapWebhookService(inner service.WebhookService) service.WebhookService {
+	return &auditedWebhookService{inner: inner}
+}
+
+func (s *auditedWebhookService) CreateWebhook(
+	ctx context.Context,
+	req *domain.CreateWebhookReq,
+) (*domain.WebhookSubscription, error) {
+	params := *req
+	params.Secret = ""
+	return s.inner.CreateWebhook(audited(ctx, "CreateWebhook", params), req)
+}
+
+func (s *auditedWebhookService) DeleteWebhook(ctx context.Context, req *domain.DeleteWebhookReq) error {
+	return s.inner.DeleteWebhook(audited(ctx, "DeleteWebhook", req), req)
+}
+
+func (s *auditedWebhookService) ReplayDelivery(
+	ctx context.Context,
+	req *domain.ReplayWebhookDeliveryReq,
+) (*domain.WebhookDelivery, error) {
+	return s.inner.ReplayDelivery(audited(ctx, "ReplayDelivery", req), req)
+}
+
+func (s *auditedWebhookService) ListWebhooks(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	return s.inner.ListWebhooks(ctx)
+}
+
+func (s *auditedWebhookService) ListDeliveries(
+	ctx context.Context,
+	req *domain.ListWebhookDeliveriesReq,
+) ([]domain.WebhookDelivery, error) {
+	return s.inner.ListDeliveries(ctx, req)
+}
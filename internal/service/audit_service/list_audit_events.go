@@ -0,0 +1,33 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"context"
+	"strconv"
+)
+
+// ListAuditEvents возвращает страницу журнала аудита от новых записей к старым
+func (s *AuditServiceImpl) ListAuditEvents(
+	ctx context.Context,
+	req *domain.ListAuditEventsReq,
+) (*domain.ListAuditEventsResponse, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = domain.DefaultPageLimit
+	}
+
+	// Запрашиваем на одну запись больше, чтобы понять, есть ли следующая страница
+	events, err := s.auditRepo.ListAuditEvents(ctx, req, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &domain.ListAuditEventsResponse{Events: events}
+	if len(events) > limit {
+		res.Events = events[:limit]
+		last := res.Events[limit-1]
+		res.NextCursor = domain.PageCursor{CreatedAt: *last.CreatedAt, ID: strconv.FormatInt(last.ID, 10)}.Encode()
+	}
+
+	return res, nil
+}
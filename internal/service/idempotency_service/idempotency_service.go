@@ -0,0 +1,94 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/repository"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"errors"
+	"time"
+)
+
+// IdempotencyServiceImpl хранит ответы на запросы с заголовком Idempotency-Key.
+// Первый запрос занимает ключ и сохраняет ответ, повторы с тем же запросом получают сохранённый ответ
+type IdempotencyServiceImpl struct {
+	idempotencyRepo repository.IdempotencyRepositoryInterface
+	ttl             time.Duration
+}
+
+func NewIdempotencyService(idempotencyRepo repository.IdempotencyRepositoryInterface, ttl time.Duration) *IdempotencyServiceImpl {
+	return &IdempotencyServiceImpl{
+		idempotencyRepo: idempotencyRepo,
+		ttl:             ttl,
+	}
+}
+
+// Begin занимает ключ под запрос. Если ключ свободен, возвращает nil: вызывающий выполняет запрос и обязан
+// вызвать Complete или Release. Если ответ уже сохранён, возвращает его для повтора.
+// Ключ, использованный с другим запросом, — ErrIdempotencyKeyReused; ключ, чей запрос ещё выполняется, — ErrIdempotencyInProgress
+func (s *IdempotencyServiceImpl) Begin(ctx context.Context, principal, key, requestHash string) (*domain.IdempotencyRecord, error) {
+	record := &domain.IdempotencyRecord{Principal: principal, Key: key, RequestHash: requestHash}
+	claimed, err := s.idempotencyRepo.ClaimKey(ctx, record, s.ttl)
+	if err != nil {
+		return nil, err
+	}
+	if claimed {
+		return nil, nil
+	}
+
+	existing, err := s.idempotencyRepo.GetKey(ctx, principal, key)
+	if errors.Is(err, domain.ErrNotFound) {
+		// Ключ освободили между попыткой занять его и чтением: первый запрос завершился ошибкой 5xx
+		return nil, domain.ErrIdempotencyInProgress
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if existing.RequestHash != requestHash {
+		logger.LogBusinessRule("idempotency_key_reused", map[string]interface{}{
+			"principal": principal,
+			"key":       key,
+		})
+		return nil, domain.ErrIdempotencyKeyReused
+	}
+	if !existing.Completed() {
+		return nil, domain.ErrIdempotencyInProgress
+	}
+
+	return existing, nil
+}
+
+// Complete сохраняет ответ на запрос, занявший ключ
+func (s *IdempotencyServiceImpl) Complete(ctx context.Context, principal, key string, statusCode int, body []byte) error {
+	return s.idempotencyRepo.CompleteKey(ctx, principal, key, statusCode, body)
+}
+
+// Release освобождает ключ без сохранения ответа, чтобы запрос можно было повторить
+func (s *IdempotencyServiceImpl) Release(ctx context.Context, principal, key string) error {
+	return s.idempotencyRepo.DeleteKey(ctx, principal, key)
+}
+
+// RunCleanup до отмены ctx периодически удаляет истёкшие ключи. На корректность это не влияет:
+// истёкший ключ и так считается свободным, очистка только не даёт таблице расти
+func (s *IdempotencyServiceImpl) RunCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		deleted, err := s.idempotencyRepo.DeleteExpiredKeys(ctx)
+		if err != nil {
+			logger.Logger.Errorw("idempotency keys cleanup failed", "error", err)
+			continue
+		}
+		if deleted > 0 {
+			logger.Logger.Infow("expired idempotency keys deleted", "count", deleted)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/repository/mocks"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logger.InitLogger()
+}
+
+func takenKey(existing *domain.IdempotencyRecord) *mocks.MockIdempotencyRepository {
+	return &mocks.MockIdempotencyRepository{
+		ClaimKeyFunc: func(context.Context, *domain.IdempotencyRecord, time.Duration) (bool, error) {
+			return false, nil
+		},
+		GetKeyFunc: func(context.Context, string, string) (*domain.IdempotencyRecord, error) {
+			if existing == nil {
+				return nil, domain.ErrNotFound
+			}
+			return existing, nil
+		},
+	}
+}
+
+func TestIdempotencyService_Begin(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("free key is claimed with ttl", func(t *testing.T) {
+		var gotTTL time.Duration
+		svc := NewIdempotencyService(&mocks.MockIdempotencyRepository{
+			ClaimKeyFunc: func(_ context.Context, record *domain.IdempotencyRecord, ttl time.Duration) (bool, error) {
+				assert.Equal(t, "alice", record.Principal)
+				assert.Equal(t, "hash", record.RequestHash)
+				gotTTL = ttl
+				return true, nil
+			},
+		}, time.Hour)
+
+		record, err := svc.Begin(ctx, "alice", "k1", "hash")
+		require.NoError(t, err)
+		assert.Nil(t, record)
+		assert.Equal(t, time.Hour, gotTTL)
+	})
+
+	t.Run("completed key is replayed", func(t *testing.T) {
+		stored := &domain.IdempotencyRecord{RequestHash: "hash", StatusCode: 201, Body: []byte(`{}`)}
+		svc := NewIdempotencyService(takenKey(stored), time.Hour)
+
+		record, err := svc.Begin(ctx, "alice", "k1", "hash")
+		require.NoError(t, err)
+		assert.Same(t, stored, record)
+	})
+
+	t.Run("different request is rejected", func(t *testing.T) {
+		svc := NewIdempotencyService(takenKey(&domain.IdempotencyRecord{RequestHash: "other", StatusCode: 201}), time.Hour)
+
+		_, err := svc.Begin(ctx, "alice", "k1", "hash")
+		assert.ErrorIs(t, err, domain.ErrIdempotencyKeyReused)
+	})
+
+	t.Run("pending key is in progress", func(t *testing.T) {
+		svc := NewIdempotencyService(takenKey(&domain.IdempotencyRecord{RequestHash: "hash"}), time.Hour)
+
+		_, err := svc.Begin(ctx, "alice", "k1", "hash")
+		assert.ErrorIs(t, err, domain.ErrIdempotencyInProgress)
+	})
+
+	t.Run("key released concurrently is in progress", func(t *testing.T) {
+		svc := NewIdempotencyService(takenKey(nil), time.Hour)
+
+		_, err := svc.Begin(ctx, "alice", "k1", "hash")
+		assert.ErrorIs(t, err, domain.ErrIdempotencyInProgress)
+	})
+}
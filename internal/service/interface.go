@@ -8,11 +8,13 @@ import (
 type TeamService interface {
 	CreateTeam(ctx context.Context, team *domain.Team) (*domain.Team, error)
 	GetTeam(ctx context.Context, teamName string) (*domain.Team, error)
+	GetTeamSettings(ctx context.Context, teamName string) (*domain.TeamSettings, error)
+	UpdateTeamSettings(ctx context.Context, req *domain.UpdateTeamSettingsReq) (*domain.TeamSettings, error)
 }
 
 type UserService interface {
 	SetIsActive(ctx context.Context, req *domain.SetIsActiveRequest) (*domain.User, error)
-	GetUserReviews(ctx context.Context, userID string) ([]domain.PullRequestShort, error)
+	GetUserReviews(ctx context.Context, req *domain.GetUserReviewsReq) (*domain.GetUserReviewsResponse, error)
 	DeactivateTeamMembers(ctx context.Context, req *domain.DeactivateTeamMembersReq) (*domain.DeactivateTeamMembersRes, error)
 }
 
@@ -20,4 +22,47 @@ type PullRequestService interface {
 	CreatePullRequest(ctx context.Context, req *domain.CreatePullRequestReq) (*domain.PullRequest, error)
 	MergePullRequest(ctx context.Context, req *domain.MergePullRequestReq) (*domain.PullRequest, error)
 	ReassignReviewer(ctx context.Context, req *domain.ReassignReviewerReq) (*domain.PullRequest, string, error)
+	SubmitReview(ctx context.Context, req *domain.SubmitReviewReq) (*domain.PullRequest, error)
+	ClosePullRequest(ctx context.Context, req *domain.ClosePullRequestReq) (*domain.PullRequest, error)
+	ReopenPullRequest(ctx context.Context, req *domain.ReopenPullRequestReq) (*domain.PullRequest, error)
+	MarkReady(ctx context.Context, req *domain.MarkReadyReq) (*domain.PullRequest, error)
+	GetPullRequest(ctx context.Context, prID string) (*domain.PullRequest, error)
+	GetPullRequestHistory(ctx context.Context, prID string) (*domain.PullRequestHistoryResponse, error)
+	ListPullRequests(ctx context.Context, req *domain.ListPullRequestsReq) (*domain.ListPullRequestsResponse, error)
+}
+
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, req *domain.CreateWebhookReq) (*domain.WebhookSubscription, error)
+	ListWebhooks(ctx context.Context) ([]domain.WebhookSubscription, error)
+	DeleteWebhook(ctx context.Context, req *domain.DeleteWebhookReq) error
+	ListDeliveries(ctx context.Context, req *domain.ListWebhookDeliveriesReq) ([]domain.WebhookDelivery, error)
+	ReplayDelivery(ctx context.Context, req *domain.ReplayWebhookDeliveryReq) (*domain.WebhookDelivery, error)
+}
+
+type APIKeyService interface {
+	CreateAPIKey(ctx context.Context, req *domain.CreateAPIKeyReq) (*domain.APIKey, error)
+	ListAPIKeys(ctx context.Context) ([]domain.APIKey, error)
+	RevokeAPIKey(ctx context.Context, req *domain.RevokeAPIKeyReq) (*domain.APIKey, error)
+}
+
+type StatsService interface {
+	GetTeamStats(ctx context.Context, req *domain.GetTeamStatsReq) (*domain.TeamStats, error)
+	GetUserStats(ctx context.Context, req *domain.GetUserStatsReq) (*domain.UserStats, error)
+	ExportReviews(ctx context.Context, req *domain.ExportReviewsReq, handle func(row *domain.ReviewExportRow) error) error
+}
+
+type AuditService interface {
+	ListAuditEvents(ctx context.Context, req *domain.ListAuditEventsReq) (*domain.ListAuditEventsResponse, error)
+}
+
+// EventHandler обрабатывает доменные события из outbox. Доставка «как минимум один раз»:
+// при ошибке событие будет передано снова, поэтому обработка должна переносить повторы
+type EventHandler interface {
+	HandleEvent(ctx context.Context, event *domain.Event) error
+}
+
+// ReviewerSelector выбирает ревьюверов из списка участников команды.
+// Реализации обязаны исключать автора и неактивных пользователей.
+type ReviewerSelector interface {
+	SelectReviewers(ctx context.Context, team *domain.Team, members []domain.TeamMember, authorID string, count int) ([]string, error)
 }
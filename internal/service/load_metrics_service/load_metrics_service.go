@@ -0,0 +1,71 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/repository"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"sync"
+	"time"
+)
+
+// reviewerLabels метки серии reviewer_open_reviews
+type reviewerLabels struct {
+	userID   string
+	teamName string
+}
+
+// LoadMetricsServiceImpl поддерживает метрики нагрузки ревьюверов и команд. Значения каждый раз
+// пересчитываются целиком одним агрегирующим запросом: периодически и после событий из outbox
+type LoadMetricsServiceImpl struct {
+	statsRepo repository.StatsRepositoryInterface
+	refresh   chan struct{}
+
+	mu        sync.Mutex
+	reviewers map[reviewerLabels]struct{}
+	teams     map[string]struct{}
+}
+
+func NewLoadMetricsService(statsRepo repository.StatsRepositoryInterface) *LoadMetricsServiceImpl {
+	return &LoadMetricsServiceImpl{
+		statsRepo: statsRepo,
+		refresh:   make(chan struct{}, 1),
+		reviewers: make(map[reviewerLabels]struct{}),
+		teams:     make(map[string]struct{}),
+	}
+}
+
+// Notify просит пересчитать метрики, не дожидаясь очередного тика. Не блокируется:
+// несколько запросов до начала пересчёта схлопываются в один
+func (s *LoadMetricsServiceImpl) Notify() {
+	select {
+	case s.refresh <- struct{}{}:
+	default:
+	}
+}
+
+// HandleEvent запускает пересчёт после любого доменного события. Ошибку не возвращает:
+// пересчёт идёт в фоне, и повторная доставка события ничего бы не дала
+func (s *LoadMetricsServiceImpl) HandleEvent(ctx context.Context, event *domain.Event) error {
+	s.Notify()
+	return nil
+}
+
+// Run до отмены ctx пересчитывает метрики сразу, затем раз в interval и после каждого Notify
+func (s *LoadMetricsServiceImpl) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Refresh(ctx); err != nil && ctx.Err() == nil {
+			logger.Logger.Errorw("load metrics refresh failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.refresh:
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/repository/mocks"
+	"AVITOSAMPISHU/pkg/metrics"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMetricsService_Refresh(t *testing.T) {
+	ctx := context.Background()
+	snapshot := []domain.TeamLoad{
+		{
+			TeamName:          "lm-backend",
+			OpenPullRequests:  3,
+			NeedMoreReviewers: 1,
+			ActiveMembers:     2,
+			InactiveMembers:   1,
+			Reviewers: []domain.ReviewerLoad{
+				{UserID: "lm-u1", TeamName: "lm-backend", IsActive: true, OpenReviews: 2},
+				{UserID: "lm-u2", TeamName: "lm-backend", IsActive: true, OpenReviews: 0},
+				{UserID: "lm-u3", TeamName: "lm-backend", IsActive: false, OpenReviews: 1},
+			},
+		},
+		{TeamName: "lm-frontend"},
+	}
+	svc := NewLoadMetricsService(&mocks.MockStatsRepository{
+		GetLoadSnapshotFunc: func(context.Context) ([]domain.TeamLoad, error) {
+			return snapshot, nil
+		},
+	})
+
+	require.NoError(t, svc.Refresh(ctx))
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(metrics.ReviewerOpenReviews.WithLabelValues("lm-u1", "lm-backend")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(metrics.ReviewerOpenReviews.WithLabelValues("lm-u2", "lm-backend")))
+	assert.Equal(t, 3.0, testutil.ToFloat64(metrics.TeamOpenPullRequests.WithLabelValues("lm-backend")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.TeamPullRequestsNeedMoreReviewers.WithLabelValues("lm-backend")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.TeamInactiveMembers.WithLabelValues("lm-backend")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(metrics.TeamOpenPullRequests.WithLabelValues("lm-frontend")))
+
+	// lm-u1 перешёл в другую команду, lm-frontend удалена: их старые серии пропадают
+	snapshot = []domain.TeamLoad{
+		{
+			TeamName: "lm-backend",
+			Reviewers: []domain.ReviewerLoad{
+				{UserID: "lm-u2", TeamName: "lm-backend", IsActive: true},
+				{UserID: "lm-u3", TeamName: "lm-backend"},
+			},
+		},
+		{
+			TeamName:  "lm-platform",
+			Reviewers: []domain.ReviewerLoad{{UserID: "lm-u1", TeamName: "lm-platform", IsActive: true, OpenReviews: 2}},
+		},
+	}
+	require.NoError(t, svc.Refresh(ctx))
+
+	assert.False(t, metrics.ReviewerOpenReviews.DeleteLabelValues("lm-u1", "lm-backend"), "stale reviewer series is removed")
+	assert.False(t, metrics.TeamOpenPullRequests.DeleteLabelValues("lm-frontend"), "stale team series is removed")
+	assert.Equal(t, 2.0, testutil.ToFloat64(metrics.ReviewerOpenReviews.WithLabelValues("lm-u1", "lm-platform")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(metrics.TeamOpenPullRequests.WithLabelValues("lm-backend")))
+}
+
+func TestLoadMetricsService_RefreshError(t *testing.T) {
+	svc := NewLoadMetricsService(&mocks.MockStatsRepository{
+		GetLoadSnapshotFunc: func(context.Context) ([]domain.TeamLoad, error) {
+			return nil, errors.New("connection refused")
+		},
+	})
+
+	assert.Error(t, svc.Refresh(context.Background()))
+}
+
+func TestLoadMetricsService_EventTriggersRefresh(t *testing.T) {
+	refreshes := make(chan struct{}, 10)
+	svc := NewLoadMetricsService(&mocks.MockStatsRepository{
+		GetLoadSnapshotFunc: func(context.Context) ([]domain.TeamLoad, error) {
+			refreshes <- struct{}{}
+			return nil, nil
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go svc.Run(ctx, time.Hour)
+
+	waitRefresh := func() {
+		t.Helper()
+		select {
+		case <-refreshes:
+		case <-time.After(time.Second):
+			t.Fatal("metrics were not refreshed")
+		}
+	}
+
+	// Первый пересчёт сразу при запуске, следующий — по событию, не дожидаясь тика
+	waitRefresh()
+	require.NoError(t, svc.HandleEvent(ctx, &domain.Event{Type: domain.EventPullRequestMerged}))
+	waitRefresh()
+}
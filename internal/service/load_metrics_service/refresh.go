@@ -0,0 +1,49 @@
+package service
+
+import (
+	"AVITOSAMPISHU/pkg/metrics"
+	"context"
+)
+
+// Refresh пересчитывает метрики нагрузки. Серии пользователей и команд, которых больше нет
+// (удалены или перешли в другую команду), удаляются, чтобы не отдавать устаревшие значения
+func (s *LoadMetricsServiceImpl) Refresh(ctx context.Context) error {
+	snapshot, err := s.statsRepo.GetLoadSnapshot(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	reviewers := make(map[reviewerLabels]struct{}, len(s.reviewers))
+	teams := make(map[string]struct{}, len(snapshot))
+	for _, team := range snapshot {
+		teams[team.TeamName] = struct{}{}
+		metrics.TeamOpenPullRequests.WithLabelValues(team.TeamName).Set(float64(team.OpenPullRequests))
+		metrics.TeamPullRequestsNeedMoreReviewers.WithLabelValues(team.TeamName).Set(float64(team.NeedMoreReviewers))
+		metrics.TeamInactiveMembers.WithLabelValues(team.TeamName).Set(float64(team.InactiveMembers))
+
+		for _, reviewer := range team.Reviewers {
+			reviewers[reviewerLabels{userID: reviewer.UserID, teamName: team.TeamName}] = struct{}{}
+			metrics.ReviewerOpenReviews.WithLabelValues(reviewer.UserID, team.TeamName).Set(float64(reviewer.OpenReviews))
+		}
+	}
+
+	for labels := range s.reviewers {
+		if _, ok := reviewers[labels]; !ok {
+			metrics.ReviewerOpenReviews.DeleteLabelValues(labels.userID, labels.teamName)
+		}
+	}
+	for teamName := range s.teams {
+		if _, ok := teams[teamName]; !ok {
+			metrics.TeamOpenPullRequests.DeleteLabelValues(teamName)
+			metrics.TeamPullRequestsNeedMoreReviewers.DeleteLabelValues(teamName)
+			metrics.TeamInactiveMembers.DeleteLabelValues(teamName)
+		}
+	}
+	s.reviewers = reviewers
+	s.teams = teams
+
+	return nil
+}
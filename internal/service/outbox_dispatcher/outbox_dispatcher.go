@@ -0,0 +1,170 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/repository"
+	"AVITOSAMPISHU/internal/service"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"time"
+)
+
+// Dispatcher разбирает outbox в фоне и передаёт события обработчикам в порядке их записи
+type Dispatcher struct {
+	outboxRepo   repository.OutboxRepositoryInterface
+	handlers     []service.EventHandler
+	batchSize    int
+	claimTimeout time.Duration
+	pollInterval time.Duration
+	maxAttempts  int
+}
+
+func NewDispatcher(outboxRepo repository.OutboxRepositoryInterface, handlers ...service.EventHandler) *Dispatcher {
+	return &Dispatcher{
+		outboxRepo:   outboxRepo,
+		handlers:     handlers,
+		batchSize:    domain.OutboxBatchSize,
+		claimTimeout: domain.OutboxClaimTimeout,
+		pollInterval: domain.OutboxPollInterval,
+		maxAttempts:  domain.OutboxMaxAttempts,
+	}
+}
+
+// Run опрашивает outbox до отмены ctx. Каждый тик разбирает пачки, пока они приходят полными
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		d.drain(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// drain разбирает outbox до первой неполной пачки или ошибки; необработанные события останутся до следующего тика
+func (d *Dispatcher) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		done, err := d.processBatch(ctx)
+		if err != nil {
+			logger.Logger.Errorw("outbox dispatch failed", "processed", done, "error", err)
+			return
+		}
+		if done < d.batchSize {
+			return
+		}
+	}
+}
+
+// processBatch забирает пачку событий и передаёт их обработчикам по одному. Обработанное событие сразу
+// помечается в outbox. Неудачная попытка записывается в событие: пока попытки не исчерпаны, разбор
+// останавливается, чтобы не нарушить порядок, и событие будет передано снова после паузы, которая растёт
+// с каждой попыткой; после maxAttempts попыток событие отбрасывается и разбор идёт дальше.
+// Возвращает число снятых с очереди событий
+func (d *Dispatcher) processBatch(ctx context.Context) (int, error) {
+	events, err := d.outboxRepo.ClaimEvents(ctx, d.batchSize, d.claimTimeout)
+	if err != nil {
+		return 0, err
+	}
+
+	done := 0
+	for i := range events {
+		event := &events[i]
+		if handleErr := d.handle(ctx, &event.Event); handleErr != nil {
+			if ctx.Err() != nil {
+				// Остановка сервиса — не ошибка события, попытка не засчитывается
+				d.release(ctx, events[i:])
+				return done, handleErr
+			}
+
+			retryAfter := d.pollInterval << event.Attempts
+			failed, err := d.outboxRepo.RecordEventFailure(ctx, event.Seq, handleErr.Error(), d.maxAttempts, retryAfter)
+			if err != nil {
+				d.release(ctx, events[i:])
+				return done, err
+			}
+			if !failed {
+				d.release(ctx, events[i+1:])
+				return done, handleErr
+			}
+
+			logger.Logger.Errorw("outbox event dropped after max attempts",
+				"event_id", event.Event.ID.String(),
+				"event", event.Event.Type,
+				"attempts", event.Attempts+1,
+				"error", handleErr,
+			)
+			done++
+			continue
+		}
+
+		if err = d.outboxRepo.MarkEventProcessed(ctx, event.Seq); err != nil {
+			// Событие будет передано повторно, обработчики переносят повторы
+			d.release(ctx, events[i:])
+			return done, err
+		}
+		done++
+	}
+
+	return done, nil
+}
+
+// release возвращает в очередь забранные, но не обработанные события. Выполняется и после отмены ctx,
+// иначе очередь простоит до окончания срока закрепления
+func (d *Dispatcher) release(ctx context.Context, events []domain.OutboxEvent) {
+	if len(events) == 0 {
+		return
+	}
+
+	seqs := make([]int64, 0, len(events))
+	for _, event := range events {
+		seqs = append(seqs, event.Seq)
+	}
+	if err := d.outboxRepo.ReleaseEvents(context.WithoutCancel(ctx), seqs); err != nil {
+		logger.Logger.Errorw("outbox events release failed", "count", len(seqs), "error", err)
+	}
+}
+
+// RunCleanup до отмены ctx периодически удаляет события, обработанные раньше чем retention назад.
+// Outbox — только очередь на рассылку: история изменений хранится в reviewers и журнале аудита
+func (d *Dispatcher) RunCleanup(ctx context.Context, interval, retention time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		deleted, err := d.outboxRepo.DeleteProcessedEvents(ctx, retention)
+		if err != nil {
+			logger.Logger.Errorw("outbox cleanup failed", "error", err)
+			continue
+		}
+		if deleted > 0 {
+			logger.Logger.Infow("processed outbox events deleted", "count", deleted)
+		}
+	}
+}
+
+// handle передаёт событие всем обработчикам. Если один из них вернул ошибку, событие будет передано
+// снова всем обработчикам, поэтому каждый из них должен переносить повторы
+func (d *Dispatcher) handle(ctx context.Context, event *domain.Event) error {
+	for _, h := range d.handlers {
+		if err := h.HandleEvent(ctx, event); err != nil {
+			logger.Logger.Errorw("outbox event handling failed",
+				"event_id", event.ID.String(),
+				"event", event.Type,
+				"error", err,
+			)
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/repository/mocks"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	logger.InitLogger()
+}
+
+type recordingHandler struct {
+	handled []domain.EventType
+	err     error
+}
+
+func (h *recordingHandler) HandleEvent(_ context.Context, event *domain.Event) error {
+	if h.err != nil {
+		return h.err
+	}
+	h.handled = append(h.handled, event.Type)
+	return nil
+}
+
+// memoryOutbox очередь событий в памяти поверх MockOutboxRepository
+type memoryOutbox struct {
+	events   []domain.OutboxEvent
+	claims   int
+	failures []time.Duration
+	released []int64
+	dropped  []int64
+}
+
+// outboxWith имитирует outbox с событиями, у которых уже было attempts неудачных попыток
+func outboxWith(attempts int, events ...domain.EventType) (*mocks.MockOutboxRepository, *memoryOutbox) {
+	outbox := &memoryOutbox{}
+	for i, eventType := range events {
+		outbox.events = append(outbox.events, domain.OutboxEvent{
+			Seq:      int64(i + 1),
+			Attempts: attempts,
+			Event:    domain.Event{Type: eventType},
+		})
+	}
+
+	remove := func(seq int64) {
+		for i := range outbox.events {
+			if outbox.events[i].Seq == seq {
+				outbox.events = append(outbox.events[:i], outbox.events[i+1:]...)
+				return
+			}
+		}
+	}
+
+	repo := &mocks.MockOutboxRepository{
+		ClaimEventsFunc: func(_ context.Context, limit int, _ time.Duration) ([]domain.OutboxEvent, error) {
+			outbox.claims++
+			n := min(limit, len(outbox.events))
+			return append([]domain.OutboxEvent(nil), outbox.events[:n]...), nil
+		},
+		MarkEventProcessedFunc: func(_ context.Context, seq int64) error {
+			remove(seq)
+			return nil
+		},
+		RecordEventFailureFunc: func(_ context.Context, seq int64, _ string, maxAttempts int, retryAfter time.Duration) (bool, error) {
+			outbox.failures = append(outbox.failures, retryAfter)
+			for i := range outbox.events {
+				if outbox.events[i].Seq != seq {
+					continue
+				}
+				outbox.events[i].Attempts++
+				if outbox.events[i].Attempts >= maxAttempts {
+					outbox.dropped = append(outbox.dropped, seq)
+					remove(seq)
+					return true, nil
+				}
+			}
+			return false, nil
+		},
+		ReleaseEventsFunc: func(_ context.Context, seqs []int64) error {
+			outbox.released = append(outbox.released, seqs...)
+			return nil
+		},
+	}
+	return repo, outbox
+}
+
+// failingHandler отклоняет события одного типа
+type failingHandler struct {
+	recordingHandler
+	failOn domain.EventType
+}
+
+func (h *failingHandler) HandleEvent(ctx context.Context, event *domain.Event) error {
+	if event.Type == h.failOn {
+		return errors.New("handler failed")
+	}
+	return h.recordingHandler.HandleEvent(ctx, event)
+}
+
+func TestDispatcher_DrainsFullBatches(t *testing.T) {
+	repo, outbox := outboxWith(0,
+		domain.EventPullRequestCreated,
+		domain.EventReviewerReassigned,
+		domain.EventPullRequestMerged,
+	)
+	first, second := &recordingHandler{}, &recordingHandler{}
+	d := NewDispatcher(repo, first, second)
+	d.batchSize = 2
+
+	d.drain(context.Background())
+
+	expected := []domain.EventType{
+		domain.EventPullRequestCreated,
+		domain.EventReviewerReassigned,
+		domain.EventPullRequestMerged,
+	}
+	assert.Equal(t, expected, first.handled)
+	assert.Equal(t, expected, second.handled)
+	assert.Empty(t, outbox.events)
+	// Полная пачка, затем неполная — дальше ждём следующего тика
+	assert.Equal(t, 2, outbox.claims)
+}
+
+func TestDispatcher_StopsOnHandlerError(t *testing.T) {
+	repo, outbox := outboxWith(1, domain.EventPullRequestCreated, domain.EventPullRequestMerged)
+	failing := &recordingHandler{err: errors.New("db is down")}
+	d := NewDispatcher(repo, failing)
+
+	d.drain(context.Background())
+
+	assert.Empty(t, failing.handled)
+	assert.Equal(t, 1, outbox.claims)
+	// Попытка записана с растущей паузой, следующее событие возвращено в очередь
+	assert.Equal(t, []time.Duration{2 * domain.OutboxPollInterval}, outbox.failures)
+	assert.Equal(t, []int64{2}, outbox.released)
+	assert.Len(t, outbox.events, 2)
+}
+
+func TestDispatcher_DropsEventAfterMaxAttempts(t *testing.T) {
+	repo, outbox := outboxWith(domain.OutboxMaxAttempts-1,
+		domain.EventPullRequestCreated,
+		domain.EventPullRequestMerged,
+	)
+	handler := &failingHandler{failOn: domain.EventPullRequestCreated}
+	d := NewDispatcher(repo, handler)
+
+	d.drain(context.Background())
+
+	assert.Equal(t, []int64{1}, outbox.dropped)
+	assert.Equal(t, []domain.EventType{domain.EventPullRequestMerged}, handler.handled)
+	assert.Empty(t, outbox.released)
+	assert.Empty(t, outbox.events)
+}
+
+func TestDispatcher_RunStopsOnCancel(t *testing.T) {
+	repo, outbox := outboxWith(0)
+	d := NewDispatcher(repo)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		d.Run(ctx)
+		close(done)
+	}()
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("dispatcher did not stop after cancel")
+	}
+	assert.LessOrEqual(t, outbox.claims, 1)
+}
+
+func TestDispatcher_RunCleanupDeletesProcessedEvents(t *testing.T) {
+	retentions := make(chan time.Duration, 1)
+	repo := &mocks.MockOutboxRepository{
+		DeleteProcessedEventsFunc: func(_ context.Context, retention time.Duration) (int64, error) {
+			select {
+			case retentions <- retention:
+			default:
+			}
+			return 3, nil
+		},
+	}
+	d := NewDispatcher(repo)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		d.RunCleanup(ctx, time.Millisecond, domain.OutboxRetention)
+		close(done)
+	}()
+
+	select {
+	case retention := <-retentions:
+		assert.Equal(t, domain.OutboxRetention, retention)
+	case <-time.After(5 * time.Second):
+		t.Fatal("cleanup did not run")
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("cleanup did not stop after cancel")
+	}
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/service"
+	"context"
+)
+
+// apiKeyPolicy пускает к управлению ключами только администратора: ключ API не может выпускать другие ключи.
+// Все методы проверяются явно, поэтому новый метод APIKeyService не соберётся без проверки прав
+type apiKeyPolicy struct {
+	inner service.APIKeyService
+}
+
+func (s *PolicyServiceImpl) WrapAPIKeyService(inner service.APIKeyService) service.APIKeyService {
+	return &apiKeyPolicy{inner: inner}
+}
+
+func (s *apiKeyPolicy) CreateAPIKey(ctx context.Context, req *domain.CreateAPIKeyReq) (*domain.APIKey, error) {
+	if err := requireAdminOnly(domain.ActorFromContext(ctx), "api key creation"); err != nil {
+		return nil, err
+	}
+	return s.inner.CreateAPIKey(ctx, req)
+}
+
+func (s *apiKeyPolicy) ListAPIKeys(ctx context.Context) ([]domain.APIKey, error) {
+	if err := requireAdminOnly(domain.ActorFromContext(ctx), "listing api keys"); err != nil {
+		return nil, err
+	}
+	return s.inner.ListAPIKeys(ctx)
+}
+
+func (s *apiKeyPolicy) RevokeAPIKey(ctx context.Context, req *domain.RevokeAPIKeyReq) (*domain.APIKey, error) {
+	if err := requireAdminOnly(domain.ActorFromContext(ctx), "api key revocation"); err != nil {
+		return nil, err
+	}
+	return s.inner.RevokeAPIKey(ctx, req)
+}
@@ -0,0 +1,23 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/service"
+	"context"
+)
+
+// auditPolicy пускает к журналу аудита только администратора
+type auditPolicy struct {
+	inner service.AuditService
+}
+
+func (s *PolicyServiceImpl) WrapAuditService(inner service.AuditService) service.AuditService {
+	return &auditPolicy{inner: inner}
+}
+
+func (s *auditPolicy) ListAuditEvents(ctx context.Context, req *domain.ListAuditEventsReq) (*domain.ListAuditEventsResponse, error) {
+	if err := requireAdminOnly(domain.ActorFromContext(ctx), "reading the audit log"); err != nil {
+		return nil, err
+	}
+	return s.inner.ListAuditEvents(ctx, req)
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// PolicyServiceImpl проверяет права на изменяющие операции по роли инициатора.
+// Проверки подключаются обёртками Wrap*Service поверх остальных сервисов:
+//   - admin может всё;
+//   - team_lead управляет своей командой, её пользователями и PR её участников;
+//   - member работает только от своего имени: свои PR, своё переназначение, своё ревью;
+//   - ключ API с разрешением pr:write меняет любые PR, кроме ревью, с team:admin — команды и пользователей;
+//   - вебхуки, ключи API и журнал аудита доступны только администратору.
+//
+// Команда тимлида — команда его пользователя в БД. Чтение доступно всем аутентифицированным пользователям
+type PolicyServiceImpl struct {
+	userRepo repository.UserRepositoryInterface
+	prRepo   repository.PullRequestRepositoryInterface
+}
+
+func NewPolicyService(
+	userRepo repository.UserRepositoryInterface,
+	prRepo repository.PullRequestRepositoryInterface,
+) *PolicyServiceImpl {
+	return &PolicyServiceImpl{
+		userRepo: userRepo,
+		prRepo:   prRepo,
+	}
+}
+
+func forbidden(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: "+format, append([]interface{}{domain.ErrForbidden}, args...)...)
+}
+
+// requireAdmin пропускает администратора и ключ API с разрешением scope
+func requireAdmin(actor domain.Actor, scope domain.APIKeyScope, operation string) error {
+	if actor.IsAdmin() || actor.HasScope(scope) {
+		return nil
+	}
+	if actor.Role == domain.RoleService {
+		return forbidden("%s requires the %s scope", operation, scope)
+	}
+	return forbidden("%s is allowed only for admins", operation)
+}
+
+// requireAdminOnly пропускает только администратора: эти операции не открываются ключу API ни с каким разрешением
+func requireAdminOnly(actor domain.Actor, operation string) error {
+	if actor.IsAdmin() {
+		return nil
+	}
+	if actor.Role == domain.RoleService {
+		return forbidden("%s is not available to API keys", operation)
+	}
+	return forbidden("%s is allowed only for admins", operation)
+}
+
+// leadTeam возвращает команду тимлида. Тимлид без пользователя в БД не управляет ни одной командой
+func (s *PolicyServiceImpl) leadTeam(ctx context.Context, actor domain.Actor) (string, error) {
+	lead, err := s.userRepo.GetUserByID(ctx, actor.ID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return "", forbidden("team lead %s is not a member of any team", actor.ID)
+	}
+	if err != nil {
+		return "", err
+	}
+	return lead.TeamName, nil
+}
+
+// requireAdminOrLeadOf пропускает администратора, ключ API с разрешением scope и тимлида команды teamName
+func (s *PolicyServiceImpl) requireAdminOrLeadOf(
+	ctx context.Context,
+	actor domain.Actor,
+	scope domain.APIKeyScope,
+	teamName, operation string,
+) error {
+	if actor.HasScope(scope) {
+		return nil
+	}
+
+	switch actor.Role {
+	case domain.RoleAdmin:
+		return nil
+	case domain.RoleService:
+		return forbidden("%s requires the %s scope", operation, scope)
+	case domain.RoleTeamLead:
+		leadTeam, err := s.leadTeam(ctx, actor)
+		if err != nil {
+			return err
+		}
+		if leadTeam != teamName {
+			return forbidden("team lead may %s only in own team", operation)
+		}
+		return nil
+	default:
+		return forbidden("%s is allowed only for admins and team leads", operation)
+	}
+}
+
+// userTeam возвращает команду пользователя; ErrNotFound возвращается как есть, чтобы клиент получил 404
+func (s *PolicyServiceImpl) userTeam(ctx context.Context, userID string) (string, error) {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	return user.TeamName, nil
+}
+
+// requirePullRequestAccess пропускает администратора, ключ API с разрешением pr:write, тимлида команды автора PR и самого автора
+func (s *PolicyServiceImpl) requirePullRequestAccess(ctx context.Context, actor domain.Actor, prID, operation string) error {
+	if actor.IsAdmin() || actor.HasScope(domain.ScopePullRequestWrite) {
+		return nil
+	}
+	if actor.Role == domain.RoleService {
+		return forbidden("%s requires the %s scope", operation, domain.ScopePullRequestWrite)
+	}
+
+	pr, err := s.prRepo.GetPullRequestByID(ctx, prID)
+	if err != nil {
+		return err
+	}
+	if pr.AuthorID == actor.ID {
+		return nil
+	}
+	if actor.Role != domain.RoleTeamLead {
+		return forbidden("only the author may %s this PR", operation)
+	}
+
+	authorTeam, err := s.userTeam(ctx, pr.AuthorID)
+	if err != nil {
+		return err
+	}
+	return s.requireAdminOrLeadOf(ctx, actor, domain.ScopePullRequestWrite, authorTeam, operation)
+}
@@ -0,0 +1,265 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/repository/mocks"
+	"AVITOSAMPISHU/internal/service"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Пользователи: lead-a и alice в команде team-a, lead-b и bob в команде team-b; PR pr-a создан alice
+func newTestPolicy() *PolicyServiceImpl {
+	teams := map[string]string{"lead-a": "team-a", "alice": "team-a", "lead-b": "team-b", "bob": "team-b"}
+	userRepo := &mocks.MockUserRepository{
+		GetUserByIDFunc: func(_ context.Context, userID string) (*domain.User, error) {
+			team, ok := teams[userID]
+			if !ok {
+				return nil, domain.ErrNotFound
+			}
+			return &domain.User{UserID: userID, TeamName: team, IsActive: true}, nil
+		},
+	}
+	prRepo := &mocks.MockPullRequestRepository{
+		GetPullRequestByIDFunc: func(_ context.Context, prID string) (*domain.PullRequest, error) {
+			if prID != "pr-a" {
+				return nil, domain.ErrNotFound
+			}
+			return &domain.PullRequest{PullRequestID: prID, AuthorID: "alice", Status: domain.PRStatusOpen}, nil
+		},
+	}
+	return NewPolicyService(userRepo, prRepo)
+}
+
+func actorCtx(id string, role domain.Role) context.Context {
+	return domain.ContextWithActor(context.Background(), domain.Actor{ID: id, Role: role})
+}
+
+func apiKeyCtx(scopes ...domain.APIKeyScope) context.Context {
+	return domain.ContextWithActor(context.Background(), domain.Actor{ID: "apikey:1", Role: domain.RoleService, Scopes: scopes})
+}
+
+type stubUserService struct {
+	service.UserService
+	called bool
+}
+
+func (s *stubUserService) DeactivateTeamMembers(context.Context, *domain.DeactivateTeamMembersReq) (*domain.DeactivateTeamMembersRes, error) {
+	s.called = true
+	return &domain.DeactivateTeamMembersRes{}, nil
+}
+
+func TestUserPolicy_DeactivateTeamMembers(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		team    string
+		allowed bool
+	}{
+		{"admin any team", actorCtx(domain.AdminActorID, domain.RoleAdmin), "team-b", true},
+		{"lead own team", actorCtx("lead-a", domain.RoleTeamLead), "team-a", true},
+		{"lead other team", actorCtx("lead-a", domain.RoleTeamLead), "team-b", false},
+		{"lead without user", actorCtx("ghost", domain.RoleTeamLead), "team-a", false},
+		{"member", actorCtx("alice", domain.RoleMember), "team-a", false},
+		{"api key with team:admin", apiKeyCtx(domain.ScopeTeamAdmin), "team-b", true},
+		{"api key with pr:write", apiKeyCtx(domain.ScopePullRequestWrite), "team-b", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner := &stubUserService{}
+			svc := newTestPolicy().WrapUserService(inner)
+
+			_, err := svc.DeactivateTeamMembers(tt.ctx, &domain.DeactivateTeamMembersReq{TeamName: tt.team})
+			assert.Equal(t, tt.allowed, inner.called)
+			if tt.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, domain.ErrForbidden)
+			}
+		})
+	}
+}
+
+type stubTeamService struct {
+	service.TeamService
+}
+
+func (stubTeamService) CreateTeam(_ context.Context, team *domain.Team) (*domain.Team, error) {
+	return team, nil
+}
+
+func TestTeamPolicy_CreateTeamRequiresAdmin(t *testing.T) {
+	svc := newTestPolicy().WrapTeamService(stubTeamService{})
+
+	_, err := svc.CreateTeam(actorCtx("lead-a", domain.RoleTeamLead), &domain.Team{TeamName: "team-c"})
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+
+	_, err = svc.CreateTeam(actorCtx(domain.AdminActorID, domain.RoleAdmin), &domain.Team{TeamName: "team-c"})
+	assert.NoError(t, err)
+}
+
+type stubPullRequestService struct {
+	service.PullRequestService
+}
+
+func (stubPullRequestService) CreatePullRequest(_ context.Context, req *domain.CreatePullRequestReq) (*domain.PullRequest, error) {
+	return &domain.PullRequest{PullRequestID: req.PullRequestID, AuthorID: req.AuthorID}, nil
+}
+
+func (stubPullRequestService) ReassignReviewer(_ context.Context, req *domain.ReassignReviewerReq) (*domain.PullRequest, string, error) {
+	return &domain.PullRequest{PullRequestID: req.PullRequestID}, "new", nil
+}
+
+func (stubPullRequestService) MergePullRequest(_ context.Context, req *domain.MergePullRequestReq) (*domain.PullRequest, error) {
+	return &domain.PullRequest{PullRequestID: req.PullRequestID}, nil
+}
+
+func (stubPullRequestService) SubmitReview(_ context.Context, req *domain.SubmitReviewReq) (*domain.PullRequest, error) {
+	return &domain.PullRequest{PullRequestID: req.PullRequestID}, nil
+}
+
+func TestPullRequestPolicy(t *testing.T) {
+	svc := newTestPolicy().WrapPullRequestService(stubPullRequestService{})
+
+	t.Run("member reassigns only themselves", func(t *testing.T) {
+		_, _, err := svc.ReassignReviewer(actorCtx("bob", domain.RoleMember), &domain.ReassignReviewerReq{PullRequestID: "pr-a", OldUserID: "bob"})
+		assert.NoError(t, err)
+
+		_, _, err = svc.ReassignReviewer(actorCtx("bob", domain.RoleMember), &domain.ReassignReviewerReq{PullRequestID: "pr-a", OldUserID: "lead-a"})
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+	})
+
+	t.Run("lead reassigns on own team PR", func(t *testing.T) {
+		_, _, err := svc.ReassignReviewer(actorCtx("lead-a", domain.RoleTeamLead), &domain.ReassignReviewerReq{PullRequestID: "pr-a", OldUserID: "bob"})
+		assert.NoError(t, err)
+
+		_, _, err = svc.ReassignReviewer(actorCtx("lead-b", domain.RoleTeamLead), &domain.ReassignReviewerReq{PullRequestID: "pr-a", OldUserID: "alice"})
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+	})
+
+	t.Run("merge by author lead or admin", func(t *testing.T) {
+		allowed := []context.Context{
+			actorCtx("alice", domain.RoleMember),
+			actorCtx("lead-a", domain.RoleTeamLead),
+			actorCtx(domain.AdminActorID, domain.RoleAdmin),
+		}
+		for _, ctx := range allowed {
+			_, err := svc.MergePullRequest(ctx, &domain.MergePullRequestReq{PullRequestID: "pr-a"})
+			assert.NoError(t, err)
+		}
+
+		_, err := svc.MergePullRequest(actorCtx("bob", domain.RoleMember), &domain.MergePullRequestReq{PullRequestID: "pr-a"})
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+	})
+
+	t.Run("unknown PR keeps not found", func(t *testing.T) {
+		_, err := svc.MergePullRequest(actorCtx("bob", domain.RoleMember), &domain.MergePullRequestReq{PullRequestID: "pr-x"})
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("review only on behalf of yourself", func(t *testing.T) {
+		_, err := svc.SubmitReview(actorCtx("bob", domain.RoleMember), &domain.SubmitReviewReq{PullRequestID: "pr-a", ReviewerID: "bob"})
+		require.NoError(t, err)
+
+		_, err = svc.SubmitReview(actorCtx("lead-b", domain.RoleTeamLead), &domain.SubmitReviewReq{PullRequestID: "pr-a", ReviewerID: "bob"})
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+	})
+}
+
+func TestPullRequestPolicy_APIKeyScopes(t *testing.T) {
+	svc := newTestPolicy().WrapPullRequestService(stubPullRequestService{})
+	writer := apiKeyCtx(domain.ScopePullRequestWrite)
+	teamAdmin := apiKeyCtx(domain.ScopeTeamAdmin)
+
+	t.Run("pr:write creates and merges on behalf of anyone", func(t *testing.T) {
+		_, err := svc.CreatePullRequest(writer, &domain.CreatePullRequestReq{PullRequestID: "pr-b", AuthorID: "bob"})
+		assert.NoError(t, err)
+
+		_, err = svc.MergePullRequest(writer, &domain.MergePullRequestReq{PullRequestID: "pr-a"})
+		assert.NoError(t, err)
+
+		_, _, err = svc.ReassignReviewer(writer, &domain.ReassignReviewerReq{PullRequestID: "pr-a", OldUserID: "bob"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("other scopes are forbidden", func(t *testing.T) {
+		_, err := svc.CreatePullRequest(teamAdmin, &domain.CreatePullRequestReq{PullRequestID: "pr-b", AuthorID: "bob"})
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+
+		_, err = svc.MergePullRequest(teamAdmin, &domain.MergePullRequestReq{PullRequestID: "pr-a"})
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+
+		_, _, err = svc.ReassignReviewer(teamAdmin, &domain.ReassignReviewerReq{PullRequestID: "pr-a", OldUserID: "bob"})
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+	})
+
+	t.Run("pr:write does not submit reviews", func(t *testing.T) {
+		_, err := svc.SubmitReview(writer, &domain.SubmitReviewReq{PullRequestID: "pr-a", ReviewerID: "bob"})
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+	})
+
+	t.Run("team:admin creates teams", func(t *testing.T) {
+		teams := newTestPolicy().WrapTeamService(stubTeamService{})
+
+		_, err := teams.CreateTeam(teamAdmin, &domain.Team{TeamName: "team-c"})
+		assert.NoError(t, err)
+
+		_, err = teams.CreateTeam(writer, &domain.Team{TeamName: "team-c"})
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+	})
+}
+
+type stubWebhookService struct {
+	service.WebhookService
+}
+
+func (stubWebhookService) ReplayDelivery(context.Context, *domain.ReplayWebhookDeliveryReq) (*domain.WebhookDelivery, error) {
+	return &domain.WebhookDelivery{}, nil
+}
+
+type stubAPIKeyService struct {
+	service.APIKeyService
+}
+
+func (stubAPIKeyService) CreateAPIKey(_ context.Context, req *domain.CreateAPIKeyReq) (*domain.APIKey, error) {
+	return &domain.APIKey{Name: req.Name}, nil
+}
+
+type stubAuditService struct{}
+
+func (stubAuditService) ListAuditEvents(context.Context, *domain.ListAuditEventsReq) (*domain.ListAuditEventsResponse, error) {
+	return &domain.ListAuditEventsResponse{}, nil
+}
+
+func TestAdminOnlyPolicies(t *testing.T) {
+	policy := newTestPolicy()
+	webhooks := policy.WrapWebhookService(stubWebhookService{})
+	apiKeys := policy.WrapAPIKeyService(stubAPIKeyService{})
+	audit := policy.WrapAuditService(stubAuditService{})
+
+	call := func(ctx context.Context) []error {
+		_, webhookErr := webhooks.ReplayDelivery(ctx, &domain.ReplayWebhookDeliveryReq{})
+		_, apiKeyErr := apiKeys.CreateAPIKey(ctx, &domain.CreateAPIKeyReq{Name: "ci-bot"})
+		_, auditErr := audit.ListAuditEvents(ctx, &domain.ListAuditEventsReq{})
+		return []error{webhookErr, apiKeyErr, auditErr}
+	}
+
+	for _, err := range call(actorCtx(domain.AdminActorID, domain.RoleAdmin)) {
+		assert.NoError(t, err)
+	}
+
+	for name, ctx := range map[string]context.Context{
+		"team lead":           actorCtx("lead-a", domain.RoleTeamLead),
+		"member":              actorCtx("alice", domain.RoleMember),
+		"api key with scopes": apiKeyCtx(domain.ScopePullRequestWrite, domain.ScopeTeamAdmin),
+		"anonymous":           context.Background(),
+	} {
+		for _, err := range call(ctx) {
+			assert.ErrorIs(t, err, domain.ErrForbidden, name)
+		}
+	}
+}
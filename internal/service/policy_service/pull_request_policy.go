@@ -0,0 +1,93 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/service"
+	"context"
+)
+
+// pullRequestPolicy проверяет права на изменяющие операции PullRequestService
+type pullRequestPolicy struct {
+	service.PullRequestService
+	policy *PolicyServiceImpl
+}
+
+func (s *PolicyServiceImpl) WrapPullRequestService(inner service.PullRequestService) service.PullRequestService {
+	return &pullRequestPolicy{PullRequestService: inner, policy: s}
+}
+
+// CreatePullRequest участник создаёт PR только от своего имени, тимлид — от имени участников своей команды,
+// ключ API с разрешением pr:write — от имени любого пользователя
+func (s *pullRequestPolicy) CreatePullRequest(ctx context.Context, req *domain.CreatePullRequestReq) (*domain.PullRequest, error) {
+	actor := domain.ActorFromContext(ctx)
+	if !actor.IsAdmin() && !actor.HasScope(domain.ScopePullRequestWrite) && req.AuthorID != actor.ID {
+		if actor.Role == domain.RoleService {
+			return nil, forbidden("create PR requires the %s scope", domain.ScopePullRequestWrite)
+		}
+		if actor.Role != domain.RoleTeamLead {
+			return nil, forbidden("PR can be created only on behalf of yourself")
+		}
+		authorTeam, err := s.policy.userTeam(ctx, req.AuthorID)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.policy.requireAdminOrLeadOf(ctx, actor, domain.ScopePullRequestWrite, authorTeam, "create PR"); err != nil {
+			return nil, err
+		}
+	}
+	return s.PullRequestService.CreatePullRequest(ctx, req)
+}
+
+func (s *pullRequestPolicy) MergePullRequest(ctx context.Context, req *domain.MergePullRequestReq) (*domain.PullRequest, error) {
+	if err := s.policy.requirePullRequestAccess(ctx, domain.ActorFromContext(ctx), req.PullRequestID, "merge"); err != nil {
+		return nil, err
+	}
+	return s.PullRequestService.MergePullRequest(ctx, req)
+}
+
+func (s *pullRequestPolicy) ClosePullRequest(ctx context.Context, req *domain.ClosePullRequestReq) (*domain.PullRequest, error) {
+	if err := s.policy.requirePullRequestAccess(ctx, domain.ActorFromContext(ctx), req.PullRequestID, "close"); err != nil {
+		return nil, err
+	}
+	return s.PullRequestService.ClosePullRequest(ctx, req)
+}
+
+func (s *pullRequestPolicy) ReopenPullRequest(ctx context.Context, req *domain.ReopenPullRequestReq) (*domain.PullRequest, error) {
+	if err := s.policy.requirePullRequestAccess(ctx, domain.ActorFromContext(ctx), req.PullRequestID, "reopen"); err != nil {
+		return nil, err
+	}
+	return s.PullRequestService.ReopenPullRequest(ctx, req)
+}
+
+func (s *pullRequestPolicy) MarkReady(ctx context.Context, req *domain.MarkReadyReq) (*domain.PullRequest, error) {
+	if err := s.policy.requirePullRequestAccess(ctx, domain.ActorFromContext(ctx), req.PullRequestID, "mark ready"); err != nil {
+		return nil, err
+	}
+	return s.PullRequestService.MarkReady(ctx, req)
+}
+
+// ReassignReviewer участник может снять с ревью только себя, тимлид — любого ревьювера на PR своей команды
+func (s *pullRequestPolicy) ReassignReviewer(
+	ctx context.Context,
+	req *domain.ReassignReviewerReq,
+) (*domain.PullRequest, string, error) {
+	actor := domain.ActorFromContext(ctx)
+	if !actor.IsAdmin() && req.OldUserID != actor.ID {
+		if actor.Role != domain.RoleTeamLead && actor.Role != domain.RoleService {
+			return nil, "", forbidden("members may reassign only themselves")
+		}
+		if err := s.policy.requirePullRequestAccess(ctx, actor, req.PullRequestID, "reassign reviewers on"); err != nil {
+			return nil, "", err
+		}
+	}
+	return s.PullRequestService.ReassignReviewer(ctx, req)
+}
+
+// SubmitReview решение оставляет только сам ревьювер: разрешение pr:write не позволяет ключу API одобрять PR за людей
+func (s *pullRequestPolicy) SubmitReview(ctx context.Context, req *domain.SubmitReviewReq) (*domain.PullRequest, error) {
+	actor := domain.ActorFromContext(ctx)
+	if !actor.IsAdmin() && req.ReviewerID != actor.ID {
+		return nil, forbidden("review can be submitted only on behalf of yourself")
+	}
+	return s.PullRequestService.SubmitReview(ctx, req)
+}
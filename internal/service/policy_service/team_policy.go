@@ -0,0 +1,32 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/service"
+	"context"
+)
+
+// teamPolicy проверяет права на изменяющие операции TeamService
+type teamPolicy struct {
+	service.TeamService
+	policy *PolicyServiceImpl
+}
+
+func (s *PolicyServiceImpl) WrapTeamService(inner service.TeamService) service.TeamService {
+	return &teamPolicy{TeamService: inner, policy: s}
+}
+
+func (s *teamPolicy) CreateTeam(ctx context.Context, team *domain.Team) (*domain.Team, error) {
+	if err := requireAdmin(domain.ActorFromContext(ctx), domain.ScopeTeamAdmin, "team creation"); err != nil {
+		return nil, err
+	}
+	return s.TeamService.CreateTeam(ctx, team)
+}
+
+func (s *teamPolicy) UpdateTeamSettings(ctx context.Context, req *domain.UpdateTeamSettingsReq) (*domain.TeamSettings, error) {
+	actor := domain.ActorFromContext(ctx)
+	if err := s.policy.requireAdminOrLeadOf(ctx, actor, domain.ScopeTeamAdmin, req.TeamName, "update settings"); err != nil {
+		return nil, err
+	}
+	return s.TeamService.UpdateTeamSettings(ctx, req)
+}
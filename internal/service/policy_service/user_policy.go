@@ -0,0 +1,42 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/service"
+	"context"
+)
+
+// userPolicy проверяет права на изменяющие операции UserService
+type userPolicy struct {
+	service.UserService
+	policy *PolicyServiceImpl
+}
+
+func (s *PolicyServiceImpl) WrapUserService(inner service.UserService) service.UserService {
+	return &userPolicy{UserService: inner, policy: s}
+}
+
+func (s *userPolicy) SetIsActive(ctx context.Context, req *domain.SetIsActiveRequest) (*domain.User, error) {
+	actor := domain.ActorFromContext(ctx)
+	if !actor.IsAdmin() && !actor.HasScope(domain.ScopeTeamAdmin) {
+		teamName, err := s.policy.userTeam(ctx, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.policy.requireAdminOrLeadOf(ctx, actor, domain.ScopeTeamAdmin, teamName, "change user activity"); err != nil {
+			return nil, err
+		}
+	}
+	return s.UserService.SetIsActive(ctx, req)
+}
+
+func (s *userPolicy) DeactivateTeamMembers(
+	ctx context.Context,
+	req *domain.DeactivateTeamMembersReq,
+) (*domain.DeactivateTeamMembersRes, error) {
+	actor := domain.ActorFromContext(ctx)
+	if err := s.policy.requireAdminOrLeadOf(ctx, actor, domain.ScopeTeamAdmin, req.TeamName, "deactivate members"); err != nil {
+		return nil, err
+	}
+	return s.UserService.DeactivateTeamMembers(ctx, req)
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/service"
+	"context"
+)
+
+// webhookPolicy пускает к вебхукам только администратора: подписка раскрывает секрет и данные всех PR.
+// Все методы проверяются явно, поэтому новый метод WebhookService не соберётся без проверки прав
+type webhookPolicy struct {
+	inner service.WebhookService
+}
+
+func (s *PolicyServiceImpl) WrapWebhookService(inner service.WebhookService) service.WebhookService {
+	return &webhookPolicy{inner: inner}
+}
+
+func (s *webhookPolicy) CreateWebhook(ctx context.Context, req *domain.CreateWebhookReq) (*domain.WebhookSubscription, error) {
+	if err := requireAdminOnly(domain.ActorFromContext(ctx), "webhook creation"); err != nil {
+		return nil, err
+	}
+	return s.inner.CreateWebhook(ctx, req)
+}
+
+func (s *webhookPolicy) ListWebhooks(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	if err := requireAdminOnly(domain.ActorFromContext(ctx), "listing webhooks"); err != nil {
+		return nil, err
+	}
+	return s.inner.ListWebhooks(ctx)
+}
+
+func (s *webhookPolicy) DeleteWebhook(ctx context.Context, req *domain.DeleteWebhookReq) error {
+	if err := requireAdminOnly(domain.ActorFromContext(ctx), "webhook deletion"); err != nil {
+		return err
+	}
+	return s.inner.DeleteWebhook(ctx, req)
+}
+
+func (s *webhookPolicy) ListDeliveries(ctx context.Context, req *domain.ListWebhookDeliveriesReq) ([]domain.WebhookDelivery, error) {
+	if err := requireAdminOnly(domain.ActorFromContext(ctx), "listing webhook deliveries"); err != nil {
+		return nil, err
+	}
+	return s.inner.ListDeliveries(ctx, req)
+}
+
+func (s *webhookPolicy) ReplayDelivery(ctx context.Context, req *domain.ReplayWebhookDeliveryReq) (*domain.WebhookDelivery, error) {
+	if err := requireAdminOnly(domain.ActorFromContext(ctx), "webhook replay"); err != nil {
+		return nil, err
+	}
+	return s.inner.ReplayDelivery(ctx, req)
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/infrastructure/tracing"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"fmt"
+	"time"
+)
+
+// activatePullRequest выполняет переход from -> OPEN: проверяет текущий статус,
+// выбирает ревьюверов и атомарно сохраняет новый статус вместе с назначениями
+func (s *PullRequestServiceImpl) activatePullRequest(
+	ctx context.Context,
+	operation string,
+	prID string,
+	from domain.PRStatus,
+) (*domain.PullRequest, error) {
+	start := time.Now()
+
+	ctx, span := tracing.Start(ctx, "PullRequestService."+operation)
+	defer span.End()
+
+	logger.LogBusinessTransactionStart(ctx, operation, map[string]interface{}{
+		"pr_id": prID,
+	})
+
+	pr, err := s.prRepo.GetPullRequestByID(ctx, prID)
+	if err != nil {
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
+			"pr_id": prID,
+			"error": err.Error(),
+		})
+		return nil, err
+	}
+
+	if pr.Status != from {
+		err = fmt.Errorf("%w: %s -> %s, expected %s", domain.ErrInvalidTransition, pr.Status, domain.PRStatusOpen, from)
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
+			"pr_id": prID,
+			"error": err.Error(),
+		})
+		return nil, err
+	}
+
+	if err = domain.ValidateStatusTransition(pr.Status, domain.PRStatusOpen); err != nil {
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
+			"pr_id": prID,
+			"error": err.Error(),
+		})
+		return nil, err
+	}
+
+	author, err := s.userRepo.GetUserByID(ctx, pr.AuthorID)
+	if err != nil {
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
+			"pr_id":  prID,
+			"error":  err.Error(),
+			"reason": "author_not_found",
+		})
+		return nil, err
+	}
+
+	team, err := s.teamRepo.GetTeamByName(ctx, author.TeamName)
+	if err != nil {
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
+			"pr_id":  prID,
+			"error":  err.Error(),
+			"reason": "team_not_found",
+		})
+		return nil, err
+	}
+
+	settings := team.EffectiveSettings()
+	reviewers, err := s.selectReviewers(ctx, team, pr.AuthorID, settings.RequiredReviewers)
+	if err != nil {
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
+			"pr_id":  prID,
+			"error":  err.Error(),
+			"reason": "select_reviewers_failed",
+		})
+		return nil, err
+	}
+	needMoreReviewers := len(reviewers) < settings.RequiredReviewers
+
+	if err = s.prRepo.OpenPullRequest(ctx, prID, from, reviewers, needMoreReviewers); err != nil {
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
+			"pr_id": prID,
+			"error": err.Error(),
+		})
+		return nil, err
+	}
+
+	opened, err := s.prRepo.GetPullRequestByID(ctx, prID)
+	if err != nil {
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
+			"pr_id": prID,
+			"error": err.Error(),
+		})
+		return nil, err
+	}
+
+	logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), true, map[string]interface{}{
+		"pr_id":           prID,
+		"reviewers_count": len(reviewers),
+	})
+	logger.LogCriticalEvent("pull_request_opened", map[string]interface{}{
+		"pr_id":           prID,
+		"previous_status": string(from),
+		"reviewers":       reviewers,
+	})
+
+	return opened, nil
+}
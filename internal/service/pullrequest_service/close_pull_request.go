@@ -0,0 +1,72 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/infrastructure/tracing"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"time"
+)
+
+// ClosePullRequest отклоняет PR без слияния и освобождает его ревьюверов
+func (s *PullRequestServiceImpl) ClosePullRequest(
+	ctx context.Context,
+	req *domain.ClosePullRequestReq,
+) (*domain.PullRequest, error) {
+	start := time.Now()
+	operation := "ClosePullRequest"
+
+	ctx, span := tracing.Start(ctx, "PullRequestService."+operation)
+	defer span.End()
+
+	logger.LogBusinessTransactionStart(ctx, operation, map[string]interface{}{
+		"pr_id": req.PullRequestID,
+	})
+
+	pr, err := s.prRepo.GetPullRequestByID(ctx, req.PullRequestID)
+	if err != nil {
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
+			"pr_id": req.PullRequestID,
+			"error": err.Error(),
+		})
+		return nil, err
+	}
+
+	if err = domain.ValidateStatusTransition(pr.Status, domain.PRStatusClosed); err != nil {
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
+			"pr_id": req.PullRequestID,
+			"error": err.Error(),
+		})
+		return nil, err
+	}
+
+	released, err := s.prRepo.ClosePullRequest(ctx, req.PullRequestID)
+	if err != nil {
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
+			"pr_id": req.PullRequestID,
+			"error": err.Error(),
+		})
+		return nil, err
+	}
+
+	closed, err := s.prRepo.GetPullRequestByID(ctx, req.PullRequestID)
+	if err != nil {
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
+			"pr_id": req.PullRequestID,
+			"error": err.Error(),
+		})
+		return nil, err
+	}
+
+	logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), true, map[string]interface{}{
+		"pr_id":              req.PullRequestID,
+		"released_reviewers": len(released),
+	})
+	logger.LogCriticalEvent("pull_request_closed", map[string]interface{}{
+		"pr_id":              req.PullRequestID,
+		"previous_status":    string(pr.Status),
+		"released_reviewers": released,
+	})
+
+	return closed, nil
+}
@@ -2,7 +2,8 @@ package service
 
 import (
 	"AVITOSAMPISHU/internal/domain"
-	"AVITOSAMPISHU/pkg/helpers"
+	"AVITOSAMPISHU/internal/infrastructure/tracing"
+	reviewer_selector "AVITOSAMPISHU/internal/service/reviewer_selector"
 	"AVITOSAMPISHU/pkg/logger"
 	"context"
 	"time"
@@ -15,15 +16,19 @@ func (s *PullRequestServiceImpl) CreatePullRequest(
 	start := time.Now()
 	operation := "CreatePullRequest"
 
-	logger.LogBusinessTransactionStart(operation, map[string]interface{}{
+	ctx, span := tracing.Start(ctx, "PullRequestService."+operation)
+	defer span.End()
+
+	logger.LogBusinessTransactionStart(ctx, operation, map[string]interface{}{
 		"pr_id":   req.PullRequestID,
 		"pr_name": req.PullRequestName,
 		"author":  req.AuthorID,
+		"draft":   req.Draft,
 	})
 
 	existingPR, err := s.prRepo.GetPullRequestByID(ctx, req.PullRequestID)
 	if err == nil && existingPR != nil {
-		logger.LogBusinessTransactionEnd(operation, time.Since(start), false, map[string]interface{}{
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
 			"pr_id":  req.PullRequestID,
 			"error":  domain.ErrPRExists.Error(),
 			"reason": "pr_already_exists",
@@ -31,7 +36,7 @@ func (s *PullRequestServiceImpl) CreatePullRequest(
 		return nil, domain.ErrPRExists
 	}
 	if err != nil && err != domain.ErrNotFound {
-		logger.LogBusinessTransactionEnd(operation, time.Since(start), false, map[string]interface{}{
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
 			"pr_id":  req.PullRequestID,
 			"error":  err.Error(),
 			"reason": "error_checking_pr",
@@ -41,7 +46,7 @@ func (s *PullRequestServiceImpl) CreatePullRequest(
 
 	author, err := s.userRepo.GetUserByID(ctx, req.AuthorID)
 	if err != nil {
-		logger.LogBusinessTransactionEnd(operation, time.Since(start), false, map[string]interface{}{
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
 			"pr_id":  req.PullRequestID,
 			"error":  err.Error(),
 			"reason": "author_not_found",
@@ -51,7 +56,7 @@ func (s *PullRequestServiceImpl) CreatePullRequest(
 
 	team, err := s.teamRepo.GetTeamByName(ctx, author.TeamName)
 	if err != nil {
-		logger.LogBusinessTransactionEnd(operation, time.Since(start), false, map[string]interface{}{
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
 			"pr_id":  req.PullRequestID,
 			"error":  err.Error(),
 			"reason": "team_not_found",
@@ -59,33 +64,62 @@ func (s *PullRequestServiceImpl) CreatePullRequest(
 		return nil, err
 	}
 
+	settings := team.EffectiveSettings()
+
+	status := domain.PRStatusOpen
+	if req.Draft {
+		status = domain.PRStatusDraft
+	}
+
 	now := time.Now()
 	pr := &domain.PullRequest{
 		PullRequestID:     req.PullRequestID,
 		PullRequestName:   req.PullRequestName,
 		AuthorID:          req.AuthorID,
-		Status:            domain.PRStatusOpen,
-		AssignedReviewers: make([]string, 0, domain.MaxReviewersCount),
+		AuthorUsername:    author.Username,
+		TeamName:          author.TeamName,
+		Status:            status,
+		AssignedReviewers: make([]string, 0, settings.RequiredReviewers),
 		CreatedAt:         &now,
 	}
 
-	reviewers := helpers.RandSelectReviewers(team.Members, req.AuthorID, domain.MaxReviewersCount)
-	needMoreReviewers := len(reviewers) < domain.MaxReviewersCount
+	// Черновику ревьюверы назначаются только при переводе в OPEN
+	reviewers := make([]string, 0, settings.RequiredReviewers)
+	needMoreReviewers := false
+	if !req.Draft {
+		reviewers, err = s.selectReviewers(ctx, team, req.AuthorID, settings.RequiredReviewers)
+		if err != nil {
+			logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
+				"pr_id":  req.PullRequestID,
+				"error":  err.Error(),
+				"reason": "select_reviewers_failed",
+			})
+			return nil, err
+		}
+		needMoreReviewers = len(reviewers) < settings.RequiredReviewers
+	}
+
+	// PR собирается полностью до записи: в таком виде он попадает в событие pull_request_created
+	reviews := make([]domain.ReviewerState, 0, len(reviewers))
+	for _, reviewerID := range reviewers {
+		reviews = append(reviews, domain.ReviewerState{
+			ReviewerID: reviewerID,
+			Verdict:    domain.ReviewVerdictPending,
+			AssignedAt: &now,
+		})
+	}
+	pr.SetReviews(reviews)
+	pr.NeedMoreReviewers = &needMoreReviewers
 
 	if err := s.prRepo.CreatePullRequestWithReviewers(ctx, pr, reviewers, needMoreReviewers); err != nil {
-		logger.LogBusinessTransactionEnd(operation, time.Since(start), false, map[string]interface{}{
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
 			"pr_id": req.PullRequestID,
 			"error": err.Error(),
 		})
 		return nil, err
 	}
 
-	pr.AssignedReviewers = reviewers
-	pr.NeedMoreReviewers = &needMoreReviewers
-
-	helpers.UpdateReviewerLoadMetrics(ctx, s.prReviewersRepo, reviewers)
-
-	logger.LogBusinessTransactionEnd(operation, time.Since(start), true, map[string]interface{}{
+	logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), true, map[string]interface{}{
 		"pr_id": req.PullRequestID,
 	})
 	logger.LogCriticalEvent("pull_request_created", map[string]interface{}{
@@ -94,3 +128,33 @@ func (s *PullRequestServiceImpl) CreatePullRequest(
 
 	return pr, nil
 }
+
+// selectReviewers выбирает ревьюверов из команды автора и, если их не хватает
+// и команда это разрешает, добирает недостающих из других команд.
+func (s *PullRequestServiceImpl) selectReviewers(
+	ctx context.Context,
+	team *domain.Team,
+	authorID string,
+	count int,
+) ([]string, error) {
+	reviewers, err := s.reviewerSelector.SelectReviewers(ctx, team, team.Members, authorID, count)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(reviewers) >= count {
+		return reviewers, nil
+	}
+
+	excluded := make(map[string]struct{}, len(reviewers))
+	for _, reviewerID := range reviewers {
+		excluded[reviewerID] = struct{}{}
+	}
+
+	extra, err := reviewer_selector.SelectCrossTeamReviewers(ctx, s.reviewerSelector, s.userRepo, team, authorID, excluded, count-len(reviewers))
+	if err != nil {
+		return nil, err
+	}
+
+	return append(reviewers, extra...), nil
+}
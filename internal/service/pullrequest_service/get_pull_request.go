@@ -0,0 +1,16 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"context"
+)
+
+// GetPullRequest возвращает PR вместе с ревьюверами, их решениями и информацией об авторе
+func (s *PullRequestServiceImpl) GetPullRequest(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	pr, err := s.prRepo.GetPullRequestByID(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"context"
+)
+
+// GetPullRequestHistory возвращает все назначения ревьюверов PR, включая снятые
+func (s *PullRequestServiceImpl) GetPullRequestHistory(ctx context.Context, prID string) (*domain.PullRequestHistoryResponse, error) {
+	pr, err := s.prRepo.GetPullRequestByID(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.prReviewersRepo.GetReviewerHistory(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PullRequestHistoryResponse{
+		PullRequestID: pr.PullRequestID,
+		Status:        pr.Status,
+		History:       history,
+	}, nil
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/repository/mocks"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullRequestServiceImpl_GetPullRequestHistory(t *testing.T) {
+	assignedAt := time.Date(2025, 10, 1, 10, 0, 0, 0, time.UTC)
+	reassignedAt := assignedAt.Add(time.Hour)
+	mergedAt := assignedAt.Add(3 * time.Hour)
+
+	// Назначения слитого PR завершены слиянием ещё в БД, сервис отдаёт историю как есть
+	history := []domain.ReviewerAssignment{
+		{ReviewerID: "u2", AssignedAt: &assignedAt, UnassignedAt: &reassignedAt, Reason: domain.UnassignReasonManualReassign},
+		{ReviewerID: "u3", AssignedAt: &assignedAt, UnassignedAt: &mergedAt, Reason: domain.UnassignReasonMerged},
+		{ReviewerID: "u4", AssignedAt: &reassignedAt, UnassignedAt: &mergedAt, Reason: domain.UnassignReasonMerged},
+	}
+
+	tests := []struct {
+		name    string
+		pr      *domain.PullRequest
+		prErr   error
+		wantErr error
+	}{
+		{
+			name: "merged PR",
+			pr:   &domain.PullRequest{PullRequestID: "pr1", Status: domain.PRStatusMerged, MergedAt: &mergedAt},
+		},
+		{
+			name:    "PR not found",
+			prErr:   domain.ErrNotFound,
+			wantErr: domain.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prRepo := &mocks.MockPullRequestRepository{
+				GetPullRequestByIDFunc: func(ctx context.Context, prID string) (*domain.PullRequest, error) {
+					return tt.pr, tt.prErr
+				},
+			}
+			prReviewersRepo := &mocks.MockPrReviewersRepository{
+				GetReviewerHistoryFunc: func(ctx context.Context, prID string) ([]domain.ReviewerAssignment, error) {
+					return history, nil
+				},
+			}
+			svc := NewPullRequestService(prRepo, prReviewersRepo, nil, nil, nil)
+
+			res, err := svc.GetPullRequestHistory(context.Background(), "pr1")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.pr.Status, res.Status)
+			assert.Equal(t, history, res.History)
+		})
+	}
+}
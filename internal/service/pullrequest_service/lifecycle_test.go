@@ -0,0 +1,140 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/repository/mocks"
+	reviewer_selector "AVITOSAMPISHU/internal/service/reviewer_selector"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lifecycleFixture хранит состояние PR между вызовами моков репозитория
+type lifecycleFixture struct {
+	status    domain.PRStatus
+	reviewers []string
+	from      domain.PRStatus
+}
+
+func newLifecycleService(f *lifecycleFixture) *PullRequestServiceImpl {
+	prRepo := &mocks.MockPullRequestRepository{
+		GetPullRequestByIDFunc: func(ctx context.Context, prID string) (*domain.PullRequest, error) {
+			return &domain.PullRequest{
+				PullRequestID:     prID,
+				AuthorID:          "user1",
+				Status:            f.status,
+				AssignedReviewers: f.reviewers,
+			}, nil
+		},
+		OpenPullRequestFunc: func(ctx context.Context, prID string, from domain.PRStatus, reviewerIDs []string, needMoreReviewers bool) error {
+			f.from = from
+			f.status = domain.PRStatusOpen
+			f.reviewers = reviewerIDs
+			return nil
+		},
+		ClosePullRequestFunc: func(ctx context.Context, prID string) ([]string, error) {
+			released := f.reviewers
+			f.status = domain.PRStatusClosed
+			f.reviewers = nil
+			return released, nil
+		},
+	}
+	userRepo := &mocks.MockUserRepository{
+		GetUserByIDFunc: func(ctx context.Context, userID string) (*domain.User, error) {
+			return &domain.User{UserID: userID, TeamName: "team1", IsActive: true}, nil
+		},
+	}
+	teamRepo := &mocks.MockTeamRepository{
+		GetTeamByNameFunc: func(ctx context.Context, teamName string) (*domain.Team, error) {
+			return &domain.Team{
+				TeamName: teamName,
+				Members: []domain.TeamMember{
+					{UserID: "user1", IsActive: true},
+					{UserID: "user2", IsActive: true},
+					{UserID: "user3", IsActive: true},
+				},
+			}, nil
+		},
+	}
+
+	return NewPullRequestService(prRepo, &mocks.MockPrReviewersRepository{}, userRepo, teamRepo, reviewer_selector.NewRandomSelector())
+}
+
+func TestPullRequestServiceImpl_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("draft is created without reviewers", func(t *testing.T) {
+		f := &lifecycleFixture{}
+		svc := newLifecycleService(f)
+		svc.prRepo.(*mocks.MockPullRequestRepository).GetPullRequestByIDFunc = func(ctx context.Context, prID string) (*domain.PullRequest, error) {
+			return nil, domain.ErrNotFound
+		}
+
+		pr, err := svc.CreatePullRequest(ctx, &domain.CreatePullRequestReq{
+			PullRequestID:   "pr1",
+			PullRequestName: "Draft",
+			AuthorID:        "user1",
+			Draft:           true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, domain.PRStatusDraft, pr.Status)
+		assert.Empty(t, pr.AssignedReviewers)
+		assert.False(t, *pr.NeedMoreReviewers)
+	})
+
+	t.Run("mark ready assigns reviewers", func(t *testing.T) {
+		f := &lifecycleFixture{status: domain.PRStatusDraft}
+		pr, err := newLifecycleService(f).MarkReady(ctx, &domain.MarkReadyReq{PullRequestID: "pr1"})
+		require.NoError(t, err)
+		assert.Equal(t, domain.PRStatusOpen, pr.Status)
+		assert.Equal(t, domain.PRStatusDraft, f.from)
+		assert.ElementsMatch(t, []string{"user2", "user3"}, pr.AssignedReviewers)
+	})
+
+	t.Run("mark ready rejects closed PR", func(t *testing.T) {
+		f := &lifecycleFixture{status: domain.PRStatusClosed}
+		_, err := newLifecycleService(f).MarkReady(ctx, &domain.MarkReadyReq{PullRequestID: "pr1"})
+		assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+	})
+
+	t.Run("close releases reviewers and reopen assigns new ones", func(t *testing.T) {
+		f := &lifecycleFixture{status: domain.PRStatusOpen, reviewers: []string{"user2", "user3"}}
+		svc := newLifecycleService(f)
+
+		closed, err := svc.ClosePullRequest(ctx, &domain.ClosePullRequestReq{PullRequestID: "pr1"})
+		require.NoError(t, err)
+		assert.Equal(t, domain.PRStatusClosed, closed.Status)
+		assert.Empty(t, closed.AssignedReviewers)
+
+		reopened, err := svc.ReopenPullRequest(ctx, &domain.ReopenPullRequestReq{PullRequestID: "pr1"})
+		require.NoError(t, err)
+		assert.Equal(t, domain.PRStatusOpen, reopened.Status)
+		assert.Equal(t, domain.PRStatusClosed, f.from)
+		assert.Len(t, reopened.AssignedReviewers, 2)
+	})
+
+	t.Run("merged PR cannot be closed or reopened", func(t *testing.T) {
+		f := &lifecycleFixture{status: domain.PRStatusMerged}
+		svc := newLifecycleService(f)
+
+		_, err := svc.ClosePullRequest(ctx, &domain.ClosePullRequestReq{PullRequestID: "pr1"})
+		assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+
+		_, err = svc.ReopenPullRequest(ctx, &domain.ReopenPullRequestReq{PullRequestID: "pr1"})
+		assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+	})
+
+	t.Run("draft cannot be merged", func(t *testing.T) {
+		f := &lifecycleFixture{status: domain.PRStatusDraft}
+		_, err := newLifecycleService(f).MergePullRequest(ctx, &domain.MergePullRequestReq{PullRequestID: "pr1"})
+		assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+	})
+
+	t.Run("closed PR cannot be reassigned", func(t *testing.T) {
+		f := &lifecycleFixture{status: domain.PRStatusClosed}
+		_, _, err := newLifecycleService(f).ReassignReviewer(ctx, &domain.ReassignReviewerReq{PullRequestID: "pr1", OldUserID: "user2"})
+		assert.ErrorIs(t, err, domain.ErrPRNotOpen)
+	})
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"context"
+)
+
+// ListPullRequests возвращает страницу PR по фильтрам и курсор следующей страницы, если она есть
+func (s *PullRequestServiceImpl) ListPullRequests(
+	ctx context.Context,
+	req *domain.ListPullRequestsReq,
+) (*domain.ListPullRequestsResponse, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = domain.DefaultPageLimit
+	}
+
+	// Запрашиваем на одну запись больше, чтобы понять, есть ли следующая страница
+	prs, err := s.prRepo.ListPullRequests(ctx, req, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &domain.ListPullRequestsResponse{PullRequests: prs}
+	if len(prs) > limit {
+		res.PullRequests = prs[:limit]
+		last := res.PullRequests[limit-1]
+		res.NextCursor = domain.PageCursor{CreatedAt: *last.CreatedAt, ID: last.PullRequestID}.Encode()
+	}
+
+	return res, nil
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/repository/mocks"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullRequestServiceImpl_ListPullRequests(t *testing.T) {
+	base := time.Date(2025, 10, 24, 10, 0, 0, 0, time.UTC)
+
+	// listRepo отдаёт count PR, не больше запрошенного лимита
+	listRepo := func(count int, gotLimit *int) *mocks.MockPullRequestRepository {
+		return &mocks.MockPullRequestRepository{
+			ListPullRequestsFunc: func(ctx context.Context, filter *domain.ListPullRequestsReq, limit int) ([]domain.PullRequest, error) {
+				*gotLimit = limit
+				prs := make([]domain.PullRequest, 0, count)
+				for i := 0; i < count && i < limit; i++ {
+					createdAt := base.Add(-time.Duration(i) * time.Minute)
+					prs = append(prs, domain.PullRequest{PullRequestID: fmt.Sprintf("pr%d", i), CreatedAt: &createdAt})
+				}
+				return prs, nil
+			},
+		}
+	}
+
+	t.Run("has next page", func(t *testing.T) {
+		var gotLimit int
+		svc := NewPullRequestService(listRepo(5, &gotLimit), nil, nil, nil, nil)
+
+		res, err := svc.ListPullRequests(context.Background(), &domain.ListPullRequestsReq{Limit: 2})
+		require.NoError(t, err)
+		assert.Equal(t, 3, gotLimit)
+		require.Len(t, res.PullRequests, 2)
+		require.NotEmpty(t, res.NextCursor)
+
+		cursor, err := domain.DecodePageCursor(res.NextCursor)
+		require.NoError(t, err)
+		assert.Equal(t, "pr1", cursor.ID)
+		assert.True(t, base.Add(-time.Minute).Equal(cursor.CreatedAt))
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		var gotLimit int
+		svc := NewPullRequestService(listRepo(2, &gotLimit), nil, nil, nil, nil)
+
+		res, err := svc.ListPullRequests(context.Background(), &domain.ListPullRequestsReq{})
+		require.NoError(t, err)
+		assert.Equal(t, domain.DefaultPageLimit+1, gotLimit)
+		assert.Len(t, res.PullRequests, 2)
+		assert.Empty(t, res.NextCursor)
+	})
+}
@@ -0,0 +1,14 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"context"
+)
+
+// MarkReady переводит черновик в OPEN и назначает ревьюверов по настройкам команды автора
+func (s *PullRequestServiceImpl) MarkReady(
+	ctx context.Context,
+	req *domain.MarkReadyReq,
+) (*domain.PullRequest, error) {
+	return s.activatePullRequest(ctx, "MarkReady", req.PullRequestID, domain.PRStatusDraft)
+}
@@ -2,9 +2,10 @@ package service
 
 import (
 	"AVITOSAMPISHU/internal/domain"
-	"AVITOSAMPISHU/pkg/helpers"
+	"AVITOSAMPISHU/internal/infrastructure/tracing"
 	"AVITOSAMPISHU/pkg/logger"
 	"context"
+	"fmt"
 	"time"
 )
 
@@ -15,36 +16,97 @@ func (s *PullRequestServiceImpl) MergePullRequest(
 	start := time.Now()
 	operation := "MergePullRequest"
 
-	logger.LogBusinessTransactionStart(operation, map[string]interface{}{
+	ctx, span := tracing.Start(ctx, "PullRequestService."+operation)
+	defer span.End()
+	actor := domain.ActorFromContext(ctx)
+
+	logger.LogBusinessTransactionStart(ctx, operation, map[string]interface{}{
 		"pr_id": req.PullRequestID,
+		"force": req.Force,
+		"actor": actor.ID,
 	})
 
+	// Принудительное слияние доступно только администраторам
+	if req.Force && !actor.IsAdmin() {
+		logger.LogBusinessRule("force_merge_requires_admin", map[string]interface{}{
+			"pr_id": req.PullRequestID,
+			"actor": actor.ID,
+		})
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
+			"pr_id": req.PullRequestID,
+			"error": domain.ErrForbidden.Error(),
+		})
+		return nil, fmt.Errorf("%w: force merge is allowed only for admins", domain.ErrForbidden)
+	}
+
 	pr, err := s.prRepo.GetPullRequestByID(ctx, req.PullRequestID)
 	if err != nil {
-		logger.LogBusinessTransactionEnd(operation, time.Since(start), false, map[string]interface{}{
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
 			"pr_id": req.PullRequestID,
 			"error": err.Error(),
 		})
 		return nil, err
 	}
 
+	// Повторное слияние идемпотентно, а черновик и закрытый PR слить нельзя
+	if pr.Status != domain.PRStatusMerged {
+		if err := domain.ValidateStatusTransition(pr.Status, domain.PRStatusMerged); err != nil {
+			logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
+				"pr_id": req.PullRequestID,
+				"error": err.Error(),
+			})
+			return nil, err
+		}
+	}
+
 	if pr.Status == domain.PRStatusOpen {
-		if err := s.prRepo.MergePullRequest(ctx, req.PullRequestID); err != nil {
-			logger.LogBusinessTransactionEnd(operation, time.Since(start), false, map[string]interface{}{
+		requiredApprovals, err := s.requiredApprovals(ctx, pr)
+		if err != nil {
+			logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
+				"pr_id": req.PullRequestID,
+				"error": err.Error(),
+			})
+			return nil, err
+		}
+
+		// Политика одобрений проверяется внутри транзакции слияния по заблокированным решениям ревьюверов,
+		// поэтому CHANGES_REQUESTED, отправленный после чтения PR, не даст слить его.
+		// Нарушение политики администратор может обойти флагом force. Если PR уже слит параллельным запросом,
+		// проверка не вызывается и принудительного слияния не было
+		var policyErr error
+		var checked bool
+		var approved, changesRequested int
+		err = s.prRepo.MergePullRequest(ctx, pr, func(locked *domain.PullRequest) error {
+			checked = true
+			approved, changesRequested = locked.CountVerdicts()
+			policyErr = checkApprovals(locked, requiredApprovals)
+			if policyErr != nil && !req.Force {
+				return policyErr
+			}
+			return nil
+		})
+		if err != nil {
+			logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
 				"pr_id": req.PullRequestID,
 				"error": err.Error(),
 			})
 			return nil, err
 		}
 
-		now := time.Now()
-		pr.Status = domain.PRStatusMerged
-		pr.MergedAt = &now
+		if req.Force && checked {
+			logger.LogCriticalEvent("pull_request_force_merged", map[string]interface{}{
+				"pr_id":             req.PullRequestID,
+				"actor":             actor.ID,
+				"policy_bypassed":   policyErr != nil,
+				"approved":          approved,
+				"changes_requested": changesRequested,
+			})
+		}
 	}
 
 	reviewers, err := s.prReviewersRepo.GetAssignedReviewers(ctx, req.PullRequestID)
 	if err != nil {
-		logger.LogBusinessTransactionEnd(operation, time.Since(start), false, map[string]interface{}{
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
 			"pr_id": req.PullRequestID,
 			"error": err.Error(),
 		})
@@ -53,11 +115,7 @@ func (s *PullRequestServiceImpl) MergePullRequest(
 
 	pr.AssignedReviewers = reviewers
 
-	if pr.Status == domain.PRStatusMerged {
-		helpers.UpdateReviewerLoadMetrics(ctx, s.prReviewersRepo, reviewers)
-	}
-
-	logger.LogBusinessTransactionEnd(operation, time.Since(start), true, map[string]interface{}{
+	logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), true, map[string]interface{}{
 		"pr_id": req.PullRequestID,
 	})
 	logger.LogCriticalEvent("pull_request_merged", map[string]interface{}{
@@ -66,3 +124,44 @@ func (s *PullRequestServiceImpl) MergePullRequest(
 
 	return pr, nil
 }
+
+// requiredApprovals возвращает число одобрений, которое требует команда автора; 0 — политика отключена
+func (s *PullRequestServiceImpl) requiredApprovals(ctx context.Context, pr *domain.PullRequest) (int, error) {
+	author, err := s.userRepo.GetUserByID(ctx, pr.AuthorID)
+	if err != nil {
+		return 0, err
+	}
+
+	settings, err := s.teamRepo.GetTeamSettings(ctx, author.TeamName)
+	if err != nil {
+		return 0, err
+	}
+
+	return settings.RequiredApprovals, nil
+}
+
+// checkApprovals проверяет правило одобрений: нужное число APPROVED и ни одного CHANGES_REQUESTED.
+// Нарушение политики возвращается как ErrNotApproved.
+func checkApprovals(pr *domain.PullRequest, requiredApprovals int) error {
+	// Политика отключена
+	if requiredApprovals == 0 {
+		return nil
+	}
+
+	approved, changesRequested := pr.CountVerdicts()
+	logger.LogBusinessRule("merge_requires_approvals", map[string]interface{}{
+		"pr_id":              pr.PullRequestID,
+		"required_approvals": requiredApprovals,
+		"approved":           approved,
+		"changes_requested":  changesRequested,
+	})
+
+	if changesRequested > 0 {
+		return fmt.Errorf("%w: %d reviewer(s) requested changes", domain.ErrNotApproved, changesRequested)
+	}
+	if approved < requiredApprovals {
+		return fmt.Errorf("%w: %d of %d required approvals", domain.ErrNotApproved, approved, requiredApprovals)
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/repository/mocks"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logger.InitLogger()
+}
+
+func reviewsWith(verdicts ...domain.ReviewVerdict) []domain.ReviewerState {
+	reviews := make([]domain.ReviewerState, 0, len(verdicts))
+	for i, verdict := range verdicts {
+		reviews = append(reviews, domain.ReviewerState{
+			ReviewerID: fmt.Sprintf("reviewer%d", i+1),
+			Verdict:    verdict,
+		})
+	}
+	return reviews
+}
+
+func TestPullRequestServiceImpl_MergePullRequest(t *testing.T) {
+	admin := domain.Actor{ID: domain.AdminActorID, Role: domain.RoleAdmin}
+	member := domain.Actor{ID: "user1"}
+
+	tests := []struct {
+		name              string
+		status            domain.PRStatus
+		reviews           []domain.ReviewerState
+		lockedReviews     []domain.ReviewerState // Решения на момент слияния, если изменились после чтения PR
+		requiredApprovals int
+		force             bool
+		actor             domain.Actor
+		wantErr           error
+		wantMergeCalled   bool
+	}{
+		{
+			name:              "policy disabled",
+			status:            domain.PRStatusOpen,
+			reviews:           reviewsWith(domain.ReviewVerdictPending, domain.ReviewVerdictPending),
+			requiredApprovals: 0,
+			actor:             member,
+			wantMergeCalled:   true,
+		},
+		{
+			name:              "enough approvals",
+			status:            domain.PRStatusOpen,
+			reviews:           reviewsWith(domain.ReviewVerdictApproved, domain.ReviewVerdictApproved),
+			requiredApprovals: 2,
+			actor:             member,
+			wantMergeCalled:   true,
+		},
+		{
+			name:              "not enough approvals",
+			status:            domain.PRStatusOpen,
+			reviews:           reviewsWith(domain.ReviewVerdictApproved, domain.ReviewVerdictPending),
+			requiredApprovals: 2,
+			actor:             member,
+			wantErr:           domain.ErrNotApproved,
+		},
+		{
+			name:              "changes requested blocks merge",
+			status:            domain.PRStatusOpen,
+			reviews:           reviewsWith(domain.ReviewVerdictApproved, domain.ReviewVerdictApproved, domain.ReviewVerdictChangesRequested),
+			requiredApprovals: 2,
+			actor:             member,
+			wantErr:           domain.ErrNotApproved,
+		},
+		{
+			name:              "changes requested after read blocks merge",
+			status:            domain.PRStatusOpen,
+			reviews:           reviewsWith(domain.ReviewVerdictApproved, domain.ReviewVerdictApproved),
+			lockedReviews:     reviewsWith(domain.ReviewVerdictApproved, domain.ReviewVerdictChangesRequested),
+			requiredApprovals: 2,
+			actor:             member,
+			wantErr:           domain.ErrNotApproved,
+		},
+		{
+			name:              "force by member is forbidden",
+			status:            domain.PRStatusOpen,
+			reviews:           reviewsWith(domain.ReviewVerdictPending),
+			requiredApprovals: 1,
+			force:             true,
+			actor:             member,
+			wantErr:           domain.ErrForbidden,
+		},
+		{
+			name:              "force by admin bypasses policy",
+			status:            domain.PRStatusOpen,
+			reviews:           reviewsWith(domain.ReviewVerdictChangesRequested),
+			requiredApprovals: 1,
+			force:             true,
+			actor:             admin,
+			wantMergeCalled:   true,
+		},
+		{
+			name:              "already merged is idempotent",
+			status:            domain.PRStatusMerged,
+			reviews:           reviewsWith(domain.ReviewVerdictPending),
+			requiredApprovals: 2,
+			actor:             member,
+			wantMergeCalled:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mergeCalled := false
+
+			prRepo := &mocks.MockPullRequestRepository{
+				GetPullRequestByIDFunc: func(ctx context.Context, prID string) (*domain.PullRequest, error) {
+					pr := &domain.PullRequest{PullRequestID: prID, AuthorID: "author", Status: tt.status}
+					pr.SetReviews(tt.reviews)
+					return pr, nil
+				},
+				MergePullRequestFunc: func(ctx context.Context, pr *domain.PullRequest, check func(*domain.PullRequest) error) error {
+					if tt.lockedReviews != nil {
+						pr.SetReviews(tt.lockedReviews)
+					}
+					if err := check(pr); err != nil {
+						return err
+					}
+					mergeCalled = true
+					pr.Status = domain.PRStatusMerged
+					return nil
+				},
+			}
+			userRepo := &mocks.MockUserRepository{
+				GetUserByIDFunc: func(ctx context.Context, userID string) (*domain.User, error) {
+					return &domain.User{UserID: userID, TeamName: "team1", IsActive: true}, nil
+				},
+			}
+			teamRepo := &mocks.MockTeamRepository{
+				GetTeamSettingsFunc: func(ctx context.Context, teamName string) (*domain.TeamSettings, error) {
+					settings := domain.DefaultTeamSettings()
+					settings.RequiredApprovals = tt.requiredApprovals
+					return &settings, nil
+				},
+			}
+
+			svc := NewPullRequestService(prRepo, &mocks.MockPrReviewersRepository{}, userRepo, teamRepo, nil)
+			ctx := domain.ContextWithActor(context.Background(), tt.actor)
+
+			pr, err := svc.MergePullRequest(ctx, &domain.MergePullRequestReq{PullRequestID: "pr1", Force: tt.force})
+
+			assert.Equal(t, tt.wantMergeCalled, mergeCalled)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, pr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, domain.PRStatusMerged, pr.Status)
+		})
+	}
+}
@@ -2,13 +2,15 @@ package service
 
 import (
 	"AVITOSAMPISHU/internal/repository"
+	"AVITOSAMPISHU/internal/service"
 )
 
 type PullRequestServiceImpl struct {
-	prRepo          repository.PullRequestRepositoryInterface
-	prReviewersRepo repository.PrReviewersRepositoryInterface
-	userRepo        repository.UserRepositoryInterface
-	teamRepo        repository.TeamRepositoryInterface
+	prRepo           repository.PullRequestRepositoryInterface
+	prReviewersRepo  repository.PrReviewersRepositoryInterface
+	userRepo         repository.UserRepositoryInterface
+	teamRepo         repository.TeamRepositoryInterface
+	reviewerSelector service.ReviewerSelector
 }
 
 func NewPullRequestService(
@@ -16,11 +18,13 @@ func NewPullRequestService(
 	prReviewersRepo repository.PrReviewersRepositoryInterface,
 	userRepo repository.UserRepositoryInterface,
 	teamRepo repository.TeamRepositoryInterface,
+	reviewerSelector service.ReviewerSelector,
 ) *PullRequestServiceImpl {
 	return &PullRequestServiceImpl{
-		prRepo:          prRepo,
-		prReviewersRepo: prReviewersRepo,
-		userRepo:        userRepo,
-		teamRepo:        teamRepo,
+		prRepo:           prRepo,
+		prReviewersRepo:  prReviewersRepo,
+		userRepo:         userRepo,
+		teamRepo:         teamRepo,
+		reviewerSelector: reviewerSelector,
 	}
 }
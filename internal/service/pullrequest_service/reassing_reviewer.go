@@ -2,6 +2,8 @@ package service
 
 import (
 	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/infrastructure/tracing"
+	reviewer_selector "AVITOSAMPISHU/internal/service/reviewer_selector"
 	"AVITOSAMPISHU/pkg/helpers"
 	"AVITOSAMPISHU/pkg/logger"
 	"context"
@@ -17,14 +19,17 @@ func (s *PullRequestServiceImpl) ReassignReviewer(
 	start := time.Now()
 	operation := "ReassignReviewer"
 
-	logger.LogBusinessTransactionStart(operation, map[string]interface{}{
+	ctx, span := tracing.Start(ctx, "PullRequestService."+operation)
+	defer span.End()
+
+	logger.LogBusinessTransactionStart(ctx, operation, map[string]interface{}{
 		"pr_id":        req.PullRequestID,
 		"old_reviewer": req.OldUserID,
 	})
 
 	pr, err := s.prRepo.GetPullRequestByID(ctx, req.PullRequestID)
 	if err != nil {
-		logger.LogBusinessTransactionEnd(operation, time.Since(start), false, map[string]interface{}{
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
 			"pr_id": req.PullRequestID,
 			"error": err.Error(),
 		})
@@ -32,16 +37,25 @@ func (s *PullRequestServiceImpl) ReassignReviewer(
 	}
 
 	if pr.Status == domain.PRStatusMerged {
-		logger.LogBusinessTransactionEnd(operation, time.Since(start), false, map[string]interface{}{
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
 			"pr_id": req.PullRequestID,
 			"error": "PR already merged",
 		})
 		return nil, "", domain.ErrPRMerged
 	}
 
+	if pr.Status != domain.PRStatusOpen {
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
+			"pr_id":  req.PullRequestID,
+			"error":  "PR is not open",
+			"status": string(pr.Status),
+		})
+		return nil, "", domain.ErrPRNotOpen
+	}
+
 	currentReviewers, err := s.prReviewersRepo.GetAssignedReviewers(ctx, req.PullRequestID)
 	if err != nil {
-		logger.LogBusinessTransactionEnd(operation, time.Since(start), false, map[string]interface{}{
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
 			"pr_id": req.PullRequestID,
 			"error": err.Error(),
 		})
@@ -49,7 +63,7 @@ func (s *PullRequestServiceImpl) ReassignReviewer(
 	}
 
 	if !helpers.ContainsReviewer(currentReviewers, req.OldUserID) {
-		logger.LogBusinessTransactionEnd(operation, time.Since(start), false, map[string]interface{}{
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
 			"pr_id": req.PullRequestID,
 			"error": "reviewer not assigned",
 		})
@@ -91,19 +105,29 @@ func (s *PullRequestServiceImpl) ReassignReviewer(
 		"author_id":        pr.AuthorID,
 	})
 
-	candidates := helpers.RandSelectReviewers(onlyActiveCandidates, pr.AuthorID, 1)
+	candidates, err := s.reviewerSelector.SelectReviewers(ctx, team, onlyActiveCandidates, pr.AuthorID, 1)
+	if err == nil && len(candidates) == 0 {
+		candidates, err = reviewer_selector.SelectCrossTeamReviewers(ctx, s.reviewerSelector, s.userRepo, team, pr.AuthorID, assignedSet, 1)
+	}
+	if err != nil {
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
+			"pr_id": req.PullRequestID,
+			"error": err.Error(),
+		})
+		return nil, "", err
+	}
 	if len(candidates) == 0 {
 		logger.LogBusinessRule("no_replacement_candidate", map[string]interface{}{
 			"pr_id": req.PullRequestID,
 		})
 		if err := s.prRepo.SetNeedMoreReviewers(ctx, req.PullRequestID, true); err != nil {
-			logger.LogBusinessTransactionEnd(operation, time.Since(start), false, map[string]interface{}{
+			logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
 				"pr_id": req.PullRequestID,
 				"error": err.Error(),
 			})
 			return nil, "", err
 		}
-		logger.LogBusinessTransactionEnd(operation, time.Since(start), false, map[string]interface{}{
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
 			"pr_id":  req.PullRequestID,
 			"error":  "no replacement candidate",
 			"reason": "no_candidate",
@@ -119,32 +143,25 @@ func (s *PullRequestServiceImpl) ReassignReviewer(
 	)
 
 	if err := s.prReviewersRepo.ReassignReviewer(ctx, req.PullRequestID, req.OldUserID, newReviewerID); err != nil {
-		logger.LogBusinessTransactionEnd(operation, time.Since(start), false, map[string]interface{}{
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
 			"pr_id": req.PullRequestID,
 			"error": err.Error(),
 		})
 		return nil, "", err
 	}
 
-	updatedReviewers, err := s.prReviewersRepo.GetAssignedReviewers(ctx, req.PullRequestID)
+	updatedReviews, err := s.prReviewersRepo.GetReviews(ctx, req.PullRequestID)
 	if err != nil {
-		logger.LogBusinessTransactionEnd(operation, time.Since(start), false, map[string]interface{}{
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
 			"pr_id": req.PullRequestID,
 			"error": err.Error(),
 		})
 		return nil, "", err
 	}
 
-	pr.AssignedReviewers = updatedReviewers
-
-	affectedReviewers := make([]string, 0, 2)
-	affectedReviewers = append(affectedReviewers, req.OldUserID)
-	if newReviewerID != "" {
-		affectedReviewers = append(affectedReviewers, newReviewerID)
-	}
-	helpers.UpdateReviewerLoadMetrics(ctx, s.prReviewersRepo, affectedReviewers)
+	pr.SetReviews(updatedReviews)
 
-	logger.LogBusinessTransactionEnd(operation, time.Since(start), true, map[string]interface{}{
+	logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), true, map[string]interface{}{
 		"pr_id": req.PullRequestID,
 	})
 	logger.LogCriticalEvent("reviewer_reassigned", map[string]interface{}{
@@ -0,0 +1,14 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"context"
+)
+
+// ReopenPullRequest возвращает закрытый PR в OPEN с заново выбранными ревьюверами
+func (s *PullRequestServiceImpl) ReopenPullRequest(
+	ctx context.Context,
+	req *domain.ReopenPullRequestReq,
+) (*domain.PullRequest, error) {
+	return s.activatePullRequest(ctx, "ReopenPullRequest", req.PullRequestID, domain.PRStatusClosed)
+}
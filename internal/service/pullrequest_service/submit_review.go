@@ -0,0 +1,57 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/infrastructure/tracing"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"time"
+)
+
+// SubmitReview сохраняет решение назначенного ревьювера и возвращает PR с актуальными решениями
+func (s *PullRequestServiceImpl) SubmitReview(
+	ctx context.Context,
+	req *domain.SubmitReviewReq,
+) (*domain.PullRequest, error) {
+	start := time.Now()
+	operation := "SubmitReview"
+
+	ctx, span := tracing.Start(ctx, "PullRequestService."+operation)
+	defer span.End()
+
+	logger.LogBusinessTransactionStart(ctx, operation, map[string]interface{}{
+		"pr_id":    req.PullRequestID,
+		"reviewer": req.ReviewerID,
+		"verdict":  string(req.Verdict),
+	})
+
+	if err := s.prReviewersRepo.SubmitReview(ctx, req.PullRequestID, req.ReviewerID, req.Verdict, req.Comment); err != nil {
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
+			"pr_id":    req.PullRequestID,
+			"reviewer": req.ReviewerID,
+			"error":    err.Error(),
+		})
+		return nil, err
+	}
+
+	pr, err := s.prRepo.GetPullRequestByID(ctx, req.PullRequestID)
+	if err != nil {
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
+			"pr_id": req.PullRequestID,
+			"error": err.Error(),
+		})
+		return nil, err
+	}
+
+	logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), true, map[string]interface{}{
+		"pr_id":    req.PullRequestID,
+		"reviewer": req.ReviewerID,
+	})
+	logger.LogCriticalEvent("review_submitted", map[string]interface{}{
+		"pr_id":    req.PullRequestID,
+		"reviewer": req.ReviewerID,
+		"verdict":  string(req.Verdict),
+	})
+
+	return pr, nil
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/repository"
+	"AVITOSAMPISHU/internal/service"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+)
+
+// SelectCrossTeamReviewers добирает ревьюверов из активных участников других команд.
+// Вызывается, когда в команде не хватает кандидатов и настройки команды разрешают fallback.
+// Пользователи из excluded (уже назначенные) не выбираются.
+func SelectCrossTeamReviewers(
+	ctx context.Context,
+	selector service.ReviewerSelector,
+	userRepo repository.UserRepositoryInterface,
+	team *domain.Team,
+	authorID string,
+	excluded map[string]struct{},
+	count int,
+) ([]string, error) {
+	if count <= 0 || !team.EffectiveSettings().AllowCrossTeamFallback {
+		return make([]string, 0), nil
+	}
+
+	outsiders, err := userRepo.GetActiveUsersOutsideTeam(ctx, team.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]domain.TeamMember, 0, len(outsiders))
+	for _, member := range outsiders {
+		if _, skip := excluded[member.UserID]; skip {
+			continue
+		}
+		candidates = append(candidates, member)
+	}
+
+	logger.LogBusinessRule("cross_team_fallback", map[string]interface{}{
+		"team_name":        team.TeamName,
+		"candidates_count": len(candidates),
+		"count":            count,
+	})
+
+	return selector.SelectReviewers(ctx, team, candidates, authorID, count)
+}
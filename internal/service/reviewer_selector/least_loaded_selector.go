@@ -0,0 +1,57 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/repository"
+	"context"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// LeastLoadedSelector выбирает ревьюверов с наименьшим количеством открытых ревью.
+// При равной нагрузке кандидаты выбираются случайно.
+type LeastLoadedSelector struct {
+	prReviewersRepo repository.PrReviewersRepositoryInterface
+}
+
+func NewLeastLoadedSelector(prReviewersRepo repository.PrReviewersRepositoryInterface) *LeastLoadedSelector {
+	return &LeastLoadedSelector{prReviewersRepo: prReviewersRepo}
+}
+
+func (s *LeastLoadedSelector) SelectReviewers(
+	ctx context.Context,
+	_ *domain.Team,
+	members []domain.TeamMember,
+	authorID string,
+	count int,
+) ([]string, error) {
+	if count <= 0 {
+		return make([]string, 0), nil
+	}
+
+	candidates := eligibleCandidates(members, authorID)
+	if len(candidates) == 0 {
+		return candidates, nil
+	}
+
+	load, err := s.prReviewersRepo.GetOpenReviewsCount(ctx, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	// Перемешиваем до стабильной сортировки, чтобы при равной нагрузке не выбирать всегда одних и тех же
+	rng := rand.New(rand.NewSource(time.Now().UnixNano())) //nolint:gosec
+	rng.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return load[candidates[i]] < load[candidates[j]]
+	})
+
+	if len(candidates) > count {
+		candidates = candidates[:count]
+	}
+
+	return candidates, nil
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/helpers"
+	"context"
+)
+
+// RandomSelector выбирает ревьюверов равновероятно
+type RandomSelector struct{}
+
+func NewRandomSelector() *RandomSelector {
+	return &RandomSelector{}
+}
+
+func (s *RandomSelector) SelectReviewers(
+	_ context.Context,
+	_ *domain.Team,
+	members []domain.TeamMember,
+	authorID string,
+	count int,
+) ([]string, error) {
+	return helpers.RandSelectReviewers(members, authorID, count), nil
+}
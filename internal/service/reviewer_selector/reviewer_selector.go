@@ -0,0 +1,61 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/repository"
+	"AVITOSAMPISHU/internal/service"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+)
+
+// TeamStrategySelector выбирает реализацию ReviewerSelector по стратегии, заданной в настройках команды
+type TeamStrategySelector struct {
+	selectors map[domain.SelectionStrategy]service.ReviewerSelector
+	fallback  service.ReviewerSelector
+}
+
+func NewTeamStrategySelector(prReviewersRepo repository.PrReviewersRepositoryInterface) *TeamStrategySelector {
+	random := NewRandomSelector()
+
+	return &TeamStrategySelector{
+		selectors: map[domain.SelectionStrategy]service.ReviewerSelector{
+			domain.SelectionStrategyRandom:      random,
+			domain.SelectionStrategyLeastLoaded: NewLeastLoadedSelector(prReviewersRepo),
+			domain.SelectionStrategyRoundRobin:  NewRoundRobinSelector(),
+		},
+		fallback: random,
+	}
+}
+
+func (s *TeamStrategySelector) SelectReviewers(
+	ctx context.Context,
+	team *domain.Team,
+	members []domain.TeamMember,
+	authorID string,
+	count int,
+) ([]string, error) {
+	strategy := team.Strategy()
+	selector, ok := s.selectors[strategy]
+	if !ok {
+		selector = s.fallback
+	}
+
+	logger.LogBusinessRule("select_reviewers_strategy", map[string]interface{}{
+		"team_name": team.TeamName,
+		"strategy":  string(strategy),
+		"count":     count,
+	})
+
+	return selector.SelectReviewers(ctx, team, members, authorID, count)
+}
+
+// eligibleCandidates возвращает user_id активных участников, исключая автора
+func eligibleCandidates(members []domain.TeamMember, authorID string) []string {
+	candidates := make([]string, 0, len(members))
+	for _, member := range members {
+		if member.IsActive && member.UserID != authorID {
+			candidates = append(candidates, member.UserID)
+		}
+	}
+	return candidates
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/repository/mocks"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logger.InitLogger()
+}
+
+func testMembers() []domain.TeamMember {
+	return []domain.TeamMember{
+		{UserID: "user1", IsActive: true},
+		{UserID: "user2", IsActive: true},
+		{UserID: "user3", IsActive: true},
+		{UserID: "user4", IsActive: false},
+		{UserID: "user5", IsActive: true},
+	}
+}
+
+func TestRoundRobinSelector_SelectReviewers(t *testing.T) {
+	selector := NewRoundRobinSelector()
+	team := &domain.Team{TeamName: "team1"}
+	ctx := context.Background()
+
+	first, err := selector.SelectReviewers(ctx, team, testMembers(), "user1", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user2", "user3"}, first)
+
+	second, err := selector.SelectReviewers(ctx, team, testMembers(), "user1", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user5", "user2"}, second)
+
+	// Позиция хранится отдельно для каждой команды
+	other, err := selector.SelectReviewers(ctx, &domain.Team{TeamName: "team2"}, testMembers(), "user1", 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user2"}, other)
+
+	empty, err := selector.SelectReviewers(ctx, team, testMembers(), "user1", 0)
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
+func TestLeastLoadedSelector_SelectReviewers(t *testing.T) {
+	tests := []struct {
+		name    string
+		load    map[string]int
+		loadErr error
+		count   int
+		want    []string
+		wantErr error
+	}{
+		{
+			name:  "selects reviewers with fewest open reviews",
+			load:  map[string]int{"user2": 5, "user3": 1},
+			count: 2,
+			want:  []string{"user5", "user3"},
+		},
+		{
+			name:  "count greater than candidates",
+			load:  map[string]int{"user2": 1, "user3": 2, "user5": 3},
+			count: 5,
+			want:  []string{"user2", "user3", "user5"},
+		},
+		{
+			name:    "repository error",
+			loadErr: errors.New("db error"),
+			count:   1,
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.MockPrReviewersRepository{
+				GetOpenReviewsCountFunc: func(_ context.Context, userIDs []string) (map[string]int, error) {
+					assert.NotContains(t, userIDs, "user1", "author must not be a candidate")
+					assert.NotContains(t, userIDs, "user4", "inactive member must not be a candidate")
+					return tt.load, tt.loadErr
+				},
+			}
+
+			got, err := NewLeastLoadedSelector(repo).SelectReviewers(context.Background(), &domain.Team{TeamName: "team1"}, testMembers(), "user1", tt.count)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTeamStrategySelector_SelectReviewers(t *testing.T) {
+	repo := &mocks.MockPrReviewersRepository{
+		GetOpenReviewsCountFunc: func(_ context.Context, _ []string) (map[string]int, error) {
+			return map[string]int{"user2": 3, "user3": 3}, nil
+		},
+	}
+	selector := NewTeamStrategySelector(repo)
+	ctx := context.Background()
+
+	leastLoaded, err := selector.SelectReviewers(ctx, &domain.Team{
+		TeamName: "team1",
+		Settings: &domain.TeamSettings{SelectionStrategy: domain.SelectionStrategyLeastLoaded},
+	}, testMembers(), "user1", 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user5"}, leastLoaded)
+
+	roundRobin, err := selector.SelectReviewers(ctx, &domain.Team{
+		TeamName: "team1",
+		Settings: &domain.TeamSettings{SelectionStrategy: domain.SelectionStrategyRoundRobin},
+	}, testMembers(), "user1", 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user2"}, roundRobin)
+
+	// Неизвестная стратегия не должна ломать назначение — используется random
+	fallback, err := selector.SelectReviewers(ctx, &domain.Team{
+		TeamName: "team1",
+		Settings: &domain.TeamSettings{SelectionStrategy: "unknown"},
+	}, testMembers(), "user1", 2)
+	require.NoError(t, err)
+	assert.Len(t, fallback, 2)
+	assert.NotContains(t, fallback, "user1")
+	assert.NotContains(t, fallback, "user4")
+}
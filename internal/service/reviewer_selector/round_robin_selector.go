@@ -0,0 +1,63 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"context"
+	"sort"
+	"sync"
+)
+
+// RoundRobinSelector выбирает ревьюверов по кругу в порядке user_id.
+// Позиция хранится в памяти отдельно для каждой команды и сбрасывается при рестарте.
+type RoundRobinSelector struct {
+	mu           sync.Mutex
+	lastSelected map[string]string
+}
+
+func NewRoundRobinSelector() *RoundRobinSelector {
+	return &RoundRobinSelector{
+		lastSelected: make(map[string]string),
+	}
+}
+
+func (s *RoundRobinSelector) SelectReviewers(
+	_ context.Context,
+	team *domain.Team,
+	members []domain.TeamMember,
+	authorID string,
+	count int,
+) ([]string, error) {
+	if count <= 0 {
+		return make([]string, 0), nil
+	}
+
+	candidates := eligibleCandidates(members, authorID)
+	if len(candidates) == 0 {
+		return candidates, nil
+	}
+	sort.Strings(candidates)
+
+	if count > len(candidates) {
+		count = len(candidates)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Начинаем с первого кандидата, идущего после последнего выбранного.
+	// Поиск по значению, а не по индексу, устойчив к изменению состава команды.
+	last := s.lastSelected[team.TeamName]
+	start := sort.SearchStrings(candidates, last)
+	if start < len(candidates) && candidates[start] == last {
+		start++
+	}
+
+	selected := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		selected = append(selected, candidates[(start+i)%len(candidates)])
+	}
+
+	s.lastSelected[team.TeamName] = selected[len(selected)-1]
+
+	return selected, nil
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"time"
+)
+
+// ExportReviews передаёт handle все назначения ревьюверов по фильтрам, не загружая их в память целиком
+func (s *StatsServiceImpl) ExportReviews(
+	ctx context.Context,
+	req *domain.ExportReviewsReq,
+	handle func(row *domain.ReviewExportRow) error,
+) error {
+	start := time.Now()
+	rows := 0
+
+	err := s.statsRepo.ExportReviews(ctx, req, func(row *domain.ReviewExportRow) error {
+		rows++
+		return handle(row)
+	})
+
+	logger.WithContext(ctx).Infow("reviews export finished",
+		"team_name", req.TeamName,
+		"rows", rows,
+		"duration", time.Since(start),
+		"success", err == nil,
+	)
+	return err
+}
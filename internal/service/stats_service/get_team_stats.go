@@ -0,0 +1,15 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"context"
+)
+
+func (s *StatsServiceImpl) GetTeamStats(ctx context.Context, req *domain.GetTeamStatsReq) (*domain.TeamStats, error) {
+	from, to, err := s.window(req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.statsRepo.GetTeamStats(ctx, req.TeamName, from, to)
+}
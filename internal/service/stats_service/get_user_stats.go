@@ -0,0 +1,15 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"context"
+)
+
+func (s *StatsServiceImpl) GetUserStats(ctx context.Context, req *domain.GetUserStatsReq) (*domain.UserStats, error) {
+	from, to, err := s.window(req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.statsRepo.GetUserStats(ctx, req.UserID, from, to)
+}
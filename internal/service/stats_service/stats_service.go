@@ -0,0 +1,38 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/repository"
+	"fmt"
+	"time"
+)
+
+// StatsServiceImpl аналитика ревью: пропускная способность, время до первого ревью и до слияния, переназначения
+type StatsServiceImpl struct {
+	statsRepo repository.StatsRepositoryInterface
+	now       func() time.Time
+}
+
+func NewStatsService(statsRepo repository.StatsRepositoryInterface) *StatsServiceImpl {
+	return &StatsServiceImpl{
+		statsRepo: statsRepo,
+		now:       time.Now,
+	}
+}
+
+// window заполняет пустые границы периода: to — текущим временем, from — за DefaultStatsWindow до to
+func (s *StatsServiceImpl) window(from, to *time.Time) (time.Time, time.Time, error) {
+	end := s.now().UTC()
+	if to != nil {
+		end = to.UTC()
+	}
+	start := end.Add(-domain.DefaultStatsWindow)
+	if from != nil {
+		start = from.UTC()
+	}
+
+	if !start.Before(end) {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: from must be before to", domain.ErrInvalidRequest)
+	}
+	return start, end, nil
+}
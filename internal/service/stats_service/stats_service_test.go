@@ -0,0 +1,84 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/repository/mocks"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsService_Window(t *testing.T) {
+	now := time.Date(2025, 3, 31, 12, 0, 0, 0, time.UTC)
+	ptr := func(t time.Time) *time.Time { return &t }
+
+	tests := []struct {
+		name     string
+		from     *time.Time
+		to       *time.Time
+		wantFrom time.Time
+		wantTo   time.Time
+		wantErr  bool
+	}{
+		{
+			name:     "defaults to the last window",
+			wantFrom: now.Add(-domain.DefaultStatsWindow),
+			wantTo:   now,
+		},
+		{
+			name:     "window ends at to",
+			to:       ptr(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)),
+			wantFrom: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+			wantTo:   time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "explicit bounds are normalized to UTC",
+			from:     ptr(time.Date(2025, 1, 1, 3, 0, 0, 0, time.FixedZone("MSK", 3*60*60))),
+			to:       ptr(time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)),
+			wantFrom: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantTo:   time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "from after default to",
+			from:    ptr(now.Add(time.Hour)),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotFrom, gotTo time.Time
+			svc := NewStatsService(&mocks.MockStatsRepository{
+				GetTeamStatsFunc: func(_ context.Context, teamName string, from, to time.Time) (*domain.TeamStats, error) {
+					gotFrom, gotTo = from, to
+					return &domain.TeamStats{TeamName: teamName, From: from, To: to}, nil
+				},
+			})
+			svc.now = func() time.Time { return now }
+
+			_, err := svc.GetTeamStats(context.Background(), &domain.GetTeamStatsReq{TeamName: "backend", From: tt.from, To: tt.to})
+			if tt.wantErr {
+				assert.ErrorIs(t, err, domain.ErrInvalidRequest)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFrom, gotFrom)
+			assert.Equal(t, tt.wantTo, gotTo)
+		})
+	}
+}
+
+func TestStatsService_GetUserStats_NotFound(t *testing.T) {
+	svc := NewStatsService(&mocks.MockStatsRepository{
+		GetUserStatsFunc: func(context.Context, string, time.Time, time.Time) (*domain.UserStats, error) {
+			return nil, domain.ErrNotFound
+		},
+	})
+
+	_, err := svc.GetUserStats(context.Background(), &domain.GetUserStatsReq{UserID: "ghost"})
+	assert.True(t, errors.Is(err, domain.ErrNotFound))
+}
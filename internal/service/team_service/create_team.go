@@ -2,6 +2,7 @@ package service
 
 import (
 	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/infrastructure/tracing"
 	"AVITOSAMPISHU/pkg/logger"
 	"context"
 	"time"
@@ -11,13 +12,25 @@ func (s *TeamServiceImpl) CreateTeam(ctx context.Context, team *domain.Team) (*d
 	start := time.Now()
 	operation := "CreateTeam"
 
-	logger.LogBusinessTransactionStart(operation, map[string]interface{}{
+	ctx, span := tracing.Start(ctx, "TeamService."+operation)
+	defer span.End()
+
+	logger.LogBusinessTransactionStart(ctx, operation, map[string]interface{}{
 		"team_name":     team.TeamName,
 		"members_count": len(team.Members),
 	})
 
-	if _, err := s.teamRepo.CreateTeamWithMembers(ctx, team.TeamName, team.Members); err != nil {
-		logger.LogBusinessTransactionEnd(operation, time.Since(start), false, map[string]interface{}{
+	settings := resolveCreateSettings(team.Settings)
+	if err := checkSettingsConsistency(&settings); err != nil {
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
+			"team_name": team.TeamName,
+			"error":     err.Error(),
+		})
+		return nil, err
+	}
+
+	if _, err := s.teamRepo.CreateTeamWithMembers(ctx, team.TeamName, team.Members, settings); err != nil {
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
 			"team_name": team.TeamName,
 			"error":     err.Error(),
 		})
@@ -26,14 +39,14 @@ func (s *TeamServiceImpl) CreateTeam(ctx context.Context, team *domain.Team) (*d
 
 	createdTeam, err := s.teamRepo.GetTeamByName(ctx, team.TeamName)
 	if err != nil {
-		logger.LogBusinessTransactionEnd(operation, time.Since(start), false, map[string]interface{}{
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
 			"team_name": team.TeamName,
 			"error":     err.Error(),
 		})
 		return nil, err
 	}
 
-	logger.LogBusinessTransactionEnd(operation, time.Since(start), true, map[string]interface{}{
+	logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), true, map[string]interface{}{
 		"team_name": createdTeam.TeamName,
 	})
 	logger.LogCriticalEvent("team_created", map[string]interface{}{
@@ -42,3 +55,25 @@ func (s *TeamServiceImpl) CreateTeam(ctx context.Context, team *domain.Team) (*d
 
 	return createdTeam, nil
 }
+
+// resolveCreateSettings подставляет значения по умолчанию вместо незаполненных полей настроек
+func resolveCreateSettings(requested *domain.TeamSettings) domain.TeamSettings {
+	settings := domain.DefaultTeamSettings()
+	if requested == nil {
+		return settings
+	}
+
+	if requested.RequiredReviewers > 0 {
+		settings.RequiredReviewers = requested.RequiredReviewers
+	}
+	if requested.MinReviewers > 0 {
+		settings.MinReviewers = requested.MinReviewers
+	}
+	if requested.SelectionStrategy != "" {
+		settings.SelectionStrategy = requested.SelectionStrategy
+	}
+	settings.AllowCrossTeamFallback = requested.AllowCrossTeamFallback
+	settings.RequiredApprovals = requested.RequiredApprovals
+
+	return settings
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/infrastructure/tracing"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"fmt"
+	"time"
+)
+
+// GetTeamSettings возвращает настройки команды
+func (s *TeamServiceImpl) GetTeamSettings(ctx context.Context, teamName string) (*domain.TeamSettings, error) {
+	settings, err := s.teamRepo.GetTeamSettings(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// UpdateTeamSettings частично обновляет настройки команды: незаданные в запросе поля сохраняют текущие значения
+func (s *TeamServiceImpl) UpdateTeamSettings(ctx context.Context, req *domain.UpdateTeamSettingsReq) (*domain.TeamSettings, error) {
+	start := time.Now()
+	operation := "UpdateTeamSettings"
+
+	ctx, span := tracing.Start(ctx, "TeamService."+operation)
+	defer span.End()
+
+	logger.LogBusinessTransactionStart(ctx, operation, map[string]interface{}{
+		"team_name": req.TeamName,
+	})
+
+	settings, err := s.teamRepo.GetTeamSettings(ctx, req.TeamName)
+	if err != nil {
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
+			"team_name": req.TeamName,
+			"error":     err.Error(),
+		})
+		return nil, err
+	}
+
+	if req.RequiredReviewers != nil {
+		settings.RequiredReviewers = *req.RequiredReviewers
+	}
+	if req.MinReviewers != nil {
+		settings.MinReviewers = *req.MinReviewers
+	}
+	if req.SelectionStrategy != nil {
+		settings.SelectionStrategy = *req.SelectionStrategy
+	}
+	if req.AllowCrossTeamFallback != nil {
+		settings.AllowCrossTeamFallback = *req.AllowCrossTeamFallback
+	}
+	if req.RequiredApprovals != nil {
+		settings.RequiredApprovals = *req.RequiredApprovals
+	}
+
+	if err = checkSettingsConsistency(settings); err != nil {
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
+			"team_name": req.TeamName,
+			"error":     err.Error(),
+		})
+		return nil, err
+	}
+
+	if err = s.teamRepo.UpdateTeamSettings(ctx, req.TeamName, settings); err != nil {
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
+			"team_name": req.TeamName,
+			"error":     err.Error(),
+		})
+		return nil, err
+	}
+
+	logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), true, map[string]interface{}{
+		"team_name":          req.TeamName,
+		"required_reviewers": settings.RequiredReviewers,
+		"min_reviewers":      settings.MinReviewers,
+		"strategy":           string(settings.SelectionStrategy),
+		"required_approvals": settings.RequiredApprovals,
+	})
+	logger.LogCriticalEvent("team_settings_updated", map[string]interface{}{
+		"team_name": req.TeamName,
+	})
+
+	return settings, nil
+}
+
+// checkSettingsConsistency проверяет согласованность полей настроек между собой
+func checkSettingsConsistency(settings *domain.TeamSettings) error {
+	if settings.MinReviewers > settings.RequiredReviewers {
+		return fmt.Errorf("%w: min_reviewers (%d) cannot exceed required_reviewers (%d)",
+			domain.ErrInvalidRequest, settings.MinReviewers, settings.RequiredReviewers)
+	}
+	if settings.RequiredApprovals > settings.RequiredReviewers {
+		return fmt.Errorf("%w: required_approvals (%d) cannot exceed required_reviewers (%d)",
+			domain.ErrInvalidRequest, settings.RequiredApprovals, settings.RequiredReviewers)
+	}
+	return nil
+}
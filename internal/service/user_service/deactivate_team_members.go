@@ -2,7 +2,8 @@ package service
 
 import (
 	"AVITOSAMPISHU/internal/domain"
-	"AVITOSAMPISHU/pkg/helpers"
+	"AVITOSAMPISHU/internal/infrastructure/tracing"
+	reviewer_selector "AVITOSAMPISHU/internal/service/reviewer_selector"
 	"AVITOSAMPISHU/pkg/logger"
 	"context"
 	"fmt"
@@ -18,7 +19,10 @@ func (s *UserServiceImpl) DeactivateTeamMembers(
 	start := time.Now()
 	operation := "DeactivateTeamMembers"
 
-	logger.LogBusinessTransactionStart(operation, map[string]interface{}{
+	ctx, span := tracing.Start(ctx, "UserService."+operation)
+	defer span.End()
+
+	logger.LogBusinessTransactionStart(ctx, operation, map[string]interface{}{
 		"team_name":      req.TeamName,
 		"users_count":    len(req.UserIDs),
 		"deactivate_all": len(req.UserIDs) == 0,
@@ -27,7 +31,7 @@ func (s *UserServiceImpl) DeactivateTeamMembers(
 	team, err := s.teamRepo.GetTeamByName(ctx, req.TeamName)
 	// Ошибки репозитория уже доменные, поэтому просто пробрасываем их выше.
 	if err != nil {
-		logger.LogBusinessTransactionEnd(operation, time.Since(start), false, map[string]interface{}{
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
 			"team_name": req.TeamName,
 			"error":     err.Error(),
 		})
@@ -36,7 +40,7 @@ func (s *UserServiceImpl) DeactivateTeamMembers(
 
 	// Проверка: нельзя деактивировать всех участников команды без явного указания UserIDs
 	if len(req.UserIDs) == 0 {
-		logger.LogBusinessTransactionEnd(operation, time.Since(start), false, map[string]interface{}{
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
 			"team_name": req.TeamName,
 			"error":     "cannot deactivate all team members without explicit user IDs",
 			"reason":    "empty_user_ids",
@@ -46,7 +50,7 @@ func (s *UserServiceImpl) DeactivateTeamMembers(
 
 	// Проверка: нельзя деактивировать всех участников команды
 	if len(req.UserIDs) == len(team.Members) {
-		logger.LogBusinessTransactionEnd(operation, time.Since(start), false, map[string]interface{}{
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
 			"team_name": req.TeamName,
 			"error":     "cannot deactivate all team members, team would be left without reviewers",
 			"reason":    "deactivate_all_members",
@@ -61,7 +65,7 @@ func (s *UserServiceImpl) DeactivateTeamMembers(
 
 	for _, userID := range req.UserIDs {
 		if _, ok := memberIndex[userID]; !ok {
-			logger.LogBusinessTransactionEnd(operation, time.Since(start), false, map[string]interface{}{
+			logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
 				"team_name": req.TeamName,
 				"user_id":   userID,
 				"error":     "user is not a member of team",
@@ -73,7 +77,7 @@ func (s *UserServiceImpl) DeactivateTeamMembers(
 
 	prMap, err := s.getOpenPRsForUsers(ctx, req.UserIDs)
 	if err != nil {
-		logger.LogBusinessTransactionEnd(operation, time.Since(start), false, map[string]interface{}{
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
 			"team_name": req.TeamName,
 			"error":     err.Error(),
 		})
@@ -82,7 +86,7 @@ func (s *UserServiceImpl) DeactivateTeamMembers(
 
 	reassignments, err := s.buildReassignmentsPlan(ctx, prMap, req.UserIDs, team)
 	if err != nil {
-		logger.LogBusinessTransactionEnd(operation, time.Since(start), false, map[string]interface{}{
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
 			"team_name": req.TeamName,
 			"error":     err.Error(),
 		})
@@ -91,23 +95,14 @@ func (s *UserServiceImpl) DeactivateTeamMembers(
 
 	deactivatedUserIDs, err := s.teamRepo.DeactivateTeamMembers(ctx, req.TeamName, req.UserIDs, reassignments)
 	if err != nil {
-		logger.LogBusinessTransactionEnd(operation, time.Since(start), false, map[string]interface{}{
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
 			"team_name": req.TeamName,
 			"error":     err.Error(),
 		})
 		return nil, err
 	}
 
-	affectedReviewers := make([]string, 0, len(deactivatedUserIDs)+len(reassignments))
-	affectedReviewers = append(affectedReviewers, deactivatedUserIDs...)
-	for _, reassignment := range reassignments {
-		if reassignment.NewReviewerID != "" {
-			affectedReviewers = append(affectedReviewers, reassignment.NewReviewerID)
-		}
-	}
-	helpers.UpdateReviewerLoadMetrics(ctx, s.prReviewersRepo, affectedReviewers)
-
-	logger.LogBusinessTransactionEnd(operation, time.Since(start), true, map[string]interface{}{
+	logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), true, map[string]interface{}{
 		"team_name": req.TeamName,
 	})
 	logger.LogCriticalEvent("team_members_deactivated", map[string]interface{}{
@@ -149,6 +144,7 @@ func (s *UserServiceImpl) buildReassignmentsPlan(
 	team *domain.Team,
 ) ([]domain.ReviewerReassignment, error) {
 	reassignments := make([]domain.ReviewerReassignment, 0, len(prMap))
+	settings := team.EffectiveSettings()
 
 	usersToDeactivateSet := make(map[string]struct{}, len(usersToDeactivate))
 	for _, userID := range usersToDeactivate {
@@ -186,13 +182,33 @@ func (s *UserServiceImpl) buildReassignmentsPlan(
 			continue
 		}
 
-		candidates := helpers.RandSelectReviewers(availableMembers, pr.AuthorID, len(reviewersToReplace))
+		// Исключаем уже назначенных заранее, чтобы стратегия выбора не тратила на них слоты
+		prCandidates := make([]domain.TeamMember, 0, len(availableMembers))
+		for _, member := range availableMembers {
+			if _, exists := alreadyAssigned[member.UserID]; !exists {
+				prCandidates = append(prCandidates, member)
+			}
+		}
+
+		availableCandidates, err := s.reviewerSelector.SelectReviewers(ctx, team, prCandidates, pr.AuthorID, len(reviewersToReplace))
+		if err != nil {
+			return nil, err
+		}
+
+		if missing := len(reviewersToReplace) - len(availableCandidates); missing > 0 {
+			excluded := make(map[string]struct{}, len(alreadyAssigned)+len(availableCandidates))
+			for userID := range alreadyAssigned {
+				excluded[userID] = struct{}{}
+			}
+			for _, candidate := range availableCandidates {
+				excluded[candidate] = struct{}{}
+			}
 
-		availableCandidates := make([]string, 0, len(candidates))
-		for _, candidate := range candidates {
-			if _, exists := alreadyAssigned[candidate]; !exists {
-				availableCandidates = append(availableCandidates, candidate)
+			extra, err := reviewer_selector.SelectCrossTeamReviewers(ctx, s.reviewerSelector, s.userRepo, team, pr.AuthorID, excluded, missing)
+			if err != nil {
+				return nil, err
 			}
+			availableCandidates = append(availableCandidates, extra...)
 		}
 
 		candidateIndex := 0
@@ -215,9 +231,12 @@ func (s *UserServiceImpl) buildReassignmentsPlan(
 			})
 		}
 
+		// Нельзя опускать PR ниже min_reviewers команды. Если PR уже был ниже порога,
+		// достаточно не уменьшать текущее количество ревьюверов.
 		finalReviewerCount := len(currentReviewers) - len(reviewersToReplace) + addedCount
-		if finalReviewerCount == 0 {
-			return nil, fmt.Errorf("%w: PR %s would be left without reviewers", domain.ErrNoCandidate, pr.PullRequestID)
+		if finalReviewerCount < min(settings.MinReviewers, len(currentReviewers)) {
+			return nil, fmt.Errorf("%w: PR %s would be left with %d reviewers, team requires at least %d",
+				domain.ErrNoCandidate, pr.PullRequestID, finalReviewerCount, settings.MinReviewers)
 		}
 	}
 
@@ -2,6 +2,7 @@ package service
 
 import (
 	"AVITOSAMPISHU/internal/domain"
+	reviewer_selector "AVITOSAMPISHU/internal/service/reviewer_selector"
 	"AVITOSAMPISHU/pkg/logger"
 	"context"
 	"testing"
@@ -33,6 +34,14 @@ func (m *MockUserRepository) SetUserIsActive(ctx context.Context, userID string,
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) GetActiveUsersOutsideTeam(ctx context.Context, teamName string) ([]domain.TeamMember, error) {
+	args := m.Called(ctx, teamName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.TeamMember), args.Error(1)
+}
+
 type MockPrReviewersRepository struct {
 	mock.Mock
 }
@@ -53,11 +62,48 @@ func (m *MockPrReviewersRepository) GetPRsByReviewer(ctx context.Context, userID
 	return args.Get(0).([]domain.PullRequestShort), args.Error(1)
 }
 
+func (m *MockPrReviewersRepository) ListPRsByReviewer(ctx context.Context, req *domain.GetUserReviewsReq, limit int) ([]domain.PullRequestShort, error) {
+	args := m.Called(ctx, req, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PullRequestShort), args.Error(1)
+}
+
 func (m *MockPrReviewersRepository) ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string) error {
 	args := m.Called(ctx, prID, oldReviewerID, newReviewerID)
 	return args.Error(0)
 }
 
+func (m *MockPrReviewersRepository) GetOpenReviewsCount(ctx context.Context, userIDs []string) (map[string]int, error) {
+	args := m.Called(ctx, userIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+func (m *MockPrReviewersRepository) GetReviews(ctx context.Context, prID string) ([]domain.ReviewerState, error) {
+	args := m.Called(ctx, prID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ReviewerState), args.Error(1)
+}
+
+func (m *MockPrReviewersRepository) SubmitReview(ctx context.Context, prID, reviewerID string, verdict domain.ReviewVerdict, comment string) error {
+	args := m.Called(ctx, prID, reviewerID, verdict, comment)
+	return args.Error(0)
+}
+
+func (m *MockPrReviewersRepository) GetReviewerHistory(ctx context.Context, prID string) ([]domain.ReviewerAssignment, error) {
+	args := m.Called(ctx, prID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ReviewerAssignment), args.Error(1)
+}
+
 type MockTeamRepository struct {
 	mock.Mock
 }
@@ -70,8 +116,8 @@ func (m *MockTeamRepository) GetTeamByName(ctx context.Context, teamName string)
 	return args.Get(0).(*domain.Team), args.Error(1)
 }
 
-func (m *MockTeamRepository) CreateTeamWithMembers(ctx context.Context, teamName string, members []domain.TeamMember) (uuid.UUID, error) {
-	args := m.Called(ctx, teamName, members)
+func (m *MockTeamRepository) CreateTeamWithMembers(ctx context.Context, teamName string, members []domain.TeamMember, settings domain.TeamSettings) (uuid.UUID, error) {
+	args := m.Called(ctx, teamName, members, settings)
 	if args.Get(0) == nil {
 		return uuid.Nil, args.Error(1)
 	}
@@ -86,6 +132,19 @@ func (m *MockTeamRepository) DeactivateTeamMembers(ctx context.Context, teamName
 	return args.Get(0).([]string), args.Error(1)
 }
 
+func (m *MockTeamRepository) GetTeamSettings(ctx context.Context, teamName string) (*domain.TeamSettings, error) {
+	args := m.Called(ctx, teamName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TeamSettings), args.Error(1)
+}
+
+func (m *MockTeamRepository) UpdateTeamSettings(ctx context.Context, teamName string, settings *domain.TeamSettings) error {
+	args := m.Called(ctx, teamName, settings)
+	return args.Error(0)
+}
+
 func TestUserServiceImpl_DeactivateTeamMembers(t *testing.T) {
 	tests := []struct {
 		name                 string
@@ -114,6 +173,30 @@ func TestUserServiceImpl_DeactivateTeamMembers(t *testing.T) {
 			wantErr:              nil,
 			wantDeactivatedCount: 1,
 		},
+		{
+			name: "pr would drop below team min_reviewers",
+			req: &domain.DeactivateTeamMembersReq{
+				TeamName: "team1",
+				UserIDs:  []string{"user1"},
+			},
+			setupMocks: func(teamRepo *MockTeamRepository, prRepo *MockPrReviewersRepository, userRepo *MockUserRepository) {
+				teamRepo.On("GetTeamByName", mock.Anything, "team1").Return(&domain.Team{
+					TeamName: "team1",
+					Members: []domain.TeamMember{
+						{UserID: "user1", IsActive: true},
+						{UserID: "user2", IsActive: true},
+						{UserID: "user3", IsActive: true},
+					},
+					Settings: &domain.TeamSettings{RequiredReviewers: 2, MinReviewers: 2},
+				}, nil)
+				prRepo.On("GetPRsByReviewer", mock.Anything, "user1").Return([]domain.PullRequestShort{
+					{PullRequestID: "pr1", AuthorID: "user3", Status: domain.PRStatusOpen},
+				}, nil)
+				prRepo.On("GetAssignedReviewers", mock.Anything, "pr1").Return([]string{"user1", "user2"}, nil)
+			},
+			wantErr:              domain.ErrNoCandidate,
+			wantDeactivatedCount: 0,
+		},
 		{
 			name: "team not found",
 			req: &domain.DeactivateTeamMembersReq{
@@ -189,9 +272,10 @@ func TestUserServiceImpl_DeactivateTeamMembers(t *testing.T) {
 			tt.setupMocks(teamRepo, prRepo, userRepo)
 
 			service := &UserServiceImpl{
-				teamRepo:        teamRepo,
-				prReviewersRepo: prRepo,
-				userRepo:        userRepo,
+				teamRepo:         teamRepo,
+				prReviewersRepo:  prRepo,
+				userRepo:         userRepo,
+				reviewerSelector: reviewer_selector.NewRandomSelector(),
 			}
 
 			result, err := service.DeactivateTeamMembers(context.Background(), tt.req)
@@ -5,16 +5,32 @@ import (
 	"context"
 )
 
-// GetUserReviews возвращает список PR, где пользователь назначен ревьювером.
-func (s *UserServiceImpl) GetUserReviews(ctx context.Context, userID string) ([]domain.PullRequestShort, error) {
-	if _, err := s.userRepo.GetUserByID(ctx, userID); err != nil {
+// GetUserReviews возвращает страницу PR, где пользователь назначен ревьювером, и курсор следующей страницы.
+func (s *UserServiceImpl) GetUserReviews(
+	ctx context.Context,
+	req *domain.GetUserReviewsReq,
+) (*domain.GetUserReviewsResponse, error) {
+	if _, err := s.userRepo.GetUserByID(ctx, req.UserID); err != nil {
 		return nil, err
 	}
 
-	prs, err := s.prReviewersRepo.GetPRsByReviewer(ctx, userID)
+	limit := req.Limit
+	if limit <= 0 {
+		limit = domain.DefaultPageLimit
+	}
+
+	// Запрашиваем на одну запись больше, чтобы понять, есть ли следующая страница
+	prs, err := s.prReviewersRepo.ListPRsByReviewer(ctx, req, limit+1)
 	if err != nil {
 		return nil, err
 	}
 
-	return prs, nil
+	res := &domain.GetUserReviewsResponse{UserID: req.UserID, PullRequests: prs}
+	if len(prs) > limit {
+		res.PullRequests = prs[:limit]
+		last := res.PullRequests[limit-1]
+		res.NextCursor = domain.PageCursor{CreatedAt: *last.CreatedAt, ID: last.PullRequestID}.Encode()
+	}
+
+	return res, nil
 }
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserServiceImpl_GetUserReviews(t *testing.T) {
+	base := time.Date(2025, 10, 24, 10, 0, 0, 0, time.UTC)
+	shortPR := func(id string, offset time.Duration) domain.PullRequestShort {
+		createdAt := base.Add(offset)
+		return domain.PullRequestShort{PullRequestID: id, Status: domain.PRStatusOpen, CreatedAt: &createdAt}
+	}
+
+	t.Run("next page exists", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		prRepo := new(MockPrReviewersRepository)
+		req := &domain.GetUserReviewsReq{UserID: "u1", Statuses: []domain.PRStatus{domain.PRStatusOpen}, Limit: 2}
+
+		userRepo.On("GetUserByID", mock.Anything, "u1").Return(&domain.User{UserID: "u1"}, nil)
+		prRepo.On("ListPRsByReviewer", mock.Anything, req, 3).Return([]domain.PullRequestShort{
+			shortPR("pr3", 2*time.Hour), shortPR("pr2", time.Hour), shortPR("pr1", 0),
+		}, nil)
+
+		svc := &UserServiceImpl{userRepo: userRepo, prReviewersRepo: prRepo}
+		res, err := svc.GetUserReviews(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, "u1", res.UserID)
+		require.Len(t, res.PullRequests, 2)
+		assert.Equal(t, "pr2", res.PullRequests[1].PullRequestID)
+
+		cursor, err := domain.DecodePageCursor(res.NextCursor)
+		require.NoError(t, err)
+		assert.Equal(t, "pr2", cursor.ID)
+		assert.True(t, base.Add(time.Hour).Equal(cursor.CreatedAt))
+	})
+
+	t.Run("last page uses default limit", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		prRepo := new(MockPrReviewersRepository)
+		req := &domain.GetUserReviewsReq{UserID: "u1"}
+
+		userRepo.On("GetUserByID", mock.Anything, "u1").Return(&domain.User{UserID: "u1"}, nil)
+		prRepo.On("ListPRsByReviewer", mock.Anything, req, domain.DefaultPageLimit+1).
+			Return([]domain.PullRequestShort{shortPR("pr1", 0)}, nil)
+
+		svc := &UserServiceImpl{userRepo: userRepo, prReviewersRepo: prRepo}
+		res, err := svc.GetUserReviews(context.Background(), req)
+		require.NoError(t, err)
+		assert.Len(t, res.PullRequests, 1)
+		assert.Empty(t, res.NextCursor)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		prRepo := new(MockPrReviewersRepository)
+
+		userRepo.On("GetUserByID", mock.Anything, "ghost").Return(nil, domain.ErrNotFound)
+
+		svc := &UserServiceImpl{userRepo: userRepo, prReviewersRepo: prRepo}
+		_, err := svc.GetUserReviews(context.Background(), &domain.GetUserReviewsReq{UserID: "ghost"})
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		prRepo.AssertNotCalled(t, "ListPRsByReviewer", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
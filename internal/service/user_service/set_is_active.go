@@ -2,6 +2,7 @@ package service
 
 import (
 	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/infrastructure/tracing"
 	"AVITOSAMPISHU/pkg/logger"
 	"context"
 	"time"
@@ -12,13 +13,16 @@ func (s *UserServiceImpl) SetIsActive(ctx context.Context, req *domain.SetIsActi
 	start := time.Now()
 	operation := "SetIsActive"
 
-	logger.LogBusinessTransactionStart(operation, map[string]interface{}{
+	ctx, span := tracing.Start(ctx, "UserService."+operation)
+	defer span.End()
+
+	logger.LogBusinessTransactionStart(ctx, operation, map[string]interface{}{
 		"user_id":   req.UserID,
 		"is_active": req.IsActive,
 	})
 
 	if err := s.userRepo.SetUserIsActive(ctx, req.UserID, req.IsActive); err != nil {
-		logger.LogBusinessTransactionEnd(operation, time.Since(start), false, map[string]interface{}{
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
 			"user_id": req.UserID,
 			"error":   err.Error(),
 		})
@@ -27,7 +31,7 @@ func (s *UserServiceImpl) SetIsActive(ctx context.Context, req *domain.SetIsActi
 
 	user, err := s.userRepo.GetUserByID(ctx, req.UserID)
 	if err != nil {
-		logger.LogBusinessTransactionEnd(operation, time.Since(start), false, map[string]interface{}{
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
 			"user_id": req.UserID,
 			"error":   err.Error(),
 		})
@@ -35,7 +39,7 @@ func (s *UserServiceImpl) SetIsActive(ctx context.Context, req *domain.SetIsActi
 	}
 
 	duration := time.Since(start)
-	logger.LogBusinessTransactionEnd(operation, duration, true, map[string]interface{}{
+	logger.LogBusinessTransactionEnd(ctx, operation, duration, true, map[string]interface{}{
 		"user_id":   user.UserID,
 		"is_active": user.IsActive,
 	})
@@ -2,22 +2,26 @@ package service
 
 import (
 	"AVITOSAMPISHU/internal/repository"
+	"AVITOSAMPISHU/internal/service"
 )
 
 type UserServiceImpl struct {
-	userRepo        repository.UserRepositoryInterface
-	prReviewersRepo repository.PrReviewersRepositoryInterface
-	teamRepo        repository.TeamRepositoryInterface
+	userRepo         repository.UserRepositoryInterface
+	prReviewersRepo  repository.PrReviewersRepositoryInterface
+	teamRepo         repository.TeamRepositoryInterface
+	reviewerSelector service.ReviewerSelector
 }
 
 func NewUserService(
 	userRepo repository.UserRepositoryInterface,
 	prReviewersRepo repository.PrReviewersRepositoryInterface,
 	teamRepo repository.TeamRepositoryInterface,
+	reviewerSelector service.ReviewerSelector,
 ) *UserServiceImpl {
 	return &UserServiceImpl{
-		userRepo:        userRepo,
-		prReviewersRepo: prReviewersRepo,
-		teamRepo:        teamRepo,
+		userRepo:         userRepo,
+		prReviewersRepo:  prReviewersRepo,
+		teamRepo:         teamRepo,
+		reviewerSelector: reviewerSelector,
 	}
 }
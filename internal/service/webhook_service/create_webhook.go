@@ -0,0 +1,66 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/infrastructure/tracing"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const generatedSecretBytes = 32
+
+// CreateWebhook регистрирует подписку. Секрет возвращается только в ответе на создание
+func (s *WebhookServiceImpl) CreateWebhook(ctx context.Context, req *domain.CreateWebhookReq) (*domain.WebhookSubscription, error) {
+	start := time.Now()
+	operation := "CreateWebhook"
+
+	ctx, span := tracing.Start(ctx, "WebhookService."+operation)
+	defer span.End()
+
+	logger.LogBusinessTransactionStart(ctx, operation, map[string]interface{}{
+		"url":    req.URL,
+		"events": req.Events,
+	})
+
+	secret := req.Secret
+	if secret == "" {
+		buf := make([]byte, generatedSecretBytes)
+		if _, err := rand.Read(buf); err != nil {
+			logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
+				"error": err.Error(),
+			})
+			return nil, err
+		}
+		secret = hex.EncodeToString(buf)
+	}
+
+	sub := &domain.WebhookSubscription{
+		ID:       uuid.New(),
+		URL:      req.URL,
+		Secret:   secret,
+		Events:   req.Events,
+		IsActive: true,
+	}
+	if err := s.webhookRepo.CreateSubscription(ctx, sub); err != nil {
+		logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), false, map[string]interface{}{
+			"error": err.Error(),
+		})
+		return nil, err
+	}
+
+	logger.LogBusinessTransactionEnd(ctx, operation, time.Since(start), true, map[string]interface{}{
+		"webhook_id": sub.ID.String(),
+	})
+	logger.LogCriticalEvent("webhook_created", map[string]interface{}{
+		"webhook_id": sub.ID.String(),
+		"url":        sub.URL,
+		"events":     sub.Events,
+	})
+
+	return sub, nil
+}
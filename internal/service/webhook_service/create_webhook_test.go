@@ -0,0 +1,60 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/repository/mocks"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateWebhook(t *testing.T) {
+	adminCtx := domain.ContextWithActor(context.Background(), domain.Actor{ID: domain.AdminActorID, Role: domain.RoleAdmin})
+	req := &domain.CreateWebhookReq{
+		URL:    "https://example.com/hook",
+		Events: []domain.EventType{domain.EventPullRequestCreated},
+	}
+
+	t.Run("generates secret", func(t *testing.T) {
+		var stored *domain.WebhookSubscription
+		svc := NewWebhookService(&mocks.MockWebhookRepository{
+			CreateSubscriptionFunc: func(_ context.Context, sub *domain.WebhookSubscription) error {
+				stored = sub
+				return nil
+			},
+		})
+
+		sub, err := svc.CreateWebhook(adminCtx, req)
+		require.NoError(t, err)
+		assert.Same(t, stored, sub)
+		assert.True(t, sub.IsActive)
+		assert.Len(t, sub.Secret, 2*generatedSecretBytes)
+	})
+
+	t.Run("keeps provided secret", func(t *testing.T) {
+		svc := NewWebhookService(&mocks.MockWebhookRepository{})
+		withSecret := *req
+		withSecret.Secret = "shared"
+
+		sub, err := svc.CreateWebhook(adminCtx, &withSecret)
+		require.NoError(t, err)
+		assert.Equal(t, "shared", sub.Secret)
+	})
+}
+
+func TestListWebhooksHidesSecrets(t *testing.T) {
+	adminCtx := domain.ContextWithActor(context.Background(), domain.Actor{ID: domain.AdminActorID, Role: domain.RoleAdmin})
+	svc := NewWebhookService(&mocks.MockWebhookRepository{
+		ListSubscriptionsFunc: func(_ context.Context, eventType domain.EventType) ([]domain.WebhookSubscription, error) {
+			assert.Empty(t, eventType)
+			return []domain.WebhookSubscription{{URL: "https://example.com", Secret: "s"}}, nil
+		},
+	})
+
+	subs, err := svc.ListWebhooks(adminCtx)
+	require.NoError(t, err)
+	require.Len(t, subs, 1)
+	assert.Empty(t, subs[0].Secret)
+}
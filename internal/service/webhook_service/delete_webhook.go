@@ -0,0 +1,18 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+)
+
+func (s *WebhookServiceImpl) DeleteWebhook(ctx context.Context, req *domain.DeleteWebhookReq) error {
+	if err := s.webhookRepo.DeleteSubscription(ctx, req.ID); err != nil {
+		return err
+	}
+
+	logger.LogCriticalEvent("webhook_deleted", map[string]interface{}{
+		"webhook_id": req.ID.String(),
+	})
+	return nil
+}
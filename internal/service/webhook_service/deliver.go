@@ -0,0 +1,130 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Сколько байт ответа подписчика вычитываем, чтобы соединение можно было переиспользовать
+const maxDrainedResponseBytes = 64 << 10
+
+// dispatch запускает доставку в фоне
+func (s *WebhookServiceImpl) dispatch(sub *domain.WebhookSubscription, delivery *domain.WebhookDelivery) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.deliver(s.ctx, sub, delivery)
+	}()
+}
+
+// deliver отправляет событие подписчику, повторяя попытки с экспоненциальной задержкой,
+// пока подписчик не ответит 2xx или не закончатся попытки. Результат каждой попытки сохраняется в журнал
+func (s *WebhookServiceImpl) deliver(ctx context.Context, sub *domain.WebhookSubscription, delivery *domain.WebhookDelivery) {
+	for {
+		statusCode, err := s.send(ctx, sub, delivery)
+		if err != nil && ctx.Err() != nil {
+			// Сервис останавливается: попытка не считается, доставка останется PENDING и будет возобновлена
+			return
+		}
+		delivery.Attempts++
+		delivery.LastStatusCode = statusCode
+		delivery.LastError = ""
+		delivery.NextAttemptAt = nil
+
+		if err == nil {
+			now := time.Now()
+			delivery.Status = domain.WebhookDeliverySucceeded
+			delivery.DeliveredAt = &now
+			s.saveDelivery(delivery)
+			return
+		}
+
+		delivery.LastError = err.Error()
+		if delivery.Attempts >= s.maxAttempts {
+			delivery.Status = domain.WebhookDeliveryFailed
+			s.saveDelivery(delivery)
+			logger.Logger.Warnw("webhook delivery failed",
+				"webhook_id", sub.ID.String(),
+				"delivery_id", delivery.ID.String(),
+				"event", delivery.EventType,
+				"attempts", delivery.Attempts,
+				"error", err,
+			)
+			return
+		}
+
+		wait := s.backoff(delivery.Attempts)
+		next := time.Now().Add(wait)
+		delivery.Status = domain.WebhookDeliveryPending
+		delivery.NextAttemptAt = &next
+		s.saveDelivery(delivery)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// send выполняет одну попытку доставки и возвращает HTTP статус ответа, если он был получен
+func (s *WebhookServiceImpl) send(ctx context.Context, sub *domain.WebhookSubscription, delivery *domain.WebhookDelivery) (*int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(domain.WebhookSignatureHeader, sign(sub.Secret, delivery.Payload))
+	req.Header.Set(domain.WebhookEventHeader, string(delivery.EventType))
+	req.Header.Set(domain.WebhookDeliveryHeader, delivery.ID.String())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainedResponseBytes))
+
+	statusCode := resp.StatusCode
+	if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
+		return &statusCode, fmt.Errorf("subscriber responded with status %d", statusCode)
+	}
+	return &statusCode, nil
+}
+
+// backoff возвращает задержку перед следующей попыткой: base * 2^(attempt-1), но не больше maxBackoff
+func (s *WebhookServiceImpl) backoff(attempt int) time.Duration {
+	wait := s.baseBackoff
+	for i := 1; i < attempt; i++ {
+		wait *= 2
+		if wait >= s.maxBackoff {
+			return s.maxBackoff
+		}
+	}
+	return wait
+}
+
+// saveDelivery сохраняет состояние доставки даже после остановки сервиса
+func (s *WebhookServiceImpl) saveDelivery(delivery *domain.WebhookDelivery) {
+	if err := s.webhookRepo.UpdateDelivery(context.WithoutCancel(s.ctx), delivery); err != nil {
+		logger.Logger.Errorw("failed to update webhook delivery", "delivery_id", delivery.ID.String(), "error", err)
+	}
+}
+
+// sign вычисляет значение заголовка подписи: HMAC-SHA256 тела запроса на секрете подписки
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
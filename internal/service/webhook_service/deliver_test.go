@@ -0,0 +1,390 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/repository/mocks"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logger.InitLogger()
+}
+
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// newReceiver поднимает подписчика, который отвечает статусами из statuses по очереди, а затем 200
+func newReceiver(t *testing.T, statuses ...int) (*httptest.Server, <-chan receivedRequest) {
+	t.Helper()
+
+	received := make(chan receivedRequest, 16)
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- receivedRequest{header: r.Header.Clone(), body: body}
+
+		call := int(atomic.AddInt32(&calls, 1))
+		if call <= len(statuses) {
+			w.WriteHeader(statuses[call-1])
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, received
+}
+
+// newTestService возвращает сервис с короткими задержками и канал с финальными состояниями доставок
+func newTestService(repo *mocks.MockWebhookRepository) (*WebhookServiceImpl, <-chan domain.WebhookDelivery) {
+	final := make(chan domain.WebhookDelivery, 16)
+	repo.UpdateDeliveryFunc = func(_ context.Context, d *domain.WebhookDelivery) error {
+		if d.Status != domain.WebhookDeliveryPending {
+			final <- *d
+		}
+		return nil
+	}
+
+	svc := NewWebhookService(repo)
+	svc.baseBackoff = time.Millisecond
+	svc.maxBackoff = 5 * time.Millisecond
+	return svc, final
+}
+
+func waitDelivery(t *testing.T, final <-chan domain.WebhookDelivery) domain.WebhookDelivery {
+	t.Helper()
+	select {
+	case d := <-final:
+		return d
+	case <-time.After(5 * time.Second):
+		t.Fatal("delivery did not finish")
+		return domain.WebhookDelivery{}
+	}
+}
+
+func TestHandleEvent_SignsAndDeliversEvent(t *testing.T) {
+	receiver, received := newReceiver(t)
+	sub := domain.WebhookSubscription{
+		ID:     uuid.New(),
+		URL:    receiver.URL,
+		Secret: "top-secret",
+		Events: []domain.EventType{domain.EventPullRequestMerged},
+	}
+
+	var recorded *domain.WebhookDelivery
+	repo := &mocks.MockWebhookRepository{
+		ListSubscriptionsFunc: func(_ context.Context, eventType domain.EventType) ([]domain.WebhookSubscription, error) {
+			assert.Equal(t, domain.EventPullRequestMerged, eventType)
+			return []domain.WebhookSubscription{sub}, nil
+		},
+		CreateDeliveriesFunc: func(_ context.Context, ds []*domain.WebhookDelivery) ([]*domain.WebhookDelivery, error) {
+			require.Len(t, ds, 1)
+			copied := *ds[0]
+			recorded = &copied
+			return ds, nil
+		},
+	}
+	svc, final := newTestService(repo)
+
+	event, err := domain.NewEvent(domain.EventPullRequestMerged, &domain.PullRequest{
+		PullRequestID: "pr-1",
+		Status:        domain.PRStatusMerged,
+	})
+	require.NoError(t, err)
+	require.NoError(t, svc.HandleEvent(context.Background(), event))
+
+	req := <-received
+	delivery := waitDelivery(t, final)
+	require.NoError(t, svc.Shutdown(context.Background()))
+
+	require.NotNil(t, recorded)
+	assert.Equal(t, domain.WebhookDeliveryPending, recorded.Status)
+	assert.Equal(t, sign("top-secret", req.body), req.header.Get(domain.WebhookSignatureHeader))
+	assert.Equal(t, string(domain.EventPullRequestMerged), req.header.Get(domain.WebhookEventHeader))
+	assert.Equal(t, recorded.ID.String(), req.header.Get(domain.WebhookDeliveryHeader))
+	assert.JSONEq(t, string(recorded.Payload), string(req.body))
+
+	var sent domain.Event
+	require.NoError(t, json.Unmarshal(req.body, &sent))
+	assert.Equal(t, event.ID, sent.ID)
+	assert.Equal(t, event.ID, recorded.EventID)
+	assert.Equal(t, domain.EventPullRequestMerged, sent.Type)
+	var pr domain.PullRequest
+	require.NoError(t, json.Unmarshal(sent.Data, &pr))
+	assert.Equal(t, "pr-1", pr.PullRequestID)
+
+	assert.Equal(t, domain.WebhookDeliverySucceeded, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	require.NotNil(t, delivery.LastStatusCode)
+	assert.Equal(t, http.StatusOK, *delivery.LastStatusCode)
+	assert.NotNil(t, delivery.DeliveredAt)
+}
+
+func TestHandleEvent_NoSubscribers(t *testing.T) {
+	repo := &mocks.MockWebhookRepository{
+		CreateDeliveriesFunc: func(context.Context, []*domain.WebhookDelivery) ([]*domain.WebhookDelivery, error) {
+			t.Fatal("delivery must not be recorded without subscribers")
+			return nil, nil
+		},
+	}
+	svc, _ := newTestService(repo)
+
+	event, err := domain.NewEvent(domain.EventPullRequestCreated, &domain.PullRequest{})
+	require.NoError(t, err)
+	require.NoError(t, svc.HandleEvent(context.Background(), event))
+	require.NoError(t, svc.Shutdown(context.Background()))
+}
+
+func TestHandleEvent_ReturnsDeliveryRecordError(t *testing.T) {
+	repo := &mocks.MockWebhookRepository{
+		ListSubscriptionsFunc: func(context.Context, domain.EventType) ([]domain.WebhookSubscription, error) {
+			return []domain.WebhookSubscription{{ID: uuid.New(), URL: "http://127.0.0.1:1"}}, nil
+		},
+		CreateDeliveriesFunc: func(context.Context, []*domain.WebhookDelivery) ([]*domain.WebhookDelivery, error) {
+			return nil, errors.New("db is down")
+		},
+	}
+	svc, _ := newTestService(repo)
+
+	event, err := domain.NewEvent(domain.EventPullRequestCreated, &domain.PullRequest{})
+	require.NoError(t, err)
+	assert.Error(t, svc.HandleEvent(context.Background(), event))
+	require.NoError(t, svc.Shutdown(context.Background()))
+}
+
+func TestHandleEvent_SkipsAlreadyRecordedDeliveries(t *testing.T) {
+	receiver, received := newReceiver(t)
+	recorded := domain.WebhookSubscription{ID: uuid.New(), URL: receiver.URL, Secret: "a"}
+	fresh := domain.WebhookSubscription{ID: uuid.New(), URL: receiver.URL, Secret: "b"}
+
+	repo := &mocks.MockWebhookRepository{
+		ListSubscriptionsFunc: func(context.Context, domain.EventType) ([]domain.WebhookSubscription, error) {
+			return []domain.WebhookSubscription{recorded, fresh}, nil
+		},
+		// Доставка первому подписчику записана при прошлой передаче события
+		CreateDeliveriesFunc: func(_ context.Context, ds []*domain.WebhookDelivery) ([]*domain.WebhookDelivery, error) {
+			require.Len(t, ds, 2)
+			return ds[1:], nil
+		},
+	}
+	svc, final := newTestService(repo)
+
+	event, err := domain.NewEvent(domain.EventPullRequestCreated, &domain.PullRequest{})
+	require.NoError(t, err)
+	require.NoError(t, svc.HandleEvent(context.Background(), event))
+
+	delivery := waitDelivery(t, final)
+	require.NoError(t, svc.Shutdown(context.Background()))
+
+	assert.Equal(t, fresh.ID, delivery.SubscriptionID)
+	assert.Len(t, received, 1)
+}
+
+func TestResumeDeliveries(t *testing.T) {
+	receiver, received := newReceiver(t)
+	active := &domain.WebhookSubscription{ID: uuid.New(), URL: receiver.URL, Secret: "s", IsActive: true}
+	inactive := &domain.WebhookSubscription{ID: uuid.New(), URL: receiver.URL, Secret: "s"}
+
+	resumed := domain.WebhookDelivery{
+		ID: uuid.New(), SubscriptionID: active.ID, Payload: []byte(`{}`),
+		Status: domain.WebhookDeliveryPending, Attempts: 2,
+	}
+	orphaned := domain.WebhookDelivery{
+		ID: uuid.New(), SubscriptionID: inactive.ID, Payload: []byte(`{}`),
+		Status: domain.WebhookDeliveryPending, Attempts: 1,
+	}
+
+	var claims int32
+	repo := &mocks.MockWebhookRepository{
+		ClaimDueDeliveriesFunc: func(_ context.Context, overdue time.Duration, limit int) ([]domain.WebhookDelivery, error) {
+			assert.Equal(t, domain.WebhookResumeOverdue, overdue)
+			assert.Equal(t, domain.WebhookResumeBatchSize, limit)
+			if atomic.AddInt32(&claims, 1) > 1 {
+				return nil, nil
+			}
+			return []domain.WebhookDelivery{resumed, orphaned}, nil
+		},
+		GetSubscriptionFunc: func(_ context.Context, id uuid.UUID) (*domain.WebhookSubscription, error) {
+			if id == active.ID {
+				return active, nil
+			}
+			return inactive, nil
+		},
+	}
+	svc, final := newTestService(repo)
+
+	svc.ResumeDeliveries(context.Background())
+
+	byID := map[uuid.UUID]domain.WebhookDelivery{}
+	for range 2 {
+		d := waitDelivery(t, final)
+		byID[d.ID] = d
+	}
+	require.NoError(t, svc.Shutdown(context.Background()))
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&claims))
+	assert.Len(t, received, 1)
+	assert.Equal(t, domain.WebhookDeliverySucceeded, byID[resumed.ID].Status)
+	assert.Equal(t, 3, byID[resumed.ID].Attempts)
+	assert.Equal(t, domain.WebhookDeliveryFailed, byID[orphaned.ID].Status)
+	assert.Equal(t, 1, byID[orphaned.ID].Attempts)
+}
+
+func TestDeliver_LeavesPendingOnShutdown(t *testing.T) {
+	receiver, received := newReceiver(t, http.StatusInternalServerError)
+
+	repo := &mocks.MockWebhookRepository{}
+	svc, final := newTestService(repo)
+	svc.baseBackoff = time.Hour
+	svc.maxBackoff = time.Hour
+
+	pending := make(chan domain.WebhookDelivery, 1)
+	repo.UpdateDeliveryFunc = func(_ context.Context, d *domain.WebhookDelivery) error {
+		pending <- *d
+		return nil
+	}
+
+	sub := &domain.WebhookSubscription{ID: uuid.New(), URL: receiver.URL, Secret: "s"}
+	svc.dispatch(sub, &domain.WebhookDelivery{ID: uuid.New(), Payload: []byte(`{}`)})
+
+	saved := waitDelivery(t, pending)
+	require.NoError(t, svc.Shutdown(context.Background()))
+
+	assert.Len(t, received, 1)
+	assert.Empty(t, final)
+	assert.Empty(t, pending)
+	assert.Equal(t, domain.WebhookDeliveryPending, saved.Status)
+	assert.Equal(t, 1, saved.Attempts)
+	assert.NotNil(t, saved.NextAttemptAt)
+}
+
+func TestDeliver_RetriesUntilSuccess(t *testing.T) {
+	receiver, received := newReceiver(t, http.StatusInternalServerError, http.StatusServiceUnavailable)
+
+	var pendingUpdates int32
+	repo := &mocks.MockWebhookRepository{}
+	svc, final := newTestService(repo)
+	updateFinal := repo.UpdateDeliveryFunc
+	repo.UpdateDeliveryFunc = func(ctx context.Context, d *domain.WebhookDelivery) error {
+		if d.Status == domain.WebhookDeliveryPending {
+			atomic.AddInt32(&pendingUpdates, 1)
+			assert.NotNil(t, d.NextAttemptAt)
+			assert.NotEmpty(t, d.LastError)
+		}
+		return updateFinal(ctx, d)
+	}
+
+	sub := &domain.WebhookSubscription{ID: uuid.New(), URL: receiver.URL, Secret: "s"}
+	svc.deliver(context.Background(), sub, &domain.WebhookDelivery{
+		ID:      uuid.New(),
+		Payload: []byte(`{"id":"1"}`),
+		Status:  domain.WebhookDeliveryPending,
+	})
+
+	delivery := waitDelivery(t, final)
+	assert.Len(t, received, 3)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&pendingUpdates))
+	assert.Equal(t, domain.WebhookDeliverySucceeded, delivery.Status)
+	assert.Equal(t, 3, delivery.Attempts)
+	assert.Empty(t, delivery.LastError)
+	assert.Nil(t, delivery.NextAttemptAt)
+}
+
+func TestDeliver_GivesUpAfterMaxAttempts(t *testing.T) {
+	receiver, received := newReceiver(t,
+		http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway,
+	)
+
+	repo := &mocks.MockWebhookRepository{}
+	svc, final := newTestService(repo)
+	svc.maxAttempts = 3
+
+	sub := &domain.WebhookSubscription{ID: uuid.New(), URL: receiver.URL, Secret: "s"}
+	svc.deliver(context.Background(), sub, &domain.WebhookDelivery{ID: uuid.New(), Payload: []byte(`{}`)})
+
+	delivery := waitDelivery(t, final)
+	assert.Len(t, received, 3)
+	assert.Equal(t, domain.WebhookDeliveryFailed, delivery.Status)
+	assert.Equal(t, 3, delivery.Attempts)
+	require.NotNil(t, delivery.LastStatusCode)
+	assert.Equal(t, http.StatusBadGateway, *delivery.LastStatusCode)
+	assert.Contains(t, delivery.LastError, "502")
+	assert.Nil(t, delivery.DeliveredAt)
+}
+
+func TestBackoff(t *testing.T) {
+	svc := NewWebhookService(&mocks.MockWebhookRepository{})
+	svc.baseBackoff = time.Second
+	svc.maxBackoff = 5 * time.Second
+
+	assert.Equal(t, time.Second, svc.backoff(1))
+	assert.Equal(t, 2*time.Second, svc.backoff(2))
+	assert.Equal(t, 4*time.Second, svc.backoff(3))
+	assert.Equal(t, 5*time.Second, svc.backoff(4))
+	assert.Equal(t, 5*time.Second, svc.backoff(10))
+}
+
+func TestReplayDelivery(t *testing.T) {
+	receiver, received := newReceiver(t)
+	sub := &domain.WebhookSubscription{ID: uuid.New(), URL: receiver.URL, Secret: "s"}
+	original := &domain.WebhookDelivery{
+		ID:             uuid.New(),
+		SubscriptionID: sub.ID,
+		EventID:        uuid.New(),
+		EventType:      domain.EventReviewerReassigned,
+		Payload:        []byte(`{"type":"reviewer_reassigned"}`),
+		Status:         domain.WebhookDeliveryFailed,
+		Attempts:       domain.WebhookMaxAttempts,
+	}
+
+	repo := &mocks.MockWebhookRepository{
+		GetDeliveryFunc: func(_ context.Context, id uuid.UUID) (*domain.WebhookDelivery, error) {
+			if id != original.ID {
+				return nil, domain.ErrNotFound
+			}
+			return original, nil
+		},
+		GetSubscriptionFunc: func(_ context.Context, id uuid.UUID) (*domain.WebhookSubscription, error) {
+			require.Equal(t, sub.ID, id)
+			return sub, nil
+		},
+	}
+	svc, final := newTestService(repo)
+	req := &domain.ReplayWebhookDeliveryReq{DeliveryID: original.ID}
+
+	adminCtx := domain.ContextWithActor(context.Background(), domain.Actor{ID: domain.AdminActorID, Role: domain.RoleAdmin})
+	_, err := svc.ReplayDelivery(adminCtx, &domain.ReplayWebhookDeliveryReq{DeliveryID: uuid.New()})
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	replayed, err := svc.ReplayDelivery(adminCtx, req)
+	require.NoError(t, err)
+	assert.NotEqual(t, original.ID, replayed.ID)
+	assert.Equal(t, original.EventID, replayed.EventID)
+	require.NotNil(t, replayed.ReplayOf)
+	assert.Equal(t, original.ID, *replayed.ReplayOf)
+	assert.Equal(t, domain.WebhookDeliveryPending, replayed.Status)
+	assert.Zero(t, replayed.Attempts)
+
+	got := <-received
+	assert.Equal(t, string(original.Payload), string(got.body))
+	assert.Equal(t, replayed.ID.String(), got.header.Get(domain.WebhookDeliveryHeader))
+
+	delivery := waitDelivery(t, final)
+	assert.Equal(t, domain.WebhookDeliverySucceeded, delivery.Status)
+	assert.Equal(t, domain.WebhookDeliveryFailed, original.Status, "original log entry must stay untouched")
+	require.NoError(t, svc.Shutdown(context.Background()))
+}
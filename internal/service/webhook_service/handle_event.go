@@ -0,0 +1,54 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+// HandleEvent записывает доставки события всем активным подписчикам одной транзакцией и отправляет их в фоне.
+// Ошибка возвращается, только если доставки не удалось записать: тогда outbox передаст событие повторно,
+// а уже записанные при прошлой передаче доставки не продублируются
+func (s *WebhookServiceImpl) HandleEvent(ctx context.Context, event *domain.Event) error {
+	subs, err := s.webhookRepo.ListSubscriptions(ctx, event.Type)
+	if err != nil {
+		return err
+	}
+	if len(subs) == 0 {
+		return nil
+	}
+
+	// Тело запроса — конверт события целиком, чтобы подписчик мог отбросить повтор по id
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	subsByID := make(map[uuid.UUID]*domain.WebhookSubscription, len(subs))
+	deliveries := make([]*domain.WebhookDelivery, 0, len(subs))
+	for i := range subs {
+		subsByID[subs[i].ID] = &subs[i]
+		deliveries = append(deliveries, &domain.WebhookDelivery{
+			ID:             uuid.New(),
+			SubscriptionID: subs[i].ID,
+			EventID:        event.ID,
+			EventType:      event.Type,
+			Payload:        payload,
+			Status:         domain.WebhookDeliveryPending,
+		})
+	}
+
+	created, err := s.webhookRepo.CreateDeliveries(ctx, deliveries)
+	if err != nil {
+		return err
+	}
+
+	// Отправляем только записанные сейчас доставки: остальные уже отправляются или будут возобновлены
+	for _, delivery := range created {
+		s.dispatch(subsByID[delivery.SubscriptionID], delivery)
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"context"
+)
+
+// ListDeliveries возвращает журнал последних доставок подписки
+func (s *WebhookServiceImpl) ListDeliveries(ctx context.Context, req *domain.ListWebhookDeliveriesReq) ([]domain.WebhookDelivery, error) {
+	if _, err := s.webhookRepo.GetSubscription(ctx, req.SubscriptionID); err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = domain.DefaultPageLimit
+	}
+
+	return s.webhookRepo.ListDeliveries(ctx, req.SubscriptionID, limit)
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"context"
+)
+
+// ListWebhooks возвращает все подписки без секретов
+func (s *WebhookServiceImpl) ListWebhooks(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	subs, err := s.webhookRepo.ListSubscriptions(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range subs {
+		subs[i].Secret = ""
+	}
+	return subs, nil
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// ReplayDelivery повторно отправляет событие из журнала. Создаётся новая запись доставки с тем же event_id
+// и ссылкой на исходную, чтобы подписчик мог отбросить дубликат, а исходная запись журнала остаётся без изменений
+func (s *WebhookServiceImpl) ReplayDelivery(ctx context.Context, req *domain.ReplayWebhookDeliveryReq) (*domain.WebhookDelivery, error) {
+	original, err := s.webhookRepo.GetDelivery(ctx, req.DeliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	sub, err := s.webhookRepo.GetSubscription(ctx, original.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery := &domain.WebhookDelivery{
+		ID:             uuid.New(),
+		SubscriptionID: original.SubscriptionID,
+		EventID:        original.EventID,
+		EventType:      original.EventType,
+		Payload:        original.Payload,
+		Status:         domain.WebhookDeliveryPending,
+		ReplayOf:       &original.ID,
+	}
+	if err := s.webhookRepo.CreateDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	logger.LogCriticalEvent("webhook_delivery_replayed", map[string]interface{}{
+		"webhook_id":           sub.ID.String(),
+		"original_delivery_id": original.ID.String(),
+		"delivery_id":          delivery.ID.String(),
+	})
+
+	replayed := *delivery
+	s.dispatch(sub, delivery)
+
+	return &replayed, nil
+}
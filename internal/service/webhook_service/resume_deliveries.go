@@ -0,0 +1,77 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/pkg/logger"
+	"context"
+	"errors"
+	"time"
+)
+
+// StartResumer запускает в фоне возобновление брошенных доставок: сразу после старта и затем каждые interval.
+// Так доставки, которые ждали повторной попытки при остановке или падении процесса, не остаются в PENDING навсегда.
+// Останавливается вместе с сервисом в Shutdown
+func (s *WebhookServiceImpl) StartResumer(interval time.Duration) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			s.ResumeDeliveries(s.ctx)
+
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// ResumeDeliveries забирает просроченные доставки пачками и отправляет их в фоне, продолжая счёт попыток
+func (s *WebhookServiceImpl) ResumeDeliveries(ctx context.Context) {
+	for ctx.Err() == nil {
+		deliveries, err := s.webhookRepo.ClaimDueDeliveries(ctx, domain.WebhookResumeOverdue, domain.WebhookResumeBatchSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.Logger.Errorw("failed to claim webhook deliveries", "error", err)
+			}
+			return
+		}
+
+		for i := range deliveries {
+			s.resume(ctx, &deliveries[i])
+		}
+
+		if len(deliveries) < domain.WebhookResumeBatchSize {
+			return
+		}
+	}
+}
+
+func (s *WebhookServiceImpl) resume(ctx context.Context, delivery *domain.WebhookDelivery) {
+	sub, err := s.webhookRepo.GetSubscription(ctx, delivery.SubscriptionID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		logger.Logger.Errorw("failed to load webhook subscription", "delivery_id", delivery.ID.String(), "error", err)
+		return
+	}
+
+	// Подписку отключили после записи доставки: отправлять некуда
+	if sub == nil || !sub.IsActive {
+		delivery.Status = domain.WebhookDeliveryFailed
+		delivery.NextAttemptAt = nil
+		delivery.LastError = "subscription is inactive"
+		s.saveDelivery(delivery)
+		return
+	}
+
+	logger.Logger.Infow("resuming webhook delivery",
+		"webhook_id", sub.ID.String(),
+		"delivery_id", delivery.ID.String(),
+		"attempts", delivery.Attempts,
+	)
+	s.dispatch(sub, delivery)
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"AVITOSAMPISHU/internal/domain"
+	"AVITOSAMPISHU/internal/repository"
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type WebhookServiceImpl struct {
+	webhookRepo repository.WebhookRepositoryInterface
+	client      *http.Client
+	maxAttempts int
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+
+	// Доставки выполняются в фоне на этом контексте; он отменяется при остановке сервиса
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewWebhookService(webhookRepo repository.WebhookRepositoryInterface) *WebhookServiceImpl {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &WebhookServiceImpl{
+		webhookRepo: webhookRepo,
+		client:      &http.Client{Timeout: domain.WebhookRequestTimeout},
+		maxAttempts: domain.WebhookMaxAttempts,
+		baseBackoff: domain.WebhookBaseBackoff,
+		maxBackoff:  domain.WebhookMaxBackoff,
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+}
+
+// Shutdown останавливает возобновление доставок, прерывает ожидание повторных попыток и дожидается завершения
+// фоновых доставок. Недоставленные события остаются в журнале в статусе PENDING, и после запуска их подберёт
+// возобновление доставок (StartResumer) на этом или другом экземпляре сервиса
+func (s *WebhookServiceImpl) Shutdown(ctx context.Context) error {
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1 @@
+drop table if exists team_settings;
\ No newline at end of file
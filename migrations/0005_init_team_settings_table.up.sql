@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS team_settings (
+    team_id UUID PRIMARY KEY REFERENCES teams(id) ON DELETE CASCADE,
+    selection_strategy VARCHAR(32) NOT NULL DEFAULT 'random',
+    updated_at TIMESTAMP DEFAULT NOW()
+);
@@ -0,0 +1,5 @@
+ALTER TABLE team_settings DROP CONSTRAINT IF EXISTS chk_team_settings_reviewers;
+ALTER TABLE team_settings
+    DROP COLUMN IF EXISTS required_reviewers,
+    DROP COLUMN IF EXISTS min_reviewers,
+    DROP COLUMN IF EXISTS allow_cross_team_fallback;
@@ -0,0 +1,13 @@
+ALTER TABLE team_settings
+    ADD COLUMN IF NOT EXISTS required_reviewers INT NOT NULL DEFAULT 2,
+    ADD COLUMN IF NOT EXISTS min_reviewers INT NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS allow_cross_team_fallback BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE team_settings
+    ADD CONSTRAINT chk_team_settings_reviewers
+    CHECK (required_reviewers >= 1 AND min_reviewers >= 0 AND min_reviewers <= required_reviewers);
+
+-- Команды, созданные до появления настроек, получают значения по умолчанию
+INSERT INTO team_settings (team_id)
+SELECT id FROM teams
+ON CONFLICT (team_id) DO NOTHING;
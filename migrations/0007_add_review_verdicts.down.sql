@@ -0,0 +1,5 @@
+ALTER TABLE reviewers
+    DROP COLUMN IF EXISTS verdict,
+    DROP COLUMN IF EXISTS verdict_comment,
+    DROP COLUMN IF EXISTS reviewed_at;
+drop type if exists review_verdict;
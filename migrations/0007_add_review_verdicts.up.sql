@@ -0,0 +1,11 @@
+DO $$ 
+BEGIN
+    CREATE TYPE review_verdict AS ENUM ('PENDING', 'APPROVED', 'CHANGES_REQUESTED');
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END $$;
+
+ALTER TABLE reviewers
+    ADD COLUMN IF NOT EXISTS verdict review_verdict NOT NULL DEFAULT 'PENDING',
+    ADD COLUMN IF NOT EXISTS verdict_comment TEXT,
+    ADD COLUMN IF NOT EXISTS reviewed_at TIMESTAMP;
@@ -0,0 +1,3 @@
+ALTER TABLE team_settings DROP CONSTRAINT IF EXISTS chk_team_settings_approvals;
+
+ALTER TABLE team_settings DROP COLUMN IF EXISTS required_approvals;
@@ -0,0 +1,7 @@
+-- 0 означает, что проверка одобрений перед слиянием отключена
+ALTER TABLE team_settings
+    ADD COLUMN IF NOT EXISTS required_approvals INT NOT NULL DEFAULT 0;
+
+ALTER TABLE team_settings
+    ADD CONSTRAINT chk_team_settings_approvals
+    CHECK (required_approvals >= 0 AND required_approvals <= required_reviewers);
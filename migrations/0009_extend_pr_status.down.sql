@@ -0,0 +1,14 @@
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS closed_at;
+
+-- В старом наборе статусов нет DRAFT и CLOSED, такие PR возвращаются в OPEN
+UPDATE pull_requests SET status = 'OPEN' WHERE status IN ('DRAFT', 'CLOSED');
+
+-- Значения из enum удалить нельзя, поэтому тип пересоздаётся
+ALTER TYPE pr_status RENAME TO pr_status_old;
+CREATE TYPE pr_status AS ENUM ('OPEN', 'MERGED');
+
+ALTER TABLE pull_requests ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE pull_requests ALTER COLUMN status TYPE pr_status USING status::text::pr_status;
+ALTER TABLE pull_requests ALTER COLUMN status SET DEFAULT 'OPEN';
+
+DROP TYPE pr_status_old;
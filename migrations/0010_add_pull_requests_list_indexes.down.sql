@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_pr_merged_at;
+DROP INDEX IF EXISTS idx_pr_created_at_id;
@@ -0,0 +1,3 @@
+-- Ключ курсорной пагинации списка PR
+CREATE INDEX IF NOT EXISTS idx_pr_created_at_id ON pull_requests(created_at, id);
+CREATE INDEX IF NOT EXISTS idx_pr_merged_at ON pull_requests(merged_at);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_reviewers_reviewed_at;
+DROP TABLE IF EXISTS reviewer_reassignments;
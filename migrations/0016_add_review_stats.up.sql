@@ -0,0 +1,34 @@
+-- Журнал переназначений ревьюверов для аналитики: пара «снятый — назначенный вместо него» записывается
+-- в транзакции переназначения. new_reviewer_id пуст, если при деактивации замена не нашлась
+CREATE TABLE IF NOT EXISTS reviewer_reassignments (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+    old_reviewer_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    new_reviewer_id VARCHAR(255) REFERENCES users(id) ON DELETE CASCADE,
+    reassigned_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_reviewer_reassignments_reassigned_at ON reviewer_reassignments(reassigned_at);
+CREATE INDEX IF NOT EXISTS idx_reviewers_reviewed_at ON reviewers(reviewed_at) WHERE reviewed_at IS NOT NULL;
+
+-- Переназначения, сделанные до появления журнала, восстанавливаются из событий outbox
+INSERT INTO reviewer_reassignments (pull_request_id, old_reviewer_id, new_reviewer_id, reassigned_at)
+SELECT e.pull_request_id, e.old_reviewer_id, e.new_reviewer_id, e.occurred_at
+FROM (
+    SELECT o.payload->>'pull_request_id' AS pull_request_id,
+        o.payload->>'old_reviewer_id' AS old_reviewer_id,
+        NULLIF(o.payload->>'new_reviewer_id', '') AS new_reviewer_id,
+        o.occurred_at
+    FROM outbox o
+    WHERE o.event_type = 'reviewer_reassigned'
+    UNION ALL
+    SELECT ra->>'pr_id', ra->>'old_reviewer_id', NULLIF(ra->>'new_reviewer_id', ''), o.occurred_at
+    FROM outbox o
+    CROSS JOIN LATERAL jsonb_array_elements(
+        CASE WHEN jsonb_typeof(o.payload->'reassignments') = 'array' THEN o.payload->'reassignments' ELSE '[]'::jsonb END
+    ) ra
+    WHERE o.event_type = 'team_members_deactivated'
+) e
+WHERE EXISTS (SELECT 1 FROM pull_requests pr WHERE pr.id = e.pull_request_id)
+    AND EXISTS (SELECT 1 FROM users u WHERE u.id = e.old_reviewer_id)
+    AND (e.new_reviewer_id IS NULL OR EXISTS (SELECT 1 FROM users u WHERE u.id = e.new_reviewer_id));
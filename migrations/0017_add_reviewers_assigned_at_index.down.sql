@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_reviewers_assigned_at;
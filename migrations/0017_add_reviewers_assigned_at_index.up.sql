@@ -0,0 +1,2 @@
+-- Выгрузка истории ревью фильтрует и сортирует назначения по времени
+CREATE INDEX IF NOT EXISTS idx_reviewers_assigned_at ON reviewers(assigned_at);
@@ -0,0 +1,15 @@
+DROP INDEX IF EXISTS idx_reviewers_pull_request_assigned_at;
+DROP INDEX IF EXISTS uniq_reviewers_active;
+
+DELETE FROM reviewers WHERE unassigned_at IS NOT NULL;
+
+ALTER TABLE reviewers
+    DROP CONSTRAINT IF EXISTS reviewers_unassigned_check,
+    DROP CONSTRAINT IF EXISTS reviewers_unassign_reason_check,
+    DROP CONSTRAINT IF EXISTS reviewers_pkey;
+ALTER TABLE reviewers ADD PRIMARY KEY (pull_request_id, reviewer_id);
+
+ALTER TABLE reviewers
+    DROP COLUMN IF EXISTS unassign_reason,
+    DROP COLUMN IF EXISTS unassigned_at,
+    DROP COLUMN IF EXISTS id;
@@ -0,0 +1,19 @@
+-- Снятые ревьюверы остаются в таблице как история: время и причина снятия.
+-- Текущее назначение ревьювера на PR — строка без unassigned_at, такая строка может быть только одна
+ALTER TABLE reviewers
+    ADD COLUMN IF NOT EXISTS id BIGSERIAL,
+    ADD COLUMN IF NOT EXISTS unassigned_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS unassign_reason VARCHAR(32);
+
+ALTER TABLE reviewers DROP CONSTRAINT IF EXISTS reviewers_pkey;
+ALTER TABLE reviewers ADD PRIMARY KEY (id);
+
+ALTER TABLE reviewers
+    ADD CONSTRAINT reviewers_unassign_reason_check
+        CHECK (unassign_reason IN ('manual_reassign', 'deactivation', 'closed')),
+    ADD CONSTRAINT reviewers_unassigned_check
+        CHECK ((unassigned_at IS NULL) = (unassign_reason IS NULL));
+
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_reviewers_active
+    ON reviewers(pull_request_id, reviewer_id) WHERE unassigned_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_reviewers_pull_request_assigned_at ON reviewers(pull_request_id, assigned_at);
@@ -0,0 +1,6 @@
+-- Возобновление доставок ищет просроченные PENDING доставки, а запись доставок события проверяет,
+-- не записаны ли они уже при прошлой передаче события из outbox
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_pending
+    ON webhook_deliveries((COALESCE(next_attempt_at, created_at)))
+    WHERE status = 'PENDING';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_event ON webhook_deliveries(subscription_id, event_id);
@@ -0,0 +1,2 @@
+-- Параметры вызова API (например, force при слиянии), чтобы по журналу было видно обход проверок
+ALTER TABLE audit_events ADD COLUMN IF NOT EXISTS params JSONB;
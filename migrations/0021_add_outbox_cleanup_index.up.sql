@@ -0,0 +1,2 @@
+-- Обработанные события outbox удаляются по времени обработки
+CREATE INDEX IF NOT EXISTS idx_outbox_processed_at ON outbox(processed_at) WHERE processed_at IS NOT NULL;
@@ -0,0 +1,6 @@
+UPDATE reviewers SET unassigned_at = NULL, unassign_reason = NULL WHERE unassign_reason = 'merged';
+
+ALTER TABLE reviewers DROP CONSTRAINT IF EXISTS reviewers_unassign_reason_check;
+ALTER TABLE reviewers
+    ADD CONSTRAINT reviewers_unassign_reason_check
+        CHECK (unassign_reason IN ('manual_reassign', 'deactivation', 'closed'));
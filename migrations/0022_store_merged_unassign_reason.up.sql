@@ -0,0 +1,11 @@
+-- Назначения ревьюверов слитого PR завершаются при слиянии с причиной merged.
+-- Назначения уже слитых PR завершаются временем их слияния
+ALTER TABLE reviewers DROP CONSTRAINT IF EXISTS reviewers_unassign_reason_check;
+ALTER TABLE reviewers
+    ADD CONSTRAINT reviewers_unassign_reason_check
+        CHECK (unassign_reason IN ('manual_reassign', 'deactivation', 'closed', 'merged'));
+
+UPDATE reviewers r
+SET unassigned_at = COALESCE(pr.merged_at, NOW()), unassign_reason = 'merged'
+FROM pull_requests pr
+WHERE pr.id = r.pull_request_id AND pr.status = 'MERGED' AND r.unassigned_at IS NULL;
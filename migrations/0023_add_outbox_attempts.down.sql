@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_outbox_unprocessed;
+CREATE INDEX IF NOT EXISTS idx_outbox_unprocessed ON outbox(id) WHERE processed_at IS NULL;
+
+ALTER TABLE outbox
+    DROP COLUMN IF EXISTS claimed_until,
+    DROP COLUMN IF EXISTS failed_at,
+    DROP COLUMN IF EXISTS last_error,
+    DROP COLUMN IF EXISTS attempts;
@@ -0,0 +1,10 @@
+-- Неудачные попытки обработки события: после OutboxMaxAttempts событие помечается failed_at
+-- и больше не разбирается. claimed_until — до какого момента событие закреплено за экземпляром сервиса
+ALTER TABLE outbox
+    ADD COLUMN IF NOT EXISTS attempts INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS last_error TEXT,
+    ADD COLUMN IF NOT EXISTS failed_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS claimed_until TIMESTAMP;
+
+DROP INDEX IF EXISTS idx_outbox_unprocessed;
+CREATE INDEX IF NOT EXISTS idx_outbox_unprocessed ON outbox(id) WHERE processed_at IS NULL AND failed_at IS NULL;
@@ -0,0 +1,21 @@
+-- Ручной повтор доставки ссылается на исходную доставку и получает тот же event_id, поэтому уникальна пара
+-- подписки и события только у исходных доставок: повтор события из outbox не создаст дубликат даже при гонке
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS replay_of UUID REFERENCES webhook_deliveries(id) ON DELETE CASCADE;
+
+-- Уже записанные повторы: исходной считается самая ранняя доставка подписки и события
+UPDATE webhook_deliveries d
+SET replay_of = first.id
+FROM (
+    SELECT DISTINCT ON (subscription_id, event_id) id, subscription_id, event_id
+    FROM webhook_deliveries
+    ORDER BY subscription_id, event_id, created_at, id
+) first
+WHERE d.subscription_id = first.subscription_id
+    AND d.event_id = first.event_id
+    AND d.id <> first.id
+    AND d.replay_of IS NULL;
+
+DROP INDEX IF EXISTS idx_webhook_deliveries_event;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_deliveries_event
+    ON webhook_deliveries(subscription_id, event_id)
+    WHERE replay_of IS NULL;
@@ -0,0 +1,13 @@
+package helpers
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Querier общий интерфейс *sql.DB и *sql.Tx: одно и то же чтение доступно и вне транзакции,
+// и внутри транзакции изменения
+type Querier interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
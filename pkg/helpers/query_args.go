@@ -0,0 +1,20 @@
+package helpers
+
+import (
+	"fmt"
+	"time"
+)
+
+// QueryArgs параметры SQL-запроса, который собирается из необязательных условий
+type QueryArgs []interface{}
+
+// Add добавляет параметр запроса и возвращает его плейсхолдер
+func (a *QueryArgs) Add(value interface{}) string {
+	*a = append(*a, value)
+	return fmt.Sprintf("$%d", len(*a))
+}
+
+// TimestampArg приводит время к UTC без зоны: колонки хранятся как TIMESTAMP без часового пояса
+func TimestampArg(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04:05.999999")
+}
@@ -0,0 +1,23 @@
+package helpers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryArgs_Add(t *testing.T) {
+	args := make(QueryArgs, 0, 2)
+
+	assert.Equal(t, "$1", args.Add("team1"))
+	assert.Equal(t, "$2", args.Add(10))
+	assert.Equal(t, QueryArgs{"team1", 10}, args)
+}
+
+func TestTimestampArg(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+
+	assert.Equal(t, "2025-10-24 07:00:00.5", TimestampArg(time.Date(2025, 10, 24, 10, 0, 0, 500_000_000, moscow)))
+	assert.Equal(t, "2025-10-24 10:00:00", TimestampArg(time.Date(2025, 10, 24, 10, 0, 0, 0, time.UTC)))
+}
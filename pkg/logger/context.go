@@ -0,0 +1,17 @@
+package logger
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// WithContext возвращает Logger с trace_id и span_id текущего спана, чтобы по строке лога можно было найти трейс
+func WithContext(ctx context.Context) *zap.SugaredLogger {
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.IsValid() {
+		return Logger
+	}
+	return Logger.With("trace_id", spanCtx.TraceID().String(), "span_id", spanCtx.SpanID().String())
+}
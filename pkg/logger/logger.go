@@ -1,8 +1,10 @@
 package logger
 
 import (
+	"fmt"
 	"log"
 	"os"
+	"path/filepath"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -10,17 +12,53 @@ import (
 
 var Logger *zap.SugaredLogger
 
+// Config уровень логирования и куда писать логи. Пути — файлы или stdout/stderr
+type Config struct {
+	Level            string
+	OutputPaths      []string
+	ErrorOutputPaths []string
+}
+
+// ParseLevel разбирает уровень логирования: debug, info, warn или error
+func ParseLevel(level string) (zapcore.Level, error) {
+	parsed, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return parsed, err
+	}
+	if parsed > zapcore.ErrorLevel {
+		return parsed, fmt.Errorf("unsupported log level %q", level)
+	}
+	return parsed, nil
+}
+
+// InitLogger настраивает логгер без вывода для тестов: тесты не создают файлов логов в каталоге пакета.
+// Сервис настраивает логгер по конфигурации через InitLoggerWithConfig
 func InitLogger() {
+	Logger = zap.NewNop().Sugar()
+}
+
+// InitLoggerWithConfig настраивает глобальный Logger по конфигурации
+func InitLoggerWithConfig(cfg Config) error {
 	dev := false
 	encoderCfg := zap.NewProductionEncoderConfig()
-	level := zap.NewAtomicLevelAt(zap.InfoLevel)
+
+	parsed, err := ParseLevel(cfg.Level)
+	if err != nil {
+		return err
+	}
+	level := zap.NewAtomicLevelAt(parsed)
 
 	encoderCfg.TimeKey = "timestamp"
 	encoderCfg.EncodeTime = zapcore.ISO8601TimeEncoder
 
-	// Создаем директорию logs, если её нет
-	if err := os.MkdirAll("logs", 0755); err != nil {
-		log.Printf("Warning: failed to create logs directory: %v", err)
+	// Создаем каталоги для файлов логов, если их нет
+	for _, path := range append(append([]string{}, cfg.OutputPaths...), cfg.ErrorOutputPaths...) {
+		if path == "stdout" || path == "stderr" {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			log.Printf("Warning: failed to create logs directory: %v", err)
+		}
 	}
 
 	config := zap.Config{
@@ -31,21 +69,20 @@ func InitLogger() {
 		Sampling:          nil,
 		Encoding:          "json",
 		EncoderConfig:     encoderCfg,
-		OutputPaths: make([]string, 0, 2),
-		ErrorOutputPaths: make([]string, 0, 2),
+		OutputPaths:       cfg.OutputPaths,
+		ErrorOutputPaths:  cfg.ErrorOutputPaths,
 		InitialFields: map[string]interface{}{
 			"pid": os.Getpid(),
 		},
 	}
-	config.OutputPaths = append(config.OutputPaths, "logs/log.txt", "stdout")
-	config.ErrorOutputPaths = append(config.ErrorOutputPaths, "logs/error.txt", "stderr")
 
 	baseLogger, err := config.Build()
 	if err != nil {
-		log.Fatal("Error building zap logger")
+		return fmt.Errorf("build zap logger: %w", err)
 	}
 
 	Logger = baseLogger.Sugar()
+	return nil
 }
 
 func Sync() {
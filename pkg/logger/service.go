@@ -1,10 +1,11 @@
 package logger
 
 import (
+	"context"
 	"time"
 )
 
-func LogBusinessTransactionStart(operation string, fields map[string]interface{}) {
+func LogBusinessTransactionStart(ctx context.Context, operation string, fields map[string]interface{}) {
 	if Logger == nil {
 		return
 	}
@@ -17,10 +18,10 @@ func LogBusinessTransactionStart(operation string, fields map[string]interface{}
 	for k, v := range fields {
 		args = append(args, k, v)
 	}
-	Logger.Infow("business transaction started", args...)
+	WithContext(ctx).Infow("business transaction started", args...)
 }
 
-func LogBusinessTransactionEnd(operation string, duration time.Duration, success bool, fields map[string]interface{}) {
+func LogBusinessTransactionEnd(ctx context.Context, operation string, duration time.Duration, success bool, fields map[string]interface{}) {
 	if Logger == nil {
 		return
 	}
@@ -34,9 +35,9 @@ func LogBusinessTransactionEnd(operation string, duration time.Duration, success
 		args = append(args, k, v)
 	}
 	if success {
-		Logger.Infow("business transaction completed", args...)
+		WithContext(ctx).Infow("business transaction completed", args...)
 	} else {
-		Logger.Warnw("business transaction failed", args...)
+		WithContext(ctx).Warnw("business transaction failed", args...)
 	}
 }
 
@@ -4,15 +4,66 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-// ReviewerLoadDistribution отслеживает распределение нагрузки между ревьюверами
-// Показывает, сколько PR одновременно назначено каждому ревьюверу
-// Бакеты: 0, 1, 2, 3, 5, 10, 15, 20, 30, 50
-var ReviewerLoadDistribution = prometheus.NewHistogram(prometheus.HistogramOpts{
-	Name: "reviewer_load_distribution",
-	Help: "Distribution of PR assignments per reviewer (shows workload balance)",
-	Buckets: func() []float64 {
-		buckets := make([]float64, 0, 10)
-		buckets = append(buckets, 0, 1, 2, 3, 5, 10, 15, 20, 30, 50)
-		return buckets
-	}(),
-})
+// ReviewerOpenReviews число открытых PR, назначенных пользователю, — текущее состояние, а не история наблюдений.
+// Пересчитывается целиком периодически и после событий, поэтому у пользователя без ревью значение 0
+var ReviewerOpenReviews = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "reviewer_open_reviews",
+	Help: "Number of open pull requests currently assigned to the reviewer",
+}, []string{"user_id", "team"})
+
+// TeamOpenPullRequests число открытых PR, авторы которых состоят в команде
+var TeamOpenPullRequests = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "team_open_pull_requests",
+	Help: "Number of open pull requests authored by team members",
+}, []string{"team"})
+
+// TeamPullRequestsNeedMoreReviewers число открытых PR команды, которым не хватило ревьюверов
+var TeamPullRequestsNeedMoreReviewers = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "team_pull_requests_need_more_reviewers",
+	Help: "Number of open pull requests of the team waiting for more reviewers",
+}, []string{"team"})
+
+// TeamInactiveMembers число неактивных участников команды
+var TeamInactiveMembers = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "team_inactive_members",
+	Help: "Number of inactive team members",
+}, []string{"team"})
+
+// RateLimitedRequests считает запросы, отклонённые ограничителем частоты с ответом 429.
+// route — путь с собственным лимитом или "default", key_type — чем определялся клиент: actor или ip
+var RateLimitedRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "http_rate_limited_requests_total",
+	Help: "Number of requests rejected by the rate limiter",
+}, []string{"route", "key_type"})
+
+// HTTPRequestsTotal считает обработанные запросы. route — зарегистрированный маршрут или "unmatched"
+var HTTPRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "http_requests_total",
+	Help: "Number of handled HTTP requests",
+}, []string{"method", "route", "status"})
+
+// HTTPRequestDuration длительность обработки запросов в секундах
+var HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "http_request_duration_seconds",
+	Help:    "HTTP request latency in seconds",
+	Buckets: prometheus.DefBuckets,
+}, []string{"method", "route", "status"})
+
+// HTTPRequestsInFlight число запросов, обрабатываемых прямо сейчас
+var HTTPRequestsInFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "http_requests_in_flight",
+	Help: "Number of HTTP requests currently being served",
+}, []string{"route"})
+
+// DBQueryDuration длительность SQL-запросов по операции — методу репозитория, из которого выполнен запрос
+var DBQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "db_query_duration_seconds",
+	Help:    "SQL query latency in seconds by repository operation",
+	Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+}, []string{"operation"})
+
+// DBQueryErrors число SQL-запросов, завершившихся ошибкой, по операции
+var DBQueryErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "db_query_errors_total",
+	Help: "Number of failed SQL queries by repository operation",
+}, []string{"operation"})